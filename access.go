@@ -0,0 +1,33 @@
+package mcpmds
+
+import "time"
+
+// WithTrackAccess records when each markdown file was last served by the read
+// tool or as a resource, and reports it as last_read in file metadata.
+// Access times are kept in memory and reset when the server restarts.
+func WithTrackAccess(enabled bool) ServerOption {
+	return func(s *Server) {
+		s.trackAccess = enabled
+	}
+}
+
+// recordRead records that the file at path was served now.
+func (s *Server) recordRead(path string) {
+	if !s.trackAccess {
+		return
+	}
+	s.accessMu.Lock()
+	defer s.accessMu.Unlock()
+	if s.lastRead == nil {
+		s.lastRead = map[string]time.Time{}
+	}
+	s.lastRead[path] = time.Now()
+}
+
+// lastReadTime returns when the file at path was last served, if ever.
+func (s *Server) lastReadTime(path string) (time.Time, bool) {
+	s.accessMu.Lock()
+	defer s.accessMu.Unlock()
+	t, ok := s.lastRead[path]
+	return t, ok
+}
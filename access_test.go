@@ -0,0 +1,65 @@
+package mcpmds
+
+import (
+	"context"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/Warashi/go-modelcontextprotocol/mcp"
+)
+
+func Test_server_trackAccess(t *testing.T) {
+	testFS := fstest.MapFS{
+		"a.md": {Data: []byte("# A\n")},
+		"b.md": {Data: []byte("# B\n")},
+	}
+
+	lastRead := func(s *Server, path string) *time.Time {
+		t.Helper()
+		for f := range s.markdownFiles(context.Background()) {
+			if f.Path == path {
+				return f.LastRead
+			}
+		}
+		t.Fatalf("%s not listed", path)
+		return nil
+	}
+
+	s := &Server{fs: testFS}
+	WithTrackAccess(true)(s)
+
+	if got := lastRead(s, "a.md"); got != nil {
+		t.Fatalf("LastRead before any read = %v, want nil", got)
+	}
+
+	before := time.Now()
+	if _, err := s.readMarkdownFile(context.Background(), &readMarkdownFileRequest{Path: "a.md"}); err != nil {
+		t.Fatalf("readMarkdownFile() error = %v", err)
+	}
+	got := lastRead(s, "a.md")
+	if got == nil || got.Before(before) {
+		t.Errorf("LastRead after read = %v, want at or after %v", got, before)
+	}
+	if got := lastRead(s, "b.md"); got != nil {
+		t.Errorf("LastRead of unread file = %v, want nil", got)
+	}
+
+	req := &mcp.Request[mcp.ReadResourceRequestParams]{Params: mcp.ReadResourceRequestParams{URI: "file://b.md"}}
+	if _, err := s.ReadResource(context.Background(), req); err != nil {
+		t.Fatalf("ReadResource() error = %v", err)
+	}
+	if got := lastRead(s, "b.md"); got == nil {
+		t.Error("LastRead after resource read = nil, want a time")
+	}
+
+	t.Run("Disabled", func(t *testing.T) {
+		s := &Server{fs: testFS}
+		if _, err := s.readMarkdownFile(context.Background(), &readMarkdownFileRequest{Path: "a.md"}); err != nil {
+			t.Fatalf("readMarkdownFile() error = %v", err)
+		}
+		if got := lastRead(s, "a.md"); got != nil {
+			t.Errorf("LastRead = %v, want nil", got)
+		}
+	})
+}
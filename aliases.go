@@ -0,0 +1,142 @@
+package mcpmds
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"maps"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/Warashi/go-modelcontextprotocol/jsonschema"
+	"github.com/Warashi/go-modelcontextprotocol/mcp"
+)
+
+// aliasesKey is the frontmatter key declaring alternative names of a file.
+const aliasesKey = "aliases"
+
+// normalizeAlias returns the form of an alias or requested path used for
+// alias lookups, so that "old-name", "/old-name", and "old-name.md" match.
+// Only markdown extensions are stripped, so "release-1.2" stays distinct
+// from "release-1.3".
+func (s *Server) normalizeAlias(alias string) string {
+	alias = path.Clean(strings.TrimPrefix(strings.TrimSpace(alias), "/"))
+	if s.isMarkdown(alias) {
+		alias = strings.TrimSuffix(alias, path.Ext(alias))
+	}
+	return alias
+}
+
+// fileAliases returns the aliases declared in the frontmatter of f.
+func fileAliases(f markdownFileInfo) []string {
+	switch v := f.Frontmatter[aliasesKey].(type) {
+	case string:
+		return []string{v}
+	case []any:
+		aliases := make([]string, 0, len(v))
+		for _, a := range v {
+			if a, ok := a.(string); ok {
+				aliases = append(aliases, a)
+			}
+		}
+		return aliases
+	}
+	return nil
+}
+
+// aliasIndex maps each normalized alias to the path of the file declaring it.
+// It reports an error if two files declare the same alias. The index is built
+// on first use and rebuilt only when watch mode re-indexes the files.
+func (s *Server) aliasIndex(ctx context.Context) (map[string]string, error) {
+	s.aliasMu.Lock()
+	defer s.aliasMu.Unlock()
+	if s.aliasIndexCache != nil {
+		return s.aliasIndexCache, nil
+	}
+	index, err := s.buildAliasIndex(ctx)
+	if err != nil {
+		return nil, err
+	}
+	s.aliasIndexCache = index
+	return index, nil
+}
+
+// resetAliasIndex discards the cached alias index.
+func (s *Server) resetAliasIndex() {
+	s.aliasMu.Lock()
+	defer s.aliasMu.Unlock()
+	s.aliasIndexCache = nil
+}
+
+// buildAliasIndex builds the alias index from the served files.
+func (s *Server) buildAliasIndex(ctx context.Context) (map[string]string, error) {
+	index := map[string]string{}
+	for f := range s.markdownFiles(ctx) {
+		for _, alias := range fileAliases(f) {
+			key := s.normalizeAlias(alias)
+			if other, ok := index[key]; ok && other != f.Path {
+				return nil, fmt.Errorf("alias %q is declared by both %s and %s", alias, other, f.Path)
+			}
+			index[key] = f.Path
+		}
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return index, nil
+}
+
+// readMarkdownOrAlias reads the markdown file at p, falling back to the file
+// declaring p as an alias when no file exists at p.
+// It returns the canonical path of the file that was read.
+func (s *Server) readMarkdownOrAlias(ctx context.Context, p string) (string, []byte, error) {
+	p, content, err := s.readRequestedMarkdown(p)
+	if !errors.Is(err, fs.ErrNotExist) {
+		return p, content, err
+	}
+	index, aliasErr := s.aliasIndex(ctx)
+	if aliasErr != nil {
+		return "", nil, aliasErr
+	}
+	canonical, ok := index[s.normalizeAlias(p)]
+	if !ok {
+		return "", nil, err
+	}
+	content, err = s.readMarkdown(canonical)
+	return canonical, content, err
+}
+
+func (s *Server) aliasesTool() mcp.Tool[*aliasesRequest, *aliasesResponse] {
+	return mcp.NewToolFunc(
+		fmt.Sprintf("aliases_%s", s.name),
+		fmt.Sprintf("List the aliases declared in the frontmatter of markdown files managed by %s and the files they resolve to", s.name),
+		jsonschema.Object{},
+		s.aliases,
+	)
+}
+
+type aliasesRequest struct{}
+
+type aliasesResponse struct {
+	Aliases []aliasMapping `json:"aliases"`
+}
+
+// aliasMapping maps an alias to the canonical path of the file declaring it.
+type aliasMapping struct {
+	Alias string `json:"alias"`
+	Path  string `json:"path"`
+}
+
+func (s *Server) aliases(ctx context.Context, _ *aliasesRequest) (*aliasesResponse, error) {
+	index, err := s.aliasIndex(ctx)
+	if err != nil {
+		return nil, err
+	}
+	resp := &aliasesResponse{Aliases: []aliasMapping{}}
+	for _, alias := range slices.Sorted(maps.Keys(index)) {
+		resp.Aliases = append(resp.Aliases, aliasMapping{Alias: alias, Path: index[alias]})
+	}
+	return resp, nil
+}
@@ -0,0 +1,136 @@
+package mcpmds
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/Warashi/go-modelcontextprotocol/mcp"
+)
+
+func Test_server_aliases(t *testing.T) {
+	testFS := fstest.MapFS{
+		"docs/getting-started.md": {Data: []byte("---\ntitle: Getting Started\naliases: [old-name, intro/quickstart]\n---\nwelcome")},
+		"docs/api.md":             {Data: []byte("+++\naliases = \"reference\"\n+++\napi")},
+		"plain.md":                {Data: []byte("plain")},
+	}
+
+	s := &Server{fs: testFS}
+
+	t.Run("List aliases", func(t *testing.T) {
+		got, err := s.aliases(context.Background(), nil)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		want := &aliasesResponse{Aliases: []aliasMapping{
+			{Alias: "intro/quickstart", Path: "docs/getting-started.md"},
+			{Alias: "old-name", Path: "docs/getting-started.md"},
+			{Alias: "reference", Path: "docs/api.md"},
+		}}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("aliases() got = %+v, want %+v", got, want)
+		}
+	})
+
+	t.Run("Read by alias", func(t *testing.T) {
+		for _, path := range []string{"old-name", "old-name.md", "/intro/quickstart.md"} {
+			got, err := s.readMarkdownFile(context.Background(), &readMarkdownFileRequest{Path: path})
+			if err != nil {
+				t.Fatalf("readMarkdownFile(%q) unexpected error: %v", path, err)
+			}
+			if got.Path != "docs/getting-started.md" {
+				t.Errorf("readMarkdownFile(%q) path = %q, want docs/getting-started.md", path, got.Path)
+			}
+		}
+	})
+
+	t.Run("Read resource by alias", func(t *testing.T) {
+		req := &mcp.Request[mcp.ReadResourceRequestParams]{
+			Params: mcp.ReadResourceRequestParams{URI: "file://reference"},
+		}
+		got, err := s.ReadResource(context.Background(), req)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if text := got.Data.Contents[0].(mcp.TextResourceContents).Text; !strings.HasSuffix(text, "api") {
+			t.Errorf("ReadResource() text = %q, want content of docs/api.md", text)
+		}
+	})
+
+	t.Run("Unknown alias", func(t *testing.T) {
+		_, err := s.readMarkdownFile(context.Background(), &readMarkdownFileRequest{Path: "unknown"})
+		if !errors.Is(err, fs.ErrNotExist) {
+			t.Errorf("readMarkdownFile() error = %v, want fs.ErrNotExist", err)
+		}
+	})
+}
+
+func Test_New_aliasCollision(t *testing.T) {
+	testFS := fstest.MapFS{
+		"a.md": {Data: []byte("---\naliases: [shared]\n---\n")},
+		"b.md": {Data: []byte("---\naliases: [shared.md]\n---\n")},
+	}
+	_, err := New("test-server", "test description", testFS)
+	if err == nil || !strings.Contains(err.Error(), "shared") {
+		t.Fatalf("New() error = %v, want alias collision error", err)
+	}
+}
+
+func Test_New_dottedAliases(t *testing.T) {
+	testFS := fstest.MapFS{
+		"a.md": {Data: []byte("---\naliases: [release-1.2]\n---\na")},
+		"b.md": {Data: []byte("---\naliases: [release-1.3]\n---\nb")},
+	}
+	s := newServer("test", "test", testFS)
+	if _, err := s.server(); err != nil {
+		t.Fatalf("server() error = %v", err)
+	}
+	for alias, want := range map[string]string{"release-1.2": "a.md", "release-1.3": "b.md", "/release-1.3.md": "b.md"} {
+		got, err := s.readMarkdownFile(context.Background(), &readMarkdownFileRequest{Path: alias})
+		if err != nil {
+			t.Fatalf("readMarkdownFile(%q) error = %v", alias, err)
+		}
+		if got.Path != want {
+			t.Errorf("readMarkdownFile(%q) path = %q, want %q", alias, got.Path, want)
+		}
+	}
+}
+
+func Test_server_aliasIndex_cached(t *testing.T) {
+	cfs := &countingFS{FS: fstest.MapFS{
+		"a.md": {Data: []byte("---\naliases: [old]\n---\na")},
+		"b.md": {Data: []byte("b")},
+	}}
+	s := &Server{fs: cfs}
+	for range 3 {
+		if _, err := s.readMarkdownFile(context.Background(), &readMarkdownFileRequest{Path: "missing.md"}); !errors.Is(err, fs.ErrNotExist) {
+			t.Fatalf("readMarkdownFile() error = %v, want fs.ErrNotExist", err)
+		}
+	}
+	// The first miss reads both files to build the index; later misses only
+	// try to open the missing file.
+	if want := 2 + 3; cfs.opened != want {
+		t.Errorf("opened %d markdown files, want %d", cfs.opened, want)
+	}
+}
+
+func Test_server_aliasIndex_canceled(t *testing.T) {
+	s := &Server{fs: fstest.MapFS{"a.md": {Data: []byte("---\naliases: [old]\n---\na")}}}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := s.aliasIndex(ctx); !errors.Is(err, context.Canceled) {
+		t.Fatalf("aliasIndex() error = %v, want context.Canceled", err)
+	}
+	// The index of a canceled walk is incomplete and must not be cached.
+	index, err := s.aliasIndex(context.Background())
+	if err != nil {
+		t.Fatalf("aliasIndex() error = %v", err)
+	}
+	if index["old"] != "a.md" {
+		t.Errorf("aliasIndex() = %v, want old mapped to a.md", index)
+	}
+}
@@ -0,0 +1,78 @@
+package mcpmds
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"io/fs"
+	"path"
+	"strings"
+	"time"
+)
+
+// WithAllowlistFile restricts the served files to those listed in the given
+// file of the filesystem, one path per line. Blank lines and lines starting
+// with "#" are ignored. Files not listed are neither enumerated nor readable.
+// If the allowlist file does not exist, no files are served.
+// The allowlist is re-read whenever its modification time or size changes.
+func WithAllowlistFile(path string) ServerOption {
+	return func(s *Server) {
+		s.allowlistFile = path
+	}
+}
+
+// allowlist is a parsed allowlist file.
+type allowlist struct {
+	modTime time.Time
+	size    int64
+	paths   map[string]bool
+}
+
+// allowed reports whether the file at p may be served according to the allowlist.
+func (s *Server) allowed(p string) (bool, error) {
+	if s.allowlistFile == "" {
+		return true, nil
+	}
+	list, err := s.loadAllowlist()
+	if err != nil {
+		return false, err
+	}
+	return list.paths[path.Clean(p)], nil
+}
+
+// loadAllowlist returns the current allowlist, re-reading the file if it changed.
+func (s *Server) loadAllowlist() (*allowlist, error) {
+	s.allowlistMu.Lock()
+	defer s.allowlistMu.Unlock()
+
+	info, err := fs.Stat(s.fs, s.allowlistFile)
+	if errors.Is(err, fs.ErrNotExist) {
+		s.allowlist = nil
+		return &allowlist{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if s.allowlist != nil && s.allowlist.modTime.Equal(info.ModTime()) && s.allowlist.size == info.Size() {
+		return s.allowlist, nil
+	}
+
+	content, err := fs.ReadFile(s.fs, s.allowlistFile)
+	if err != nil {
+		return nil, err
+	}
+	list := &allowlist{modTime: info.ModTime(), size: info.Size(), paths: map[string]bool{}}
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		list.paths[path.Clean(strings.TrimPrefix(line, "/"))] = true
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	s.allowlist = list
+	return list, nil
+}
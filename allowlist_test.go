@@ -0,0 +1,80 @@
+package mcpmds
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"slices"
+	"testing"
+	"testing/fstest"
+	"time"
+)
+
+func Test_server_allowlistFile(t *testing.T) {
+	now := time.Now()
+	newFS := func() fstest.MapFS {
+		return fstest.MapFS{
+			"_published.txt": {Data: []byte("# published docs\npublic.md\n\n./docs/guide.md\n"), ModTime: now},
+			"public.md":      {Data: []byte("public")},
+			"private.md":     {Data: []byte("private")},
+			"docs/guide.md":  {Data: []byte("guide")},
+			"docs/draft.md":  {Data: []byte("draft")},
+		}
+	}
+
+	listed := func(t *testing.T, s *Server) []string {
+		t.Helper()
+		resp, err := s.listMarkdownFiles(context.Background(), nil)
+		if err != nil {
+			t.Fatalf("listMarkdownFiles() error = %v", err)
+		}
+		var paths []string
+		for _, f := range resp.Files {
+			paths = append(paths, f.Path)
+		}
+		return paths
+	}
+
+	t.Run("Allowed and disallowed files", func(t *testing.T) {
+		s := &Server{fs: newFS()}
+		WithAllowlistFile("_published.txt")(s)
+
+		if got, want := listed(t, s), []string{"docs/guide.md", "public.md"}; !slices.Equal(got, want) {
+			t.Errorf("listMarkdownFiles() got = %v, want %v", got, want)
+		}
+		if _, err := s.readMarkdownFile(context.Background(), &readMarkdownFileRequest{Path: "public.md"}); err != nil {
+			t.Errorf("readMarkdownFile(public.md) unexpected error = %v", err)
+		}
+		for _, path := range []string{"private.md", "docs/draft.md"} {
+			if _, err := s.readMarkdownFile(context.Background(), &readMarkdownFileRequest{Path: path}); !errors.Is(err, fs.ErrNotExist) {
+				t.Errorf("readMarkdownFile(%s) error = %v, want fs.ErrNotExist", path, err)
+			}
+		}
+	})
+
+	t.Run("Allowlist absent serves nothing", func(t *testing.T) {
+		s := &Server{fs: newFS()}
+		WithAllowlistFile("missing.txt")(s)
+
+		if got := listed(t, s); len(got) != 0 {
+			t.Errorf("listMarkdownFiles() got = %v, want none", got)
+		}
+		if _, err := s.readMarkdownFile(context.Background(), &readMarkdownFileRequest{Path: "public.md"}); !errors.Is(err, fs.ErrNotExist) {
+			t.Errorf("readMarkdownFile(public.md) error = %v, want fs.ErrNotExist", err)
+		}
+	})
+
+	t.Run("Allowlist is re-read when it changes", func(t *testing.T) {
+		fsys := newFS()
+		s := &Server{fs: fsys}
+		WithAllowlistFile("_published.txt")(s)
+
+		if got, want := listed(t, s), []string{"docs/guide.md", "public.md"}; !slices.Equal(got, want) {
+			t.Errorf("listMarkdownFiles() got = %v, want %v", got, want)
+		}
+		fsys["_published.txt"] = &fstest.MapFile{Data: []byte("private.md\n"), ModTime: now.Add(time.Second)}
+		if got, want := listed(t, s), []string{"private.md"}; !slices.Equal(got, want) {
+			t.Errorf("listMarkdownFiles() after change got = %v, want %v", got, want)
+		}
+	})
+}
@@ -0,0 +1,63 @@
+package mcpmds
+
+import (
+	"context"
+	"slices"
+
+	"github.com/Warashi/go-modelcontextprotocol/mcp"
+)
+
+// WithResourceAnnotations attaches MCP resource annotations declared in
+// frontmatter to the listed resources. The "audience" key names the intended
+// readers, "user" and/or "assistant", and the "priority" key is a number from
+// 0 (least important) to 1 (most important). Invalid values are ignored.
+func WithResourceAnnotations(enabled bool) ServerOption {
+	return func(s *Server) {
+		s.resourceAnnotations = enabled
+	}
+}
+
+// resourceAnnotations are the MCP annotations of a resource.
+type resourceAnnotations struct {
+	Audience []string `json:"audience,omitempty"`
+	Priority *float64 `json:"priority,omitempty"`
+}
+
+// annotatedResource is an mcp.Resource with annotations and _meta, which
+// mcp.Resource lacks.
+type annotatedResource struct {
+	mcp.Resource
+	Annotations *resourceAnnotations `json:"annotations,omitempty"`
+	Meta        map[string]any       `json:"_meta,omitempty"`
+}
+
+// annotatedResourceList is the result of resources/list with annotations.
+type annotatedResourceList struct {
+	Resources []annotatedResource `json:"resources"`
+}
+
+// frontmatterAnnotations returns the resource annotations declared in
+// frontmatter, or nil if there are none.
+func frontmatterAnnotations(frontmatter map[string]any) *resourceAnnotations {
+	var a resourceAnnotations
+	for _, role := range stringList(frontmatter["audience"]) {
+		if (role == "user" || role == "assistant") && !slices.Contains(a.Audience, role) {
+			a.Audience = append(a.Audience, role)
+		}
+	}
+	if p, ok := frontmatterNumber(frontmatter["priority"]); ok && p >= 0 && p <= 1 {
+		a.Priority = &p
+	}
+	if a.Audience == nil && a.Priority == nil {
+		return nil
+	}
+	return &a
+}
+
+// annotatedResourcesOption returns an option replacing the resources/list
+// handler with one that reports the given resources with their annotations.
+func annotatedResourcesOption(resources []annotatedResource) mcp.ServerOption {
+	return mcp.WithCustomHandlerFunc("resources/list", func(ctx context.Context, _ *mcp.Request[mcp.ListResourcesRequestParams]) (*mcp.Result[annotatedResourceList], error) {
+		return &mcp.Result[annotatedResourceList]{Data: annotatedResourceList{Resources: resources}}, nil
+	})
+}
@@ -0,0 +1,115 @@
+package mcpmds
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/Warashi/go-modelcontextprotocol/mcp"
+	"github.com/Warashi/go-modelcontextprotocol/transport"
+)
+
+// callMCP sends a single JSON-RPC request to srv over an in-memory transport
+// and returns the result of the response.
+func callMCP(t *testing.T, srv *mcp.Server, method string, params any) json.RawMessage {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	server, client := transport.NewPipe()
+	go srv.Serve(ctx, 1, server)
+	defer client.Close()
+
+	req, err := json.Marshal(map[string]any{"jsonrpc": "2.0", "id": 1, "method": method, "params": params})
+	if err != nil {
+		t.Fatalf("marshal request: %v", err)
+	}
+	go client.Send(req)
+	for msg := range client.Receive() {
+		var resp struct {
+			Result json.RawMessage `json:"result"`
+			Error  json.RawMessage `json:"error"`
+		}
+		if err := json.Unmarshal(msg, &resp); err != nil {
+			t.Fatalf("unmarshal response: %v", err)
+		}
+		if resp.Error != nil {
+			t.Fatalf("%s returned error: %s", method, resp.Error)
+		}
+		return resp.Result
+	}
+	t.Fatalf("%s: no response", method)
+	return nil
+}
+
+func Test_server_resourceAnnotations(t *testing.T) {
+	testFS := fstest.MapFS{
+		"both.md":    {Data: []byte("---\naudience: [assistant, user]\npriority: 0.8\n---\n")},
+		"single.md":  {Data: []byte("---\naudience: user\n---\n")},
+		"invalid.md": {Data: []byte("---\naudience: [robots]\npriority: 7\n---\n")},
+		"plain.md":   {Data: []byte("# Plain\n")},
+	}
+
+	type resource struct {
+		URI         string         `json:"uri"`
+		Annotations map[string]any `json:"annotations"`
+	}
+
+	tests := []struct {
+		name string
+		opts []ServerOption
+		want map[string]map[string]any
+	}{
+		{
+			name: "Enabled",
+			opts: []ServerOption{WithResourceAnnotations(true)},
+			want: map[string]map[string]any{
+				"file://both.md":    {"audience": []any{"assistant", "user"}, "priority": 0.8},
+				"file://single.md":  {"audience": []any{"user"}},
+				"file://invalid.md": nil,
+				"file://plain.md":   nil,
+			},
+		},
+		{
+			name: "Disabled",
+			want: map[string]map[string]any{
+				"file://both.md":    nil,
+				"file://single.md":  nil,
+				"file://invalid.md": nil,
+				"file://plain.md":   nil,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv, err := New("test", "test", testFS, tt.opts...)
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+			var got struct {
+				Resources []resource `json:"resources"`
+			}
+			if err := json.Unmarshal(callMCP(t, srv, "resources/list", map[string]any{}), &got); err != nil {
+				t.Fatalf("unmarshal: %v", err)
+			}
+			if len(got.Resources) != len(tt.want) {
+				t.Fatalf("got %d resources, want %d", len(got.Resources), len(tt.want))
+			}
+			for _, r := range got.Resources {
+				want, ok := tt.want[r.URI]
+				if !ok {
+					t.Errorf("unexpected resource %s", r.URI)
+					continue
+				}
+				gotJSON, _ := json.Marshal(r.Annotations)
+				wantJSON, _ := json.Marshal(want)
+				if string(gotJSON) != string(wantJSON) {
+					t.Errorf("annotations of %s = %s, want %s", r.URI, gotJSON, wantJSON)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,80 @@
+package mcpmds
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/Warashi/go-modelcontextprotocol/jsonschema"
+	"github.com/Warashi/go-modelcontextprotocol/mcp"
+)
+
+func (s *Server) byAuthorTool() mcp.Tool[*byAuthorRequest, *byAuthorResponse] {
+	return mcp.NewToolFunc(
+		fmt.Sprintf("by_author_%s", s.name),
+		fmt.Sprintf("List markdown files managed by %s grouped by the author or authors in their frontmatter", s.name),
+		jsonschema.Object{
+			Properties: map[string]jsonschema.Schema{
+				"author": jsonschema.String{
+					Description: "Only return the files of this author, compared case-insensitively",
+				},
+			},
+		},
+		s.byAuthor,
+	)
+}
+
+type byAuthorRequest struct {
+	Author string `json:"author"`
+}
+
+type byAuthorResponse struct {
+	Authors []authorFiles `json:"authors"`
+}
+
+// authorFiles are the files written by an author.
+type authorFiles struct {
+	// Author is the author's name as written in the frontmatter.
+	Author string `json:"author"`
+	// Count is the number of files the author wrote.
+	Count int `json:"count"`
+	// Files are the paths of the files the author wrote.
+	Files []string `json:"files"`
+}
+
+// frontmatterAuthors returns the authors named in the "author" and "authors"
+// keys of frontmatter, without duplicates.
+func frontmatterAuthors(frontmatter map[string]any) []string {
+	var authors []string
+	for _, author := range slices.Concat(stringList(frontmatter["author"]), stringList(frontmatter["authors"])) {
+		if author = strings.TrimSpace(author); author != "" && !slices.Contains(authors, author) {
+			authors = append(authors, author)
+		}
+	}
+	return authors
+}
+
+func (s *Server) byAuthor(ctx context.Context, request *byAuthorRequest) (*byAuthorResponse, error) {
+	groups := map[string]*authorFiles{}
+	for f := range s.markdownFiles(ctx) {
+		for _, author := range frontmatterAuthors(f.Frontmatter) {
+			if request.Author != "" && !strings.EqualFold(author, request.Author) {
+				continue
+			}
+			g, ok := groups[author]
+			if !ok {
+				g = &authorFiles{Author: author}
+				groups[author] = g
+			}
+			g.Count++
+			g.Files = append(g.Files, f.Path)
+		}
+	}
+	resp := &byAuthorResponse{Authors: []authorFiles{}}
+	for _, author := range slices.Sorted(maps.Keys(groups)) {
+		resp.Authors = append(resp.Authors, *groups[author])
+	}
+	return resp, nil
+}
@@ -0,0 +1,58 @@
+package mcpmds
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"testing/fstest"
+)
+
+func Test_server_byAuthor(t *testing.T) {
+	testFS := fstest.MapFS{
+		"a.md": {Data: []byte("---\nauthor: Jane\n---\n")},
+		"b.md": {Data: []byte("---\nauthors: [Jane, Bob]\n---\n")},
+		"c.md": {Data: []byte("+++\nauthors = [\"Bob\"]\n+++\n")},
+		"d.md": {Data: []byte("# No author\n")},
+	}
+
+	s := &Server{fs: testFS}
+
+	tests := []struct {
+		name    string
+		request *byAuthorRequest
+		want    []authorFiles
+	}{
+		{
+			name:    "All authors",
+			request: &byAuthorRequest{},
+			want: []authorFiles{
+				{Author: "Bob", Count: 2, Files: []string{"b.md", "c.md"}},
+				{Author: "Jane", Count: 2, Files: []string{"a.md", "b.md"}},
+			},
+		},
+		{
+			name:    "Author filter",
+			request: &byAuthorRequest{Author: "jane"},
+			want: []authorFiles{
+				{Author: "Jane", Count: 2, Files: []string{"a.md", "b.md"}},
+			},
+		},
+		{
+			name:    "Unknown author",
+			request: &byAuthorRequest{Author: "Alice"},
+			want:    []authorFiles{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := s.byAuthor(context.Background(), tt.request)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got.Authors, tt.want) {
+				t.Errorf("byAuthor() = %#v, want %#v", got.Authors, tt.want)
+			}
+		})
+	}
+}
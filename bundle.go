@@ -0,0 +1,117 @@
+package mcpmds
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/Warashi/go-modelcontextprotocol/jsonschema"
+	"github.com/Warashi/go-modelcontextprotocol/mcp"
+)
+
+// defaultBundleMaxBytes is the size cap of a bundle when none is given.
+const defaultBundleMaxBytes = 100_000
+
+// bundleTruncatedMarker ends a bundle that was cut at the size cap.
+const bundleTruncatedMarker = "\n\n[truncated]\n"
+
+// bundleBuilder concatenates markdown files into a single document, each
+// preceded by a header naming its path, up to a size cap.
+type bundleBuilder struct {
+	maxBytes  int
+	b         strings.Builder
+	files     []string
+	truncated bool
+}
+
+// add appends the file at path to the bundle, cutting it short if the cap is
+// reached. It reports false once the bundle is full.
+func (b *bundleBuilder) add(path string, content []byte) bool {
+	if b.truncated {
+		return false
+	}
+	section := fmt.Sprintf("## %s\n\n%s", path, content)
+	if !strings.HasSuffix(section, "\n") {
+		section += "\n"
+	}
+	if b.b.Len() > 0 {
+		section = "\n" + section
+	}
+	if remaining := b.maxBytes - b.b.Len(); len(section) > remaining {
+		cut := max(remaining, 0)
+		for cut > 0 && !utf8.RuneStart(section[cut]) {
+			cut--
+		}
+		section = section[:cut]
+		b.truncated = true
+	}
+	if section != "" {
+		b.b.WriteString(section)
+		b.files = append(b.files, path)
+	}
+	if b.truncated {
+		b.b.WriteString(bundleTruncatedMarker)
+	}
+	return !b.truncated
+}
+
+func (s *Server) bundleByTagTool() mcp.Tool[*bundleByTagRequest, *bundleResponse] {
+	return mcp.NewToolFunc(
+		fmt.Sprintf("bundle_by_tag_%s", s.name),
+		fmt.Sprintf("Return a single document concatenating all markdown files managed by %s that carry a tag, each preceded by its path", s.name),
+		jsonschema.Object{
+			Properties: map[string]jsonschema.Schema{
+				"tag": jsonschema.String{
+					Description: "The tag the files must carry in their frontmatter tags",
+				},
+				"max_bytes": jsonschema.Integer{
+					Description: fmt.Sprintf("The size cap of the bundle in bytes; defaults to %d", defaultBundleMaxBytes),
+				},
+			},
+			Required: []string{"tag"},
+		},
+		s.bundleByTag,
+	)
+}
+
+type bundleByTagRequest struct {
+	Tag      string `json:"tag" jsonschema:"required"`
+	MaxBytes int    `json:"max_bytes"`
+}
+
+type bundleResponse struct {
+	// Files are the paths of the files included in the bundle, fully or partially.
+	Files []string `json:"files"`
+	// Truncated reports whether the bundle was cut at the size cap.
+	Truncated bool `json:"truncated"`
+	// Content is the concatenated document.
+	Content string `json:"content"`
+}
+
+func (s *Server) bundleByTag(ctx context.Context, request *bundleByTagRequest) (*bundleResponse, error) {
+	if request.Tag == "" {
+		return nil, errors.New("tag is required")
+	}
+	maxBytes := request.MaxBytes
+	if maxBytes <= 0 {
+		maxBytes = defaultBundleMaxBytes
+	}
+	tag := s.normalizeTag(request.Tag)
+	b := &bundleBuilder{maxBytes: maxBytes, files: []string{}}
+	for f := range s.markdownFiles(ctx) {
+		if !slices.Contains(s.frontmatterTags(f.Frontmatter), tag) {
+			continue
+		}
+		content, err := s.readMarkdown(f.Path)
+		if err != nil {
+			return nil, err
+		}
+		if !b.add(f.Path, s.renderContent(f.Path, content)) {
+			break
+		}
+	}
+	return &bundleResponse{Files: b.files, Truncated: b.truncated, Content: b.b.String()}, nil
+}
@@ -0,0 +1,53 @@
+package mcpmds
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func Test_server_bundleByTag(t *testing.T) {
+	testFS := fstest.MapFS{
+		"a.md": {Data: []byte("---\ntags: [k8s]\n---\n# A\n")},
+		"b.md": {Data: []byte("---\ntags: [go]\n---\n# B\n")},
+		"c.md": {Data: []byte("---\ntags: k8s\n---\n# C\n")},
+	}
+
+	s := &Server{fs: testFS}
+
+	t.Run("Only tagged files", func(t *testing.T) {
+		got, err := s.bundleByTag(context.Background(), &bundleByTagRequest{Tag: "k8s"})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		want := "## a.md\n\n---\ntags: [k8s]\n---\n# A\n\n## c.md\n\n---\ntags: k8s\n---\n# C\n"
+		if got.Content != want {
+			t.Errorf("Content = %q, want %q", got.Content, want)
+		}
+		if !slices.Equal(got.Files, []string{"a.md", "c.md"}) || got.Truncated {
+			t.Errorf("Files = %v, Truncated = %v", got.Files, got.Truncated)
+		}
+	})
+
+	t.Run("Size cap truncates", func(t *testing.T) {
+		got, err := s.bundleByTag(context.Background(), &bundleByTagRequest{Tag: "k8s", MaxBytes: 40})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !got.Truncated {
+			t.Error("Truncated = false, want true")
+		}
+		if !slices.Equal(got.Files, []string{"a.md", "c.md"}) {
+			t.Errorf("Files = %v, want [a.md c.md]", got.Files)
+		}
+		content, ok := strings.CutSuffix(got.Content, bundleTruncatedMarker)
+		if !ok {
+			t.Fatalf("Content = %q, want truncation marker", got.Content)
+		}
+		if len(content) != 40 {
+			t.Errorf("len(content) = %d, want 40", len(content))
+		}
+	})
+}
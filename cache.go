@@ -0,0 +1,120 @@
+package mcpmds
+
+import (
+	"context"
+	"io/fs"
+	"maps"
+	"slices"
+	"time"
+)
+
+// WithCache caches the metadata and parsed frontmatter of each markdown file,
+// keyed by its path, so that listing tools do not re-read and re-parse files
+// that have not changed. An entry is reused while the file's modification
+// time and size, and the global frontmatter defaults, are unchanged.
+func WithCache(enabled bool) ServerOption {
+	return func(s *Server) {
+		s.cache = enabled
+	}
+}
+
+// cachedFile is the cached metadata of a markdown file.
+type cachedFile struct {
+	modTime  time.Time
+	size     int64
+	defaults *globalDefaults
+	info     markdownFileInfo
+}
+
+// readCachedMarkdownInfo returns the info of a file found during the walk,
+// from the cache when enabled and the file is unchanged.
+func (s *Server) readCachedMarkdownInfo(ctx context.Context, path string, d fs.DirEntry) (markdownFileInfo, error) {
+	if !s.cache {
+		return s.readWalkedMarkdownInfo(ctx, path, d)
+	}
+	stat, err := d.Info()
+	if err != nil {
+		return markdownFileInfo{}, err
+	}
+	defaults, err := s.currentGlobalDefaults()
+	if err != nil {
+		return markdownFileInfo{}, err
+	}
+	if info, ok := s.cachedMarkdownInfo(path, stat, defaults); ok {
+		if s.trackAccess {
+			info.LastRead = nil
+			if lastRead, ok := s.lastReadTime(path); ok {
+				info.LastRead = &lastRead
+			}
+		}
+		return info, nil
+	}
+	info, err := s.readWalkedMarkdownInfo(ctx, path, d)
+	if err != nil {
+		return info, err
+	}
+	s.cacheMu.Lock()
+	defer s.cacheMu.Unlock()
+	if s.cachedFiles == nil {
+		s.cachedFiles = map[string]cachedFile{}
+	}
+	s.cachedFiles[path] = cachedFile{modTime: stat.ModTime(), size: stat.Size(), defaults: defaults, info: cloneMarkdownFileInfo(info)}
+	return info, nil
+}
+
+// cachedMarkdownInfo returns a copy of the cached info of the file at path if
+// the cache entry matches stat and the global defaults.
+func (s *Server) cachedMarkdownInfo(path string, stat fs.FileInfo, defaults *globalDefaults) (markdownFileInfo, bool) {
+	s.cacheMu.Lock()
+	defer s.cacheMu.Unlock()
+	entry, ok := s.cachedFiles[path]
+	if !ok || !entry.modTime.Equal(stat.ModTime()) || entry.size != stat.Size() || entry.defaults != defaults {
+		return markdownFileInfo{}, false
+	}
+	return cloneMarkdownFileInfo(entry.info), true
+}
+
+// currentGlobalDefaults returns the loaded global defaults, reloading them if
+// the file changed, or nil if there are none.
+func (s *Server) currentGlobalDefaults() (*globalDefaults, error) {
+	if s.globalDefaultsFile == "" {
+		return nil, nil
+	}
+	if _, err := s.loadGlobalDefaults(); err != nil {
+		return nil, err
+	}
+	s.globalDefaultsMu.Lock()
+	defer s.globalDefaultsMu.Unlock()
+	return s.globalDefaults, nil
+}
+
+// cloneMarkdownFileInfo returns a copy of info that shares no mutable state
+// with it, so that callers may modify either.
+func cloneMarkdownFileInfo(info markdownFileInfo) markdownFileInfo {
+	if info.Frontmatter != nil {
+		info.Frontmatter = cloneValue(info.Frontmatter).(map[string]any)
+	}
+	info.Duplicates = slices.Clone(info.Duplicates)
+	info.Wikilinks = slices.Clone(info.Wikilinks)
+	info.frontmatterKeys = slices.Clone(info.frontmatterKeys)
+	return info
+}
+
+// cloneValue returns a deep copy of a frontmatter value.
+func cloneValue(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		m := maps.Clone(v)
+		for key, value := range m {
+			m[key] = cloneValue(value)
+		}
+		return m
+	case []any:
+		l := slices.Clone(v)
+		for i, value := range l {
+			l[i] = cloneValue(value)
+		}
+		return l
+	}
+	return v
+}
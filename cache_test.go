@@ -0,0 +1,122 @@
+package mcpmds
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"testing/fstest"
+	"time"
+)
+
+func TestWithCache(t *testing.T) {
+	now := time.Now()
+	testFS := fstest.MapFS{
+		"a.md":     {Data: []byte("---\ntitle: A\n---\nbody"), ModTime: now},
+		"dir/b.md": {Data: []byte("---\ntitle: B\n---\nbody"), ModTime: now},
+	}
+	cfs := &countingFS{FS: testFS}
+	s := newServer("test", "test", cfs, WithCache(true))
+
+	titles := func(t *testing.T) map[string]any {
+		t.Helper()
+		resp, err := s.listMarkdownFiles(context.Background(), &listMarkdownFilesRequest{})
+		if err != nil {
+			t.Fatalf("listMarkdownFiles() error = %v", err)
+		}
+		got := map[string]any{}
+		for _, f := range resp.Files {
+			got[f.Path] = f.Frontmatter["title"]
+			// Modifying a returned value must not affect the cache.
+			f.Frontmatter["title"] = "modified"
+		}
+		return got
+	}
+
+	if got := titles(t); got["a.md"] != "A" || got["dir/b.md"] != "B" {
+		t.Fatalf("first listing titles = %v, want A and B", got)
+	}
+	if cfs.opened != 2 {
+		t.Fatalf("first listing opened %d files, want 2", cfs.opened)
+	}
+
+	if got := titles(t); got["a.md"] != "A" || got["dir/b.md"] != "B" {
+		t.Errorf("cached listing titles = %v, want A and B", got)
+	}
+	if cfs.opened != 2 {
+		t.Errorf("cached listing opened %d files in total, want 2", cfs.opened)
+	}
+
+	// Content changed without a new modification time or size is not noticed.
+	testFS["a.md"].Data = []byte("---\ntitle: X\n---\nbody")
+	if got := titles(t); got["a.md"] != "A" {
+		t.Errorf("title of a.md with unchanged mod time = %v, want cached A", got["a.md"])
+	}
+
+	testFS["a.md"].ModTime = now.Add(time.Second)
+	if got := titles(t); got["a.md"] != "X" || got["dir/b.md"] != "B" {
+		t.Errorf("titles after mod time change = %v, want X and B", got)
+	}
+	if cfs.opened != 3 {
+		t.Errorf("opened %d files in total after one file changed, want 3", cfs.opened)
+	}
+
+	testFS["dir/b.md"].Data = []byte("---\ntitle: Longer\n---\nbody")
+	if got := titles(t); got["dir/b.md"] != "Longer" {
+		t.Errorf("title of dir/b.md after size change = %v, want Longer", got["dir/b.md"])
+	}
+}
+
+func TestWithCache_globalDefaults(t *testing.T) {
+	now := time.Now()
+	testFS := fstest.MapFS{
+		"_defaults.md": {Data: []byte("---\nauthor: alice\n---\n"), ModTime: now},
+		"a.md":         {Data: []byte("---\ntitle: A\n---\n"), ModTime: now},
+	}
+	s := newServer("test", "test", testFS, WithCache(true), WithGlobalFrontmatterDefaults("_defaults.md"))
+	author := func() any {
+		t.Helper()
+		resp, err := s.listMarkdownFiles(context.Background(), &listMarkdownFilesRequest{})
+		if err != nil {
+			t.Fatalf("listMarkdownFiles() error = %v", err)
+		}
+		for _, f := range resp.Files {
+			if f.Path == "a.md" {
+				return f.Frontmatter["author"]
+			}
+		}
+		t.Fatal("a.md not listed")
+		return nil
+	}
+	if got := author(); got != "alice" {
+		t.Fatalf("author = %v, want alice", got)
+	}
+	testFS["_defaults.md"] = &fstest.MapFile{Data: []byte("---\nauthor: bob\n---\n"), ModTime: now.Add(time.Second)}
+	if got := author(); got != "bob" {
+		t.Errorf("author after defaults changed = %v, want bob", got)
+	}
+}
+
+func BenchmarkListMarkdownFiles(b *testing.B) {
+	testFS := fstest.MapFS{}
+	for i := range 500 {
+		testFS[fmt.Sprintf("dir%d/file%d.md", i%10, i)] = &fstest.MapFile{
+			Data: []byte(fmt.Sprintf("---\ntitle: File %d\ntags: [a, b]\n---\n# File %d\n\nSome body text.\n", i, i)),
+		}
+	}
+	for _, bm := range []struct {
+		name string
+		opts []ServerOption
+	}{
+		{name: "uncached"},
+		{name: "cached", opts: []ServerOption{WithCache(true)}},
+	} {
+		b.Run(bm.name, func(b *testing.B) {
+			s := newServer("bench", "bench", testFS, bm.opts...)
+			for b.Loop() {
+				if _, err := s.listMarkdownFiles(context.Background(), &listMarkdownFilesRequest{}); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package mcpmds
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+)
+
+// WithCanonicalPaths makes tools and resources/read accept paths in any
+// equivalent spelling, such as "./dir/file.md", "dir//file.md", or
+// "dir\file.md" for "dir/file.md". Request paths are cleaned with path.Clean,
+// with backslashes taken as separators, and responses report the canonical
+// form. Paths that are absolute or leave the served directory are rejected.
+func WithCanonicalPaths(enabled bool) ServerOption {
+	return func(s *Server) {
+		s.canonicalPaths = enabled
+	}
+}
+
+// requestPath returns a path given in a request, in canonical form when
+// enabled by WithCanonicalPaths. An empty path is returned unchanged so that
+// tools can apply their defaults.
+func (s *Server) requestPath(p string) (string, error) {
+	if !s.canonicalPaths || p == "" {
+		return p, nil
+	}
+	clean, err := cleanRelativePath(strings.ReplaceAll(p, `\`, "/"))
+	if err != nil {
+		return "", fmt.Errorf("invalid path %q: %w", p, err)
+	}
+	return clean, nil
+}
+
+// readRequestedMarkdown reads the markdown file at a path given in a request,
+// returning the path in the form requestPath gives it along with the content.
+func (s *Server) readRequestedMarkdown(p string) (string, []byte, error) {
+	p, err := s.requestPath(p)
+	if err != nil {
+		return "", nil, err
+	}
+	content, err := s.readMarkdown(p)
+	return p, content, err
+}
+
+// cleanRelativePath returns p cleaned with path.Clean, rejecting paths that
+// are absolute or leave the directory they are relative to.
+func cleanRelativePath(p string) (string, error) {
+	if strings.HasPrefix(p, "/") {
+		return "", errors.New("must be relative")
+	}
+	p = path.Clean(p)
+	if p == ".." || strings.HasPrefix(p, "../") {
+		return "", errors.New("must not leave the served directory")
+	}
+	return p, nil
+}
@@ -0,0 +1,68 @@
+package mcpmds
+
+import (
+	"context"
+	"testing"
+	"testing/fstest"
+
+	"github.com/Warashi/go-modelcontextprotocol/mcp"
+)
+
+func TestWithCanonicalPaths(t *testing.T) {
+	testFS := fstest.MapFS{
+		"dir/file.md": {Data: []byte("---\ntitle: File\n---\n# File\n\n- [ ] task\n")},
+	}
+	s := newServer("test", "test", testFS, WithCanonicalPaths(true))
+
+	for _, p := range []string{"dir/file.md", "./dir/file.md", "dir//file.md", "dir/./file.md", "other/../dir/file.md", `dir\file.md`} {
+		t.Run(p, func(t *testing.T) {
+			read, err := s.readMarkdownFile(context.Background(), &readMarkdownFileRequest{Path: p})
+			if err != nil {
+				t.Fatalf("readMarkdownFile() error = %v", err)
+			}
+			if read.Path != "dir/file.md" {
+				t.Errorf("readMarkdownFile() path = %q, want %q", read.Path, "dir/file.md")
+			}
+
+			request := &tasksMarkdownFileRequest{Path: p}
+			tasks, err := s.tasksMarkdownFile(context.Background(), request)
+			if err != nil {
+				t.Fatalf("tasksMarkdownFile() error = %v", err)
+			}
+			if tasks.Path != "dir/file.md" {
+				t.Errorf("tasksMarkdownFile() path = %q, want %q", tasks.Path, "dir/file.md")
+			}
+			if request.Path != p {
+				t.Errorf("tasksMarkdownFile() changed the request path to %q", request.Path)
+			}
+
+			unseen, err := s.unseen(context.Background(), &unseenRequest{Seen: []string{p}})
+			if err != nil {
+				t.Fatalf("unseen() error = %v", err)
+			}
+			if len(unseen.Files) != 0 {
+				t.Errorf("unseen() = %+v, want none", unseen.Files)
+			}
+
+			got, err := s.ReadResource(context.Background(), &mcp.Request[mcp.ReadResourceRequestParams]{
+				Params: mcp.ReadResourceRequestParams{URI: "file://" + p},
+			})
+			if err != nil {
+				t.Fatalf("ReadResource() error = %v", err)
+			}
+			if len(got.Data.Contents) != 1 {
+				t.Errorf("ReadResource() contents = %+v, want one item", got.Data.Contents)
+			}
+		})
+	}
+
+	for _, p := range []string{"../dir/file.md", "/dir/file.md", `..\dir\file.md`} {
+		if _, err := s.readMarkdownFile(context.Background(), &readMarkdownFileRequest{Path: p}); err == nil {
+			t.Errorf("readMarkdownFile(%q) error = nil, want error", p)
+		}
+	}
+
+	if _, err := newServer("test", "test", testFS).readMarkdownFile(context.Background(), &readMarkdownFileRequest{Path: "./dir/file.md"}); err == nil {
+		t.Error("readMarkdownFile(./dir/file.md) without WithCanonicalPaths error = nil, want error")
+	}
+}
@@ -0,0 +1,82 @@
+package mcpmds
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	"github.com/Warashi/go-modelcontextprotocol/jsonschema"
+	"github.com/Warashi/go-modelcontextprotocol/mcp"
+)
+
+func (s *Server) capabilitiesTool() mcp.Tool[*capabilitiesRequest, *capabilitiesResponse] {
+	return mcp.NewToolFunc(
+		fmt.Sprintf("capabilities_%s", s.name),
+		fmt.Sprintf("Describe the active configuration of %s: frontmatter formats, file extensions, enabled features, and available tools", s.name),
+		jsonschema.Object{},
+		s.capabilities,
+	)
+}
+
+type capabilitiesRequest struct{}
+
+type capabilitiesResponse struct {
+	// FrontmatterFormats are the names of the recognized frontmatter formats.
+	FrontmatterFormats []string `json:"frontmatter_formats"`
+	// Extensions are the file extensions served as markdown.
+	Extensions []string `json:"extensions"`
+	// Watch reports whether the filesystem is watched for changes.
+	Watch bool `json:"watch"`
+	// Cache reports whether parsed files are cached.
+	Cache bool `json:"cache"`
+	// ExcludedFrontmatter are the frontmatter keys removed from responses.
+	ExcludedFrontmatter []string `json:"excluded_frontmatter"`
+	// RedactedFrontmatter are the frontmatter keys whose values are masked.
+	RedactedFrontmatter []string `json:"redacted_frontmatter"`
+	// HideDraftsKey is the frontmatter key marking hidden drafts, if drafts are hidden.
+	HideDraftsKey string `json:"hide_drafts_key,omitempty"`
+	// ReadOnlyEnumerated reports whether reads are limited to enumerated files.
+	ReadOnlyEnumerated bool `json:"read_only_enumerated"`
+	// Tools are the names of the available tools.
+	Tools []string `json:"tools"`
+}
+
+func (s *Server) capabilities(ctx context.Context, _ *capabilitiesRequest) (*capabilitiesResponse, error) {
+	resp := &capabilitiesResponse{
+		FrontmatterFormats:  []string{},
+		Extensions:          s.fileExtensions(),
+		ExcludedFrontmatter: append([]string{}, s.excludeFrontmatter...),
+		RedactedFrontmatter: append([]string{}, s.redactFrontmatter...),
+		HideDraftsKey:       s.hideDraftsKey,
+		ReadOnlyEnumerated:  s.readOnlyEnumerated,
+		Watch:               s.watch,
+		Cache:               s.cache,
+		Tools:               []string{},
+	}
+	for _, f := range s.frontmatterFormats() {
+		if !slices.Contains(resp.FrontmatterFormats, f.Name) {
+			resp.FrontmatterFormats = append(resp.FrontmatterFormats, f.Name)
+		}
+	}
+	if s.mcpServer != nil {
+		result, err := s.mcpServer.ListTools(ctx, nil)
+		if err != nil {
+			return nil, err
+		}
+		for _, t := range result.Data.Tools {
+			b, err := json.Marshal(t)
+			if err != nil {
+				return nil, err
+			}
+			var tool struct {
+				Name string `json:"name"`
+			}
+			if err := json.Unmarshal(b, &tool); err != nil {
+				return nil, err
+			}
+			resp.Tools = append(resp.Tools, tool.Name)
+		}
+	}
+	return resp, nil
+}
@@ -0,0 +1,52 @@
+package mcpmds
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"testing/fstest"
+)
+
+func Test_server_capabilities(t *testing.T) {
+	testFS := fstest.MapFS{
+		"a.md": {Data: []byte("# A\n")},
+	}
+
+	s := newServer("docs", "test", testFS,
+		WithExcludeFrontmatter("internal"),
+		WithRedactFrontmatter("token"),
+		WithHideDrafts(""),
+		WithReadOnlyEnumerated(true),
+	)
+	if _, err := s.server(); err != nil {
+		t.Fatalf("server() error = %v", err)
+	}
+
+	got, err := s.capabilities(context.Background(), &capabilitiesRequest{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !slices.Equal(got.FrontmatterFormats, []string{"yaml", "toml", "json"}) {
+		t.Errorf("FrontmatterFormats = %v", got.FrontmatterFormats)
+	}
+	if !slices.Equal(got.Extensions, []string{".md"}) {
+		t.Errorf("Extensions = %v", got.Extensions)
+	}
+	if !slices.Equal(got.ExcludedFrontmatter, []string{"internal"}) || !slices.Equal(got.RedactedFrontmatter, []string{"token"}) {
+		t.Errorf("ExcludedFrontmatter = %v, RedactedFrontmatter = %v", got.ExcludedFrontmatter, got.RedactedFrontmatter)
+	}
+	if got.HideDraftsKey != "draft" || !got.ReadOnlyEnumerated {
+		t.Errorf("HideDraftsKey = %q, ReadOnlyEnumerated = %v", got.HideDraftsKey, got.ReadOnlyEnumerated)
+	}
+	if got.Watch || got.Cache {
+		t.Errorf("Watch = %v, Cache = %v, want false", got.Watch, got.Cache)
+	}
+	for _, tool := range []string{"list_docs_markdown_files", "read_docs_markdown_file", "capabilities_docs"} {
+		if !slices.Contains(got.Tools, tool) {
+			t.Errorf("Tools = %v, want it to contain %s", got.Tools, tool)
+		}
+	}
+	if !slices.IsSorted(got.Tools) {
+		t.Errorf("Tools = %v, want sorted", got.Tools)
+	}
+}
@@ -0,0 +1,130 @@
+package mcpmds
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/Warashi/go-modelcontextprotocol/jsonschema"
+	"github.com/Warashi/go-modelcontextprotocol/mcp"
+)
+
+// defaultChangelogPath is the changelog parsed when no path is given.
+const defaultChangelogPath = "CHANGELOG.md"
+
+func (s *Server) changelogTool() mcp.Tool[*changelogRequest, *changelogResponse] {
+	return mcp.NewToolFunc(
+		fmt.Sprintf("changelog_%s", s.name),
+		fmt.Sprintf("Parse a Keep a Changelog formatted markdown file managed by %s into releases with their dates and entries", s.name),
+		jsonschema.Object{
+			Properties: map[string]jsonschema.Schema{
+				"path": jsonschema.String{
+					Description: "The path to the changelog; defaults to CHANGELOG.md",
+				},
+				"version": jsonschema.String{
+					Description: "Only return the release with this version, such as 1.2.0 or Unreleased",
+				},
+			},
+		},
+		s.changelog,
+	)
+}
+
+type changelogRequest struct {
+	Path    string `json:"path"`
+	Version string `json:"version"`
+}
+
+type changelogResponse struct {
+	Path     string             `json:"path"`
+	Releases []changelogRelease `json:"releases"`
+}
+
+// changelogRelease is a single release section of a changelog.
+type changelogRelease struct {
+	// Version is the released version, or "Unreleased".
+	Version string `json:"version"`
+	// Date is the release date as written, if any.
+	Date string `json:"date,omitempty"`
+	// Unreleased reports whether the section collects unreleased changes.
+	Unreleased bool `json:"unreleased"`
+	// Entries are the bullet entries not grouped under a change type.
+	Entries []string `json:"entries,omitempty"`
+	// Sections are the entries grouped by change type, such as Added or Fixed.
+	Sections []changelogSection `json:"sections,omitempty"`
+}
+
+// changelogSection groups the entries of a release by change type.
+type changelogSection struct {
+	Name    string   `json:"name"`
+	Entries []string `json:"entries"`
+}
+
+var (
+	changelogReleasePattern = regexp.MustCompile(`^##\s+\[?([^\]\s]+)\]?(?:\s+-\s+(\S+))?`)
+	changelogSectionPattern = regexp.MustCompile(`^###\s+(.+?)\s*$`)
+	changelogEntryPattern   = regexp.MustCompile(`^\s*[-*+]\s+(.+?)\s*$`)
+)
+
+func (s *Server) changelog(ctx context.Context, request *changelogRequest) (*changelogResponse, error) {
+	path := request.Path
+	if path == "" {
+		path = defaultChangelogPath
+	}
+	_, content, err := s.readRequestedMarkdown(path)
+	if err != nil {
+		return nil, err
+	}
+	_, _, body := s.splitFrontmatter(content)
+
+	releases := parseChangelog(body)
+	if request.Version != "" {
+		filtered := []changelogRelease{}
+		for _, r := range releases {
+			if strings.EqualFold(strings.TrimPrefix(r.Version, "v"), strings.TrimPrefix(request.Version, "v")) {
+				filtered = append(filtered, r)
+			}
+		}
+		releases = filtered
+	}
+	return &changelogResponse{Path: path, Releases: releases}, nil
+}
+
+// parseChangelog parses the release sections of a changelog body in document order.
+func parseChangelog(body []byte) []changelogRelease {
+	releases := []changelogRelease{}
+	var release *changelogRelease
+	var section *changelogSection
+	for line := range markdownLines(body) {
+		if line.InCode {
+			continue
+		}
+		if m := changelogReleasePattern.FindStringSubmatch(line.Text); m != nil {
+			releases = append(releases, changelogRelease{
+				Version:    m[1],
+				Date:       m[2],
+				Unreleased: strings.EqualFold(m[1], "unreleased"),
+			})
+			release = &releases[len(releases)-1]
+			section = nil
+			continue
+		}
+		if release == nil {
+			continue
+		}
+		if m := changelogSectionPattern.FindStringSubmatch(line.Text); m != nil {
+			release.Sections = append(release.Sections, changelogSection{Name: m[1], Entries: []string{}})
+			section = &release.Sections[len(release.Sections)-1]
+			continue
+		}
+		if m := changelogEntryPattern.FindStringSubmatch(line.Text); m != nil {
+			if section != nil {
+				section.Entries = append(section.Entries, m[1])
+			} else {
+				release.Entries = append(release.Entries, m[1])
+			}
+		}
+	}
+	return releases
+}
@@ -0,0 +1,113 @@
+package mcpmds
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"testing/fstest"
+)
+
+func Test_server_changelog(t *testing.T) {
+	testFS := fstest.MapFS{
+		"CHANGELOG.md": {Data: []byte(`# Changelog
+
+All notable changes to this project will be documented in this file.
+
+## [Unreleased]
+
+### Added
+- Search tool
+
+## [1.2.0] - 2024-01-01
+
+### Added
+- Link graph tool
+- Excerpts
+
+### Fixed
+- Frontmatter parsing with CRLF
+
+## [1.1.0] - 2023-06-15
+
+- Initial public release
+
+[Unreleased]: https://example.com/compare/v1.2.0...HEAD
+[1.2.0]: https://example.com/compare/v1.1.0...v1.2.0
+`)},
+		"docs/HISTORY.md": {Data: []byte("## 0.1.0 - 2022-01-01\n\n* First\n")},
+	}
+
+	s := &Server{fs: testFS}
+
+	unreleased := changelogRelease{
+		Version:    "Unreleased",
+		Unreleased: true,
+		Sections:   []changelogSection{{Name: "Added", Entries: []string{"Search tool"}}},
+	}
+	v120 := changelogRelease{
+		Version: "1.2.0",
+		Date:    "2024-01-01",
+		Sections: []changelogSection{
+			{Name: "Added", Entries: []string{"Link graph tool", "Excerpts"}},
+			{Name: "Fixed", Entries: []string{"Frontmatter parsing with CRLF"}},
+		},
+	}
+	v110 := changelogRelease{
+		Version: "1.1.0",
+		Date:    "2023-06-15",
+		Entries: []string{"Initial public release"},
+	}
+
+	tests := []struct {
+		name    string
+		request *changelogRequest
+		want    *changelogResponse
+		wantErr bool
+	}{
+		{
+			name:    "Multiple releases with unreleased section",
+			request: &changelogRequest{},
+			want:    &changelogResponse{Path: "CHANGELOG.md", Releases: []changelogRelease{unreleased, v120, v110}},
+		},
+		{
+			name:    "Single version",
+			request: &changelogRequest{Version: "v1.2.0"},
+			want:    &changelogResponse{Path: "CHANGELOG.md", Releases: []changelogRelease{v120}},
+		},
+		{
+			name:    "Unreleased only",
+			request: &changelogRequest{Version: "unreleased"},
+			want:    &changelogResponse{Path: "CHANGELOG.md", Releases: []changelogRelease{unreleased}},
+		},
+		{
+			name:    "Custom path",
+			request: &changelogRequest{Path: "docs/HISTORY.md"},
+			want: &changelogResponse{Path: "docs/HISTORY.md", Releases: []changelogRelease{
+				{Version: "0.1.0", Date: "2022-01-01", Entries: []string{"First"}},
+			}},
+		},
+		{
+			name:    "Missing changelog",
+			request: &changelogRequest{Path: "missing.md"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := s.changelog(context.Background(), tt.request)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected an error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("changelog()\n got = %+v,\nwant = %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,248 @@
+package mcpmds
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/Warashi/go-modelcontextprotocol/jsonschema"
+	"github.com/Warashi/go-modelcontextprotocol/mcp"
+)
+
+// charsPerToken is the rough number of characters per token used to turn a
+// token budget into a character budget.
+const charsPerToken = 4
+
+func (s *Server) chunkTool() mcp.Tool[*chunkRequest, *chunkResponse] {
+	return mcp.NewToolFunc(
+		fmt.Sprintf("chunk_%s_markdown_file", s.name),
+		fmt.Sprintf("Split the body of a markdown file managed by %s into chunks of bounded size, breaking at heading and paragraph boundaries where possible", s.name),
+		jsonschema.Object{
+			Properties: map[string]jsonschema.Schema{
+				"path": jsonschema.String{
+					Description: "The path to the markdown file",
+				},
+				"max_chars": jsonschema.Integer{
+					Description: "The maximum number of characters in a chunk; exactly one of max_chars and max_tokens must be set",
+				},
+				"max_tokens": jsonschema.Integer{
+					Description: "The maximum number of tokens in a chunk, estimated at 4 characters per token",
+				},
+				"overlap": jsonschema.Integer{
+					Description: "The number of characters from the end of each chunk repeated at the start of the next; must be less than the chunk size",
+				},
+			},
+			Required: []string{"path"},
+		},
+		s.chunk,
+	)
+}
+
+type chunkRequest struct {
+	Path      string `json:"path" jsonschema:"required"`
+	MaxChars  int    `json:"max_chars"`
+	MaxTokens int    `json:"max_tokens"`
+	Overlap   int    `json:"overlap"`
+}
+
+type chunkResponse struct {
+	// Path is the relative path to the markdown file.
+	Path string `json:"path"`
+	// Chunks are the pieces of the body, in order.
+	Chunks []chunk `json:"chunks"`
+}
+
+// chunk is a piece of a markdown body.
+type chunk struct {
+	// Index is the 0-based position of the chunk.
+	Index int `json:"index"`
+	// Text is the content of the chunk, starting with the overlap taken from
+	// the previous chunk.
+	Text string `json:"text"`
+	// Headings are the texts of the enclosing headings at the start of the
+	// chunk, outermost first.
+	Headings []string `json:"headings,omitempty"`
+	// StartLine and EndLine are the 1-based body lines the chunk covers,
+	// not counting the overlap.
+	StartLine int `json:"start_line"`
+	EndLine   int `json:"end_line"`
+}
+
+func (s *Server) chunk(ctx context.Context, request *chunkRequest) (*chunkResponse, error) {
+	size, err := chunkSize(request.MaxChars, request.MaxTokens)
+	if err != nil {
+		return nil, err
+	}
+	if request.Overlap < 0 || request.Overlap >= size {
+		return nil, fmt.Errorf("overlap must be between 0 and %d", size-1)
+	}
+	path, content, err := s.readRequestedMarkdown(request.Path)
+	if err != nil {
+		return nil, err
+	}
+	_, _, body := s.splitFrontmatter(content)
+	return &chunkResponse{Path: path, Chunks: chunkBody(body, size, request.Overlap)}, nil
+}
+
+// chunkSize returns the chunk size in characters for a request setting
+// exactly one of maxChars and maxTokens.
+func chunkSize(maxChars, maxTokens int) (int, error) {
+	switch {
+	case maxChars < 0 || maxTokens < 0:
+		return 0, errors.New("max_chars and max_tokens must not be negative")
+	case maxChars > 0 && maxTokens > 0:
+		return 0, errors.New("only one of max_chars and max_tokens may be set")
+	case maxChars > 0:
+		return maxChars, nil
+	case maxTokens > 0:
+		return maxTokens * charsPerToken, nil
+	}
+	return 0, errors.New("one of max_chars and max_tokens is required")
+}
+
+// chunkPiece is an unsplittable run of body text.
+type chunkPiece struct {
+	// sep joins the piece to the one before it.
+	sep  string
+	text string
+	// heading reports whether the piece is a heading, which is kept in the
+	// same chunk as the piece after it when they fit together.
+	heading   bool
+	headings  []string
+	startLine int
+	endLine   int
+}
+
+// chunkBody splits body into chunks of at most size characters, each after
+// the first starting with the last overlap characters of the one before.
+// Chunks break between blocks where they can, then between lines, and only
+// split a line that is longer than a chunk on its own.
+func chunkBody(body []byte, size, overlap int) []chunk {
+	budget := size - overlap
+	var chunks []chunk
+	pieces := chunkPieces(body, budget)
+	for i, p := range pieces {
+		if n := len(chunks); n > 0 && fits(chunks[n-1].Text+p.sep+p.text, budget) && !startsChunk(chunks[n-1].Text, pieces[i:], budget) {
+			chunks[n-1].Text += p.sep + p.text
+			chunks[n-1].EndLine = p.endLine
+			continue
+		}
+		chunks = append(chunks, chunk{Index: len(chunks), Text: p.text, Headings: p.headings, StartLine: p.startLine, EndLine: p.endLine})
+	}
+	for i := 1; i < len(chunks); i++ {
+		chunks[i].Text = lastRunes(chunks[i-1].Text, overlap) + chunks[i].Text
+	}
+	return chunks
+}
+
+// startsChunk reports whether the heading at the start of pieces should begin
+// a new chunk rather than be appended to cur, because the piece after it fits
+// with the heading in a new chunk but not in cur.
+func startsChunk(cur string, pieces []chunkPiece, budget int) bool {
+	if !pieces[0].heading || len(pieces) < 2 {
+		return false
+	}
+	heading, next := pieces[0], pieces[1]
+	return fits(heading.text+next.sep+next.text, budget) && !fits(cur+heading.sep+heading.text+next.sep+next.text, budget)
+}
+
+// fits reports whether text has at most budget characters.
+func fits(text string, budget int) bool {
+	return utf8.RuneCountInString(text) <= budget
+}
+
+// chunkPieces splits body into blocks separated by blank lines, with each
+// heading in a block of its own, and splits blocks longer than budget
+// characters into lines and then into runs of characters.
+func chunkPieces(body []byte, budget int) []chunkPiece {
+	lines := strings.Split(string(body), "\n")
+	headings := map[int]heading{}
+	for _, h := range scanHeadings(body) {
+		headings[h.Line] = h
+	}
+
+	var pieces []chunkPiece
+	var trail []heading
+	var block []markdownLine
+	flush := func(isHeading bool) {
+		if len(block) == 0 {
+			return
+		}
+		pieces = append(pieces, splitBlock(block, headingTexts(trail), budget)...)
+		if isHeading {
+			pieces[len(pieces)-1].heading = true
+		}
+		block = nil
+	}
+	end := 0
+	for line := range markdownLines(body) {
+		if h, ok := headings[line.Number]; ok {
+			flush(false)
+			for len(trail) > 0 && trail[len(trail)-1].Level >= h.Level {
+				trail = trail[:len(trail)-1]
+			}
+			trail = append(trail, h)
+			// A setext heading ends with its underline on the next line.
+			end = h.Line
+			if !atxHeadingPattern.MatchString(strings.TrimSuffix(lines[h.Line-1], "\r")) {
+				end++
+			}
+		}
+		if !line.InCode && strings.TrimSpace(line.Text) == "" {
+			flush(false)
+			continue
+		}
+		block = append(block, line)
+		if line.Number == end {
+			flush(true)
+		}
+	}
+	flush(false)
+	return pieces
+}
+
+// headingTexts returns the texts of headings.
+func headingTexts(headings []heading) []string {
+	if len(headings) == 0 {
+		return nil
+	}
+	texts := make([]string, len(headings))
+	for i, h := range headings {
+		texts[i] = h.Text
+	}
+	return texts
+}
+
+// splitBlock returns block as a single piece, or as one piece per line if it
+// is longer than budget characters, splitting lines that are still too long.
+func splitBlock(block []markdownLine, headings []string, budget int) []chunkPiece {
+	texts := make([]string, len(block))
+	for i, line := range block {
+		texts[i] = line.Text
+	}
+	text := strings.Join(texts, "\n")
+	if fits(text, budget) {
+		return []chunkPiece{{sep: "\n\n", text: text, headings: headings, startLine: block[0].Number, endLine: block[len(block)-1].Number}}
+	}
+	var pieces []chunkPiece
+	sep := "\n\n"
+	for _, line := range block {
+		runes := []rune(line.Text)
+		for len(runes) > budget {
+			pieces = append(pieces, chunkPiece{sep: sep, text: string(runes[:budget]), headings: headings, startLine: line.Number, endLine: line.Number})
+			runes = runes[budget:]
+			sep = ""
+		}
+		pieces = append(pieces, chunkPiece{sep: sep, text: string(runes), headings: headings, startLine: line.Number, endLine: line.Number})
+		sep = "\n"
+	}
+	return pieces
+}
+
+// lastRunes returns the last n runes of text.
+func lastRunes(text string, n int) string {
+	runes := []rune(text)
+	return string(runes[max(len(runes)-n, 0):])
+}
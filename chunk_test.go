@@ -0,0 +1,117 @@
+package mcpmds
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func Test_chunkBody(t *testing.T) {
+	body := "# Guide\n\nIntro paragraph.\n\n## Install\n\nRun the installer.\n\nThen restart.\n\n## Use\n\nOpen the app."
+	tests := []struct {
+		name    string
+		size    int
+		overlap int
+		want    []chunk
+	}{
+		{
+			name: "whole body fits",
+			size: 1000,
+			want: []chunk{
+				{Index: 0, Text: body, Headings: []string{"Guide"}, StartLine: 1, EndLine: 13},
+			},
+		},
+		{
+			name: "breaks at headings and paragraphs",
+			size: 40,
+			want: []chunk{
+				{Index: 0, Text: "# Guide\n\nIntro paragraph.", Headings: []string{"Guide"}, StartLine: 1, EndLine: 3},
+				{Index: 1, Text: "## Install\n\nRun the installer.", Headings: []string{"Guide", "Install"}, StartLine: 5, EndLine: 7},
+				{Index: 2, Text: "Then restart.\n\n## Use\n\nOpen the app.", Headings: []string{"Guide", "Install"}, StartLine: 9, EndLine: 13},
+			},
+		},
+		{
+			name: "keeps heading with next block",
+			size: 30,
+			want: []chunk{
+				{Index: 0, Text: "# Guide\n\nIntro paragraph.", Headings: []string{"Guide"}, StartLine: 1, EndLine: 3},
+				{Index: 1, Text: "## Install\n\nRun the installer.", Headings: []string{"Guide", "Install"}, StartLine: 5, EndLine: 7},
+				{Index: 2, Text: "Then restart.", Headings: []string{"Guide", "Install"}, StartLine: 9, EndLine: 9},
+				{Index: 3, Text: "## Use\n\nOpen the app.", Headings: []string{"Guide", "Use"}, StartLine: 11, EndLine: 13},
+			},
+		},
+		{
+			name:    "overlap",
+			size:    35,
+			overlap: 5,
+			want: []chunk{
+				{Index: 0, Text: "# Guide\n\nIntro paragraph.", Headings: []string{"Guide"}, StartLine: 1, EndLine: 3},
+				{Index: 1, Text: "raph.## Install\n\nRun the installer.", Headings: []string{"Guide", "Install"}, StartLine: 5, EndLine: 7},
+				{Index: 2, Text: "ller.Then restart.", Headings: []string{"Guide", "Install"}, StartLine: 9, EndLine: 9},
+				{Index: 3, Text: "tart.## Use\n\nOpen the app.", Headings: []string{"Guide", "Use"}, StartLine: 11, EndLine: 13},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := chunkBody([]byte(body), tt.size, tt.overlap)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("chunkBody() = %#v, want %#v", got, tt.want)
+			}
+			for i, c := range got {
+				if n := len([]rune(c.Text)); n > tt.size {
+					t.Errorf("chunk %d has %d characters, want at most %d", i, n, tt.size)
+				}
+				if i > 0 && tt.overlap > 0 {
+					prev := []rune(got[i-1].Text)
+					if tail := string(prev[len(prev)-tt.overlap:]); !strings.HasPrefix(c.Text, tail) {
+						t.Errorf("chunk %d = %q, want prefix %q", i, c.Text, tail)
+					}
+				}
+			}
+		})
+	}
+}
+
+func Test_chunkBody_oversized(t *testing.T) {
+	body := "```\nline one\n\nline two\n```\n\n" + strings.Repeat("é", 25)
+	got := chunkBody([]byte(body), 10, 0)
+	var texts []string
+	for _, c := range got {
+		texts = append(texts, c.Text)
+	}
+	want := []string{"```", "line one\n", "line two", "```", strings.Repeat("é", 10), strings.Repeat("é", 10), strings.Repeat("é", 5)}
+	if !reflect.DeepEqual(texts, want) {
+		t.Errorf("chunkBody() texts = %q, want %q", texts, want)
+	}
+}
+
+func TestChunk(t *testing.T) {
+	testFS := fstest.MapFS{
+		"doc.md": {Data: []byte("---\ntitle: Doc\n---\n# Doc\n\nBody text.")},
+	}
+	s := &Server{fs: testFS}
+
+	got, err := s.chunk(context.Background(), &chunkRequest{Path: "doc.md", MaxTokens: 100})
+	if err != nil {
+		t.Fatalf("chunk() error = %v", err)
+	}
+	want := []chunk{{Index: 0, Text: "# Doc\n\nBody text.", Headings: []string{"Doc"}, StartLine: 1, EndLine: 3}}
+	if !reflect.DeepEqual(got.Chunks, want) {
+		t.Errorf("chunk() chunks = %#v, want %#v", got.Chunks, want)
+	}
+
+	for _, req := range []*chunkRequest{
+		{Path: "doc.md"},
+		{Path: "doc.md", MaxChars: 10, MaxTokens: 10},
+		{Path: "doc.md", MaxChars: -1},
+		{Path: "doc.md", MaxChars: 10, Overlap: 10},
+		{Path: "missing.md", MaxChars: 10},
+	} {
+		if _, err := s.chunk(context.Background(), req); err == nil {
+			t.Errorf("chunk(%+v) error = nil, want error", req)
+		}
+	}
+}
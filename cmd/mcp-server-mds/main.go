@@ -2,28 +2,89 @@ package main
 
 import (
 	"context"
+	"errors"
 	"flag"
+	"io/fs"
 	"log"
+	"net"
+	"net/http"
 	"os"
+	"os/signal"
 	"strings"
 
 	mcpmds "github.com/Warashi/go-mcp-server-mds"
 )
 
 func main() {
-	var path, name, description, excludeFrontmatter string
+	var path, name, description, excludeFrontmatter, transport, addr, baseURL string
+	var maxFileSize int64
 	flag.StringVar(&path, "path", ".", "path to the directory to serve")
 	flag.StringVar(&name, "name", "mcp-server-mds", "name of the server")
 	flag.StringVar(&description, "description", "Markdown Documents Server", "description of the server")
 	flag.StringVar(&excludeFrontmatter, "exclude-frontmatter", "", "comma-separated list of keys to exclude from frontmatter")
+	flag.StringVar(&transport, "transport", "stdio", "transport to serve over: stdio, or sse (also accepted as http)")
+	flag.StringVar(&addr, "addr", ":8080", "address to listen on for the sse transport")
+	flag.StringVar(&baseURL, "base-url", "", "URL at which clients reach the sse endpoint; defaults to http://localhost:<port>/sse")
+	flag.Int64Var(&maxFileSize, "max-file-size", 0, "maximum size in bytes of the markdown files to serve; larger files are skipped, and 0 means no limit")
 	flag.Parse()
 
-	server, err := mcpmds.New(name, description, os.DirFS(path), mcpmds.WithExcludeFrontmatter(strings.Split(excludeFrontmatter, ",")...))
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	fsys := os.DirFS(path)
+	opts := []mcpmds.ServerOption{
+		mcpmds.WithExcludeFrontmatter(strings.Split(excludeFrontmatter, ",")...),
+		mcpmds.WithMaxFileSize(maxFileSize),
+	}
+
+	switch transport {
+	case "stdio":
+		if err := mcpmds.ServeStdio(ctx, name, description, fsys, opts...); err != nil {
+			log.Fatalf("failed to serve: %v", err)
+		}
+	case "sse", "http":
+		l, err := net.Listen("tcp", addr)
+		if err != nil {
+			log.Fatalf("failed to listen: %v", err)
+		}
+		if baseURL == "" {
+			baseURL = defaultBaseURL(l.Addr())
+		}
+		log.Printf("serving on %s", baseURL)
+		if err := serveHTTP(ctx, l, baseURL, name, description, fsys, opts...); err != nil {
+			log.Fatalf("failed to serve: %v", err)
+		}
+	default:
+		log.Fatalf("unknown transport %q: want stdio or sse", transport)
+	}
+}
+
+// defaultBaseURL returns the SSE endpoint URL for a listener address, using
+// localhost when the listener accepts connections on all interfaces.
+func defaultBaseURL(addr net.Addr) string {
+	host, port, err := net.SplitHostPort(addr.String())
 	if err != nil {
-		log.Fatalf("failed to create server: %v", err)
+		return "http://" + addr.String() + "/sse"
+	}
+	if ip := net.ParseIP(host); ip == nil || ip.IsUnspecified() {
+		host = "localhost"
 	}
+	return "http://" + net.JoinHostPort(host, port) + "/sse"
+}
 
-	if err := server.ServeStdio(context.Background()); err != nil {
-		log.Fatalf("failed to serve: %v", err)
+// serveHTTP serves the MCP SSE transport on l until ctx is canceled.
+func serveHTTP(ctx context.Context, l net.Listener, baseURL, name, description string, fsys fs.FS, opts ...mcpmds.ServerOption) error {
+	h, err := mcpmds.NewHandler(name, description, fsys, baseURL, opts...)
+	if err != nil {
+		return err
+	}
+	srv := &http.Server{Handler: h}
+	go func() {
+		<-ctx.Done()
+		srv.Close()
+	}()
+	if err := srv.Serve(l); !errors.Is(err, http.ErrServerClosed) {
+		return err
 	}
+	return nil
 }
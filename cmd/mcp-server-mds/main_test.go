@@ -0,0 +1,115 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+	"testing/fstest"
+	"time"
+)
+
+func TestServeHTTP(t *testing.T) {
+	testFS := fstest.MapFS{
+		"a.md":     {Data: []byte("---\ntitle: A\n---\nbody")},
+		"dir/b.md": {Data: []byte("body")},
+	}
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	baseURL := defaultBaseURL(l.Addr())
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	done := make(chan error, 1)
+	go func() { done <- serveHTTP(ctx, l, baseURL, "test", "test", testFS) }()
+	defer func() {
+		cancel()
+		if err := <-done; err != nil {
+			t.Errorf("serveHTTP() error = %v", err)
+		}
+	}()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, baseURL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("GET %s: %v", baseURL, err)
+	}
+	defer resp.Body.Close()
+	events := bufio.NewScanner(resp.Body)
+	nextData := func(event string) string {
+		t.Helper()
+		current := ""
+		for events.Scan() {
+			line := events.Text()
+			if v, ok := strings.CutPrefix(line, "event: "); ok {
+				current = v
+			}
+			if v, ok := strings.CutPrefix(line, "data: "); ok && current == event {
+				return v
+			}
+		}
+		t.Fatalf("no %s event: %v", event, events.Err())
+		return ""
+	}
+
+	endpoint := nextData("endpoint")
+	call := `{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"list_test_markdown_files","arguments":{}}}`
+	post, err := http.Post(endpoint, "application/json", strings.NewReader(call))
+	if err != nil {
+		t.Fatalf("POST %s: %v", endpoint, err)
+	}
+	post.Body.Close()
+
+	var result struct {
+		Result struct {
+			Content []struct {
+				Text string `json:"text"`
+			} `json:"content"`
+		} `json:"result"`
+	}
+	if err := json.Unmarshal([]byte(nextData("message")), &result); err != nil {
+		t.Fatalf("unmarshal message: %v", err)
+	}
+	if len(result.Result.Content) != 1 {
+		t.Fatalf("tools/call content = %+v, want one item", result.Result.Content)
+	}
+	var list struct {
+		Files []struct {
+			Path string `json:"path"`
+		} `json:"files"`
+	}
+	if err := json.Unmarshal([]byte(result.Result.Content[0].Text), &list); err != nil {
+		t.Fatalf("unmarshal list: %v", err)
+	}
+	if len(list.Files) != 2 || list.Files[0].Path != "a.md" || list.Files[1].Path != "dir/b.md" {
+		t.Errorf("listed files = %+v, want a.md and dir/b.md", list.Files)
+	}
+}
+
+func TestDefaultBaseURL(t *testing.T) {
+	tests := []struct {
+		addr string
+		want string
+	}{
+		{addr: "127.0.0.1:8080", want: "http://127.0.0.1:8080/sse"},
+		{addr: "0.0.0.0:8080", want: "http://localhost:8080/sse"},
+		{addr: "[::]:9000", want: "http://localhost:9000/sse"},
+	}
+	for _, tt := range tests {
+		addr, err := net.ResolveTCPAddr("tcp", tt.addr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := defaultBaseURL(addr); got != tt.want {
+			t.Errorf("defaultBaseURL(%s) = %q, want %q", tt.addr, got, tt.want)
+		}
+	}
+}
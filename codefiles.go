@@ -0,0 +1,71 @@
+package mcpmds
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/Warashi/go-modelcontextprotocol/jsonschema"
+	"github.com/Warashi/go-modelcontextprotocol/mcp"
+)
+
+func (s *Server) filesWithCodeTool() mcp.Tool[*filesWithCodeRequest, *filesWithCodeResponse] {
+	return mcp.NewToolFunc(
+		fmt.Sprintf("files_with_code_%s", s.name),
+		fmt.Sprintf("List markdown files managed by %s containing fenced code blocks of a language, with the number of such blocks", s.name),
+		jsonschema.Object{
+			Properties: map[string]jsonschema.Schema{
+				"language": jsonschema.String{
+					Description: "The language of the code blocks, as written after the opening fence, such as \"go\"; matched case-insensitively",
+				},
+			},
+			Required: []string{"language"},
+		},
+		s.filesWithCode,
+	)
+}
+
+type filesWithCodeRequest struct {
+	Language string `json:"language" jsonschema:"required"`
+}
+
+type filesWithCodeResponse struct {
+	// Files are the files containing code blocks of the language, in path order.
+	Files []codeFile `json:"files"`
+}
+
+// codeFile is a file containing code blocks of a language.
+type codeFile struct {
+	// Path is the relative path to the markdown file.
+	Path string `json:"path"`
+	// Blocks is the number of code blocks of the language.
+	Blocks int `json:"blocks"`
+	// Lines are the 1-based body line numbers of the opening fences.
+	Lines []int `json:"lines"`
+}
+
+func (s *Server) filesWithCode(ctx context.Context, request *filesWithCodeRequest) (*filesWithCodeResponse, error) {
+	if request.Language == "" {
+		return nil, errors.New("language is required")
+	}
+	resp := &filesWithCodeResponse{Files: []codeFile{}}
+	for f := range s.markdownFiles(ctx) {
+		content, err := s.readMarkdown(f.Path)
+		if err != nil {
+			return nil, err
+		}
+		_, _, body := s.splitFrontmatter(content)
+		file := codeFile{Path: f.Path}
+		for line := range markdownLines(body) {
+			if line.OpensCode && strings.EqualFold(fenceLanguage(line.Text), request.Language) {
+				file.Blocks++
+				file.Lines = append(file.Lines, line.Number)
+			}
+		}
+		if file.Blocks > 0 {
+			resp.Files = append(resp.Files, file)
+		}
+	}
+	return resp, nil
+}
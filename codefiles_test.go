@@ -0,0 +1,43 @@
+package mcpmds
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"testing/fstest"
+)
+
+func TestFilesWithCode(t *testing.T) {
+	testFS := fstest.MapFS{
+		"go.md":     {Data: []byte("---\ntitle: Go\n---\n```go\nfunc main() {}\n```\n\nText\n\n~~~ Go {linenos}\nx := 1\n~~~\n")},
+		"python.md": {Data: []byte("```python\nprint(1)\n```\n")},
+		"plain.md":  {Data: []byte("```\ngo\n```\n\n    ```go\n    indented code, not a fence\n")},
+		"nested.md": {Data: []byte("````markdown\n```go\nshown, not run\n```\n````\n")},
+	}
+	s := &Server{fs: testFS}
+
+	tests := []struct {
+		language string
+		want     []codeFile
+	}{
+		{language: "go", want: []codeFile{{Path: "go.md", Blocks: 2, Lines: []int{1, 7}}}},
+		{language: "PYTHON", want: []codeFile{{Path: "python.md", Blocks: 1, Lines: []int{1}}}},
+		{language: "markdown", want: []codeFile{{Path: "nested.md", Blocks: 1, Lines: []int{1}}}},
+		{language: "rust", want: []codeFile{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.language, func(t *testing.T) {
+			got, err := s.filesWithCode(context.Background(), &filesWithCodeRequest{Language: tt.language})
+			if err != nil {
+				t.Fatalf("filesWithCode() error = %v", err)
+			}
+			if !reflect.DeepEqual(got.Files, tt.want) {
+				t.Errorf("filesWithCode() = %#v, want %#v", got.Files, tt.want)
+			}
+		})
+	}
+
+	if _, err := s.filesWithCode(context.Background(), &filesWithCodeRequest{}); err == nil {
+		t.Error("filesWithCode() with empty language error = nil, want error")
+	}
+}
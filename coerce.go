@@ -0,0 +1,169 @@
+package mcpmds
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Warashi/go-modelcontextprotocol/jsonschema"
+	"github.com/Warashi/go-modelcontextprotocol/mcp"
+)
+
+// frontmatterCoercions convert a frontmatter value to a field type, keyed by
+// the type names of FieldSchema.
+var frontmatterCoercions = map[string]func(v any) (any, bool){
+	"string":   coerceString,
+	"integer":  coerceInteger,
+	"number":   func(v any) (any, bool) { return frontmatterNumber(v) },
+	"boolean":  coerceBoolean,
+	"datetime": func(v any) (any, bool) { return frontmatterTime(v) },
+	"array":    coerceArray,
+}
+
+func (s *Server) coerceFrontmatterTool() mcp.Tool[*coerceFrontmatterRequest, *coerceFrontmatterResponse] {
+	return mcp.NewToolFunc(
+		fmt.Sprintf("coerce_frontmatter_%s", s.name),
+		fmt.Sprintf("Return the frontmatter of a markdown file managed by %s converted to the given field types, such as the string \"5\" to the integer 5, reporting the values that cannot be converted", s.name),
+		jsonschema.Object{
+			Properties: map[string]jsonschema.Schema{
+				"path": jsonschema.String{
+					Description: "The path to the markdown file",
+				},
+				"fields": jsonschema.Map{
+					Description:          "Maps frontmatter keys to their expected types: string, integer, number, boolean, datetime, or array",
+					AdditionalProperties: jsonschema.String{},
+				},
+			},
+			Required: []string{"path", "fields"},
+		},
+		s.coerceFrontmatter,
+	)
+}
+
+type coerceFrontmatterRequest struct {
+	Path   string            `json:"path" jsonschema:"required"`
+	Fields map[string]string `json:"fields" jsonschema:"required"`
+}
+
+type coerceFrontmatterResponse struct {
+	// Path is the relative path to the markdown file.
+	Path string `json:"path"`
+	// Frontmatter holds the requested fields converted to their types.
+	// Fields that are missing or cannot be converted are left out.
+	Frontmatter map[string]any `json:"frontmatter"`
+	// Errors are the fields whose values cannot be converted, ordered by key.
+	Errors []coercionError `json:"errors"`
+	// Missing are the requested fields absent from the frontmatter, ordered by key.
+	Missing []string `json:"missing"`
+}
+
+// coercionError reports a frontmatter value that cannot be converted to the
+// type of its field.
+type coercionError struct {
+	Key   string `json:"key"`
+	Type  string `json:"type"`
+	Value any    `json:"value"`
+	// Error describes the failure.
+	Error string `json:"error"`
+}
+
+func (s *Server) coerceFrontmatter(ctx context.Context, request *coerceFrontmatterRequest) (*coerceFrontmatterResponse, error) {
+	if len(request.Fields) == 0 {
+		return nil, errors.New("fields must not be empty")
+	}
+	for key, typ := range request.Fields {
+		if _, ok := frontmatterCoercions[typ]; !ok {
+			return nil, fmt.Errorf("field %q: unknown type %q: want string, integer, number, boolean, datetime, or array", key, typ)
+		}
+	}
+	path, content, err := s.readRequestedMarkdown(request.Path)
+	if err != nil {
+		return nil, err
+	}
+	frontmatter, err := s.readFrontmatter(content)
+	if err != nil {
+		return nil, err
+	}
+	resp := &coerceFrontmatterResponse{
+		Path:        path,
+		Frontmatter: map[string]any{},
+		Errors:      []coercionError{},
+		Missing:     []string{},
+	}
+	for _, key := range slices.Sorted(maps.Keys(request.Fields)) {
+		typ := request.Fields[key]
+		v, ok := frontmatter[key]
+		if !ok {
+			resp.Missing = append(resp.Missing, key)
+			continue
+		}
+		coerced, ok := frontmatterCoercions[typ](v)
+		if !ok {
+			resp.Errors = append(resp.Errors, coercionError{
+				Key:   key,
+				Type:  typ,
+				Value: v,
+				Error: fmt.Sprintf("cannot convert %s %v to %s", schemaTypeOf(v), v, typ),
+			})
+			continue
+		}
+		resp.Frontmatter[key] = coerced
+	}
+	return resp, nil
+}
+
+// coerceString converts scalars to their string form.
+func coerceString(v any) (any, bool) {
+	switch v := v.(type) {
+	case string:
+		return v, true
+	case bool, int, int64, uint64, float64:
+		return fmt.Sprint(v), true
+	case time.Time:
+		return v.Format(time.RFC3339), true
+	}
+	return nil, false
+}
+
+// coerceInteger converts whole numbers and strings holding them to int64.
+func coerceInteger(v any) (any, bool) {
+	if s, ok := v.(string); ok {
+		if n, err := strconv.ParseInt(strings.TrimSpace(s), 10, 64); err == nil {
+			return n, true
+		}
+	}
+	f, ok := frontmatterNumber(v)
+	if !ok || f != math.Trunc(f) || f >= math.MaxInt64 || f < math.MinInt64 {
+		return nil, false
+	}
+	return int64(f), true
+}
+
+// coerceBoolean converts booleans and strings such as "true" or "0".
+func coerceBoolean(v any) (any, bool) {
+	switch v := v.(type) {
+	case bool:
+		return v, true
+	case string:
+		b, err := strconv.ParseBool(strings.TrimSpace(v))
+		return b, err == nil
+	}
+	return nil, false
+}
+
+// coerceArray keeps lists and wraps scalars in a list of one.
+func coerceArray(v any) (any, bool) {
+	switch v := v.(type) {
+	case []any:
+		return v, true
+	case map[string]any, nil:
+		return nil, false
+	}
+	return []any{v}, true
+}
@@ -0,0 +1,87 @@
+package mcpmds
+
+import (
+	"context"
+	"math"
+	"reflect"
+	"testing"
+	"testing/fstest"
+	"time"
+)
+
+func TestCoerceFrontmatter(t *testing.T) {
+	testFS := fstest.MapFS{
+		"a.md": {Data: []byte("---\nweight: \"5\"\nrating: 4.5\ndraft: \"false\"\ncount: many\ndate: \"2024-05-06\"\ntags: go\nversion: 2\n---\n")},
+	}
+	s := &Server{fs: testFS}
+
+	got, err := s.coerceFrontmatter(context.Background(), &coerceFrontmatterRequest{
+		Path: "a.md",
+		Fields: map[string]string{
+			"weight":  "integer",
+			"rating":  "number",
+			"draft":   "boolean",
+			"count":   "integer",
+			"date":    "datetime",
+			"tags":    "array",
+			"version": "string",
+			"author":  "string",
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := &coerceFrontmatterResponse{
+		Path: "a.md",
+		Frontmatter: map[string]any{
+			"weight":  int64(5),
+			"rating":  4.5,
+			"draft":   false,
+			"date":    time.Date(2024, 5, 6, 0, 0, 0, 0, time.UTC),
+			"tags":    []any{"go"},
+			"version": "2",
+		},
+		Errors: []coercionError{
+			{Key: "count", Type: "integer", Value: "many", Error: "cannot convert string many to integer"},
+		},
+		Missing: []string{"author"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("coerceFrontmatter() = %#v, want %#v", got, want)
+	}
+}
+
+func TestCoerceFrontmatter_invalidRequest(t *testing.T) {
+	s := &Server{fs: fstest.MapFS{"a.md": {Data: []byte("---\na: 1\n---\n")}}}
+	for _, fields := range []map[string]string{nil, {"a": "decimal"}} {
+		if _, err := s.coerceFrontmatter(context.Background(), &coerceFrontmatterRequest{Path: "a.md", Fields: fields}); err == nil {
+			t.Errorf("coerceFrontmatter(%v) error = nil, want error", fields)
+		}
+	}
+}
+
+func Test_coerceInteger(t *testing.T) {
+	tests := []struct {
+		v      any
+		want   any
+		wantOK bool
+	}{
+		{"5", int64(5), true},
+		{" -3 ", int64(-3), true},
+		{uint64(7), int64(7), true},
+		{8.0, int64(8), true},
+		{"2.0", int64(2), true},
+		{2.5, nil, false},
+		{"five", nil, false},
+		{true, nil, false},
+		{float64(math.MaxInt64), nil, false},
+		{float64(math.MinInt64), int64(math.MinInt64), true},
+		{-1e19, nil, false},
+	}
+	for _, tt := range tests {
+		got, ok := coerceInteger(tt.v)
+		if got != tt.want || ok != tt.wantOK {
+			t.Errorf("coerceInteger(%#v) = %#v, %v, want %#v, %v", tt.v, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
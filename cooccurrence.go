@@ -0,0 +1,83 @@
+package mcpmds
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/Warashi/go-modelcontextprotocol/jsonschema"
+	"github.com/Warashi/go-modelcontextprotocol/mcp"
+)
+
+// defaultTagCooccurrenceLimit is the number of tag pairs returned when no
+// limit is given.
+const defaultTagCooccurrenceLimit = 20
+
+func (s *Server) tagCooccurrenceTool() mcp.Tool[*tagCooccurrenceRequest, *tagCooccurrenceResponse] {
+	return mcp.NewToolFunc(
+		fmt.Sprintf("tag_cooccurrence_%s", s.name),
+		fmt.Sprintf("List the pairs of frontmatter tags that appear together on markdown files managed by %s, most frequent first", s.name),
+		jsonschema.Object{
+			Properties: map[string]jsonschema.Schema{
+				"limit": jsonschema.Integer{
+					Description: fmt.Sprintf("The maximum number of pairs to return; defaults to %d", defaultTagCooccurrenceLimit),
+				},
+			},
+		},
+		s.tagCooccurrence,
+	)
+}
+
+type tagCooccurrenceRequest struct {
+	Limit int `json:"limit"`
+}
+
+type tagCooccurrenceResponse struct {
+	// Pairs are the most frequent tag pairs, by descending count and then by tags.
+	Pairs []tagPair `json:"pairs"`
+	// Total is the number of distinct pairs, including those cut by limit.
+	Total int `json:"total"`
+}
+
+// tagPair is a pair of tags appearing together on files.
+type tagPair struct {
+	// Tags are the two tags in sorted order.
+	Tags [2]string `json:"tags"`
+	// Count is the number of files carrying both tags.
+	Count int `json:"count"`
+}
+
+func (s *Server) tagCooccurrence(ctx context.Context, request *tagCooccurrenceRequest) (*tagCooccurrenceResponse, error) {
+	if request.Limit < 0 {
+		return nil, errors.New("limit must not be negative")
+	}
+	limit := request.Limit
+	if limit == 0 {
+		limit = defaultTagCooccurrenceLimit
+	}
+	counts := map[[2]string]int{}
+	for f := range s.markdownFiles(ctx) {
+		tags := slices.Compact(slices.Sorted(slices.Values(s.frontmatterTags(f.Frontmatter))))
+		for i, a := range tags {
+			for _, b := range tags[i+1:] {
+				counts[[2]string{a, b}]++
+			}
+		}
+	}
+	pairs := make([]tagPair, 0, len(counts))
+	for tags, count := range counts {
+		pairs = append(pairs, tagPair{Tags: tags, Count: count})
+	}
+	slices.SortFunc(pairs, func(a, b tagPair) int {
+		if c := cmp.Compare(b.Count, a.Count); c != 0 {
+			return c
+		}
+		if c := cmp.Compare(a.Tags[0], b.Tags[0]); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.Tags[1], b.Tags[1])
+	})
+	return &tagCooccurrenceResponse{Pairs: pairs[:min(limit, len(pairs))], Total: len(pairs)}, nil
+}
@@ -0,0 +1,59 @@
+package mcpmds
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"testing/fstest"
+)
+
+func TestTagCooccurrence(t *testing.T) {
+	testFS := fstest.MapFS{
+		"a.md": {Data: []byte("---\ntags: [go, mcp, docs]\n---\n")},
+		"b.md": {Data: []byte("---\ntags: [mcp, go]\n---\n")},
+		"c.md": {Data: []byte("---\ntags: [docs, go, go]\n---\n")},
+		"d.md": {Data: []byte("---\ntags: solo\n---\n")},
+		"e.md": {Data: []byte("no frontmatter")},
+	}
+	s := &Server{fs: testFS}
+
+	tests := []struct {
+		name      string
+		limit     int
+		wantPairs []tagPair
+	}{
+		{
+			name: "all pairs",
+			wantPairs: []tagPair{
+				{Tags: [2]string{"docs", "go"}, Count: 2},
+				{Tags: [2]string{"go", "mcp"}, Count: 2},
+				{Tags: [2]string{"docs", "mcp"}, Count: 1},
+			},
+		},
+		{
+			name:  "limited",
+			limit: 1,
+			wantPairs: []tagPair{
+				{Tags: [2]string{"docs", "go"}, Count: 2},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := s.tagCooccurrence(context.Background(), &tagCooccurrenceRequest{Limit: tt.limit})
+			if err != nil {
+				t.Fatalf("tagCooccurrence() error = %v", err)
+			}
+			if !reflect.DeepEqual(got.Pairs, tt.wantPairs) {
+				t.Errorf("tagCooccurrence() pairs = %+v, want %+v", got.Pairs, tt.wantPairs)
+			}
+			if got.Total != 3 {
+				t.Errorf("tagCooccurrence() total = %d, want 3", got.Total)
+			}
+		})
+	}
+
+	if _, err := s.tagCooccurrence(context.Background(), &tagCooccurrenceRequest{Limit: -1}); err == nil {
+		t.Error("tagCooccurrence() with negative limit error = nil, want error")
+	}
+}
@@ -0,0 +1,108 @@
+package mcpmds
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Warashi/go-modelcontextprotocol/jsonschema"
+	"github.com/Warashi/go-modelcontextprotocol/mcp"
+)
+
+// defaultOutlineHeadingLevel is the deepest heading level included in the corpus outline by default.
+const defaultOutlineHeadingLevel = 2
+
+func (s *Server) corpusOutlineTool() mcp.Tool[*corpusOutlineRequest, *outlineNode] {
+	return mcp.NewToolFunc(
+		fmt.Sprintf("corpus_outline_%s", s.name),
+		fmt.Sprintf("Return the directories, markdown files, and top-level headings managed by %s as a single nested tree", s.name),
+		jsonschema.Object{
+			Properties: map[string]jsonschema.Schema{
+				"depth": jsonschema.Integer{
+					Description: "The number of directory levels to expand below the root; 0 expands all",
+				},
+				"heading_level": jsonschema.Integer{
+					Description: "The deepest heading level to include for each file; defaults to 2",
+				},
+			},
+		},
+		s.corpusOutline,
+	)
+}
+
+type corpusOutlineRequest struct {
+	Depth        int `json:"depth"`
+	HeadingLevel int `json:"heading_level"`
+}
+
+// outlineNode is a directory or file in the corpus outline.
+type outlineNode struct {
+	// Name is the base name of the directory or file.
+	Name string `json:"name"`
+	// Path is the path of the directory or file relative to the root.
+	Path string `json:"path"`
+	// Type is either "directory" or "file".
+	Type string `json:"type"`
+	// Children are the entries of a directory.
+	Children []*outlineNode `json:"children,omitempty"`
+	// Headings are the top-level headings of a file.
+	Headings []heading `json:"headings,omitempty"`
+	// Truncated reports whether a directory has entries beyond the depth limit.
+	Truncated bool `json:"truncated,omitempty"`
+}
+
+// child returns the child directory of n with the given name, creating it if needed.
+func (n *outlineNode) child(name string) *outlineNode {
+	for _, c := range n.Children {
+		if c.Name == name && c.Type == "directory" {
+			return c
+		}
+	}
+	p := name
+	if n.Path != "." {
+		p = n.Path + "/" + name
+	}
+	c := &outlineNode{Name: name, Path: p, Type: "directory"}
+	n.Children = append(n.Children, c)
+	return c
+}
+
+func (s *Server) corpusOutline(ctx context.Context, request *corpusOutlineRequest) (*outlineNode, error) {
+	level := request.HeadingLevel
+	if level <= 0 {
+		level = defaultOutlineHeadingLevel
+	}
+	root := &outlineNode{Name: ".", Path: ".", Type: "directory"}
+	for f := range s.markdownFiles(ctx) {
+		dirs := strings.Split(f.Path, "/")
+		name := dirs[len(dirs)-1]
+		dirs = dirs[:len(dirs)-1]
+
+		node := root
+		truncated := false
+		for i, dir := range dirs {
+			if request.Depth > 0 && i >= request.Depth {
+				truncated = true
+				break
+			}
+			node = node.child(dir)
+		}
+		if truncated {
+			node.Truncated = true
+			continue
+		}
+
+		headings, err := s.fileHeadings(f.Path)
+		if err != nil {
+			return nil, err
+		}
+		file := &outlineNode{Name: name, Path: f.Path, Type: "file"}
+		for _, h := range headings {
+			if h.Level <= level {
+				file.Headings = append(file.Headings, h)
+			}
+		}
+		node.Children = append(node.Children, file)
+	}
+	return root, nil
+}
@@ -0,0 +1,68 @@
+package mcpmds
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"testing/fstest"
+)
+
+func Test_server_corpusOutline(t *testing.T) {
+	testFS := fstest.MapFS{
+		"README.md":             {Data: []byte("---\ntitle: Readme\n---\n# Project\n\n## Install\n\n### Details\n")},
+		"docs/guide.md":         {Data: []byte("Guide\n=====\n\nUsage\n-----\n\n```\n# not a heading\n```\n")},
+		"docs/api/reference.md": {Data: []byte("# Reference\n")},
+		"docs/api/v1/old.md":    {Data: []byte("# Old\n")},
+		"notes/todo.md":         {Data: []byte("no headings\n")},
+		"notes/ignored.txt":     {Data: []byte("# not markdown\n")},
+	}
+
+	s := &Server{fs: testFS}
+
+	tests := []struct {
+		name    string
+		request *corpusOutlineRequest
+		want    string
+	}{
+		{
+			name:    "Full tree",
+			request: &corpusOutlineRequest{},
+			want: `{"name":".","path":".","type":"directory","children":[` +
+				`{"name":"README.md","path":"README.md","type":"file","headings":[{"level":1,"text":"Project","line":1},{"level":2,"text":"Install","line":3}]},` +
+				`{"name":"docs","path":"docs","type":"directory","children":[` +
+				`{"name":"api","path":"docs/api","type":"directory","children":[` +
+				`{"name":"reference.md","path":"docs/api/reference.md","type":"file","headings":[{"level":1,"text":"Reference","line":1}]},` +
+				`{"name":"v1","path":"docs/api/v1","type":"directory","children":[` +
+				`{"name":"old.md","path":"docs/api/v1/old.md","type":"file","headings":[{"level":1,"text":"Old","line":1}]}]}]},` +
+				`{"name":"guide.md","path":"docs/guide.md","type":"file","headings":[{"level":1,"text":"Guide","line":1},{"level":2,"text":"Usage","line":4}]}]},` +
+				`{"name":"notes","path":"notes","type":"directory","children":[` +
+				`{"name":"todo.md","path":"notes/todo.md","type":"file"}]}]}`,
+		},
+		{
+			name:    "Depth and heading level limits",
+			request: &corpusOutlineRequest{Depth: 1, HeadingLevel: 1},
+			want: `{"name":".","path":".","type":"directory","children":[` +
+				`{"name":"README.md","path":"README.md","type":"file","headings":[{"level":1,"text":"Project","line":1}]},` +
+				`{"name":"docs","path":"docs","type":"directory","children":[` +
+				`{"name":"guide.md","path":"docs/guide.md","type":"file","headings":[{"level":1,"text":"Guide","line":1}]}],"truncated":true},` +
+				`{"name":"notes","path":"notes","type":"directory","children":[` +
+				`{"name":"todo.md","path":"notes/todo.md","type":"file"}]}]}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := s.corpusOutline(context.Background(), tt.request)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			b, err := json.Marshal(got)
+			if err != nil {
+				t.Fatalf("marshal: %v", err)
+			}
+			if string(b) != tt.want {
+				t.Errorf("corpusOutline()\n got = %s\nwant = %s", b, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,115 @@
+package mcpmds
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"maps"
+	"math"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/Warashi/go-modelcontextprotocol/jsonschema"
+	"github.com/Warashi/go-modelcontextprotocol/mcp"
+)
+
+func (s *Server) schemaCoverageTool() mcp.Tool[*schemaCoverageRequest, *schemaCoverageResponse] {
+	return mcp.NewToolFunc(
+		fmt.Sprintf("schema_coverage_%s", s.name),
+		fmt.Sprintf("Compare, per frontmatter key, the fraction of markdown files managed by %s that include it in two directories, most divergent first", s.name),
+		jsonschema.Object{
+			Properties: map[string]jsonschema.Schema{
+				"a": jsonschema.String{
+					Description: "The path to the first directory",
+				},
+				"b": jsonschema.String{
+					Description: "The path to the second directory",
+				},
+			},
+			Required: []string{"a", "b"},
+		},
+		s.schemaCoverage,
+	)
+}
+
+type schemaCoverageRequest struct {
+	A string `json:"a" jsonschema:"required"`
+	B string `json:"b" jsonschema:"required"`
+}
+
+type schemaCoverageResponse struct {
+	// FilesA is the number of files beneath the first directory.
+	FilesA int `json:"files_a"`
+	// FilesB is the number of files beneath the second directory.
+	FilesB int `json:"files_b"`
+	// Keys are the frontmatter keys used in either directory.
+	Keys []keyCoverage `json:"keys"`
+}
+
+// keyCoverage is the share of files including a frontmatter key in two directories.
+type keyCoverage struct {
+	Key string `json:"key"`
+	// CoverageA is the fraction of files in the first directory including the key.
+	CoverageA float64 `json:"coverage_a"`
+	// CoverageB is the fraction of files in the second directory including the key.
+	CoverageB float64 `json:"coverage_b"`
+	// Difference is CoverageA minus CoverageB.
+	Difference float64 `json:"difference"`
+}
+
+// frontmatterKeyCoverage returns, for each top-level frontmatter key, the
+// fraction of files including it.
+func frontmatterKeyCoverage(files []markdownFileInfo) map[string]float64 {
+	counts := map[string]int{}
+	for _, f := range files {
+		for key := range f.Frontmatter {
+			counts[key]++
+		}
+	}
+	coverage := make(map[string]float64, len(counts))
+	for key, n := range counts {
+		coverage[key] = float64(n) / float64(len(files))
+	}
+	return coverage
+}
+
+// inDirectory reports whether p is beneath dir, recursively.
+func inDirectory(p, dir string) bool {
+	dir = path.Clean(dir)
+	return dir == "." || strings.HasPrefix(p, dir+"/")
+}
+
+func (s *Server) schemaCoverage(ctx context.Context, request *schemaCoverageRequest) (*schemaCoverageResponse, error) {
+	var filesA, filesB []markdownFileInfo
+	for f := range s.markdownFiles(ctx) {
+		if inDirectory(f.Path, request.A) {
+			filesA = append(filesA, f)
+		}
+		if inDirectory(f.Path, request.B) {
+			filesB = append(filesB, f)
+		}
+	}
+	coverageA := frontmatterKeyCoverage(filesA)
+	coverageB := frontmatterKeyCoverage(filesB)
+
+	resp := &schemaCoverageResponse{FilesA: len(filesA), FilesB: len(filesB), Keys: []keyCoverage{}}
+	keys := slices.Collect(maps.Keys(coverageA))
+	for key := range coverageB {
+		if _, ok := coverageA[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	for _, key := range keys {
+		resp.Keys = append(resp.Keys, keyCoverage{
+			Key:        key,
+			CoverageA:  round2(coverageA[key]),
+			CoverageB:  round2(coverageB[key]),
+			Difference: round2(coverageA[key] - coverageB[key]),
+		})
+	}
+	slices.SortFunc(resp.Keys, func(x, y keyCoverage) int {
+		return cmp.Or(cmp.Compare(math.Abs(y.Difference), math.Abs(x.Difference)), cmp.Compare(x.Key, y.Key))
+	})
+	return resp, nil
+}
@@ -0,0 +1,39 @@
+package mcpmds
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"testing/fstest"
+)
+
+func Test_server_schemaCoverage(t *testing.T) {
+	testFS := fstest.MapFS{
+		"team-a/1.md":        {Data: []byte("---\ntitle: One\nowner: alice\n---\n")},
+		"team-a/2.md":        {Data: []byte("---\ntitle: Two\nowner: bob\n---\n")},
+		"team-a/nested/3.md": {Data: []byte("---\ntitle: Three\n---\n")},
+		"team-a/nested/4.md": {Data: []byte("---\ntitle: Four\nowner: carol\n---\n")},
+		"team-b/1.md":        {Data: []byte("---\ntitle: One\nstatus: done\n---\n")},
+		"team-b/2.md":        {Data: []byte("# No frontmatter\n")},
+		"team-ab/x.md":       {Data: []byte("---\nunrelated: true\n---\n")},
+	}
+
+	s := &Server{fs: testFS}
+
+	got, err := s.schemaCoverage(context.Background(), &schemaCoverageRequest{A: "team-a", B: "team-b/"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := &schemaCoverageResponse{
+		FilesA: 4,
+		FilesB: 2,
+		Keys: []keyCoverage{
+			{Key: "owner", CoverageA: 0.75, CoverageB: 0, Difference: 0.75},
+			{Key: "status", CoverageA: 0, CoverageB: 0.5, Difference: -0.5},
+			{Key: "title", CoverageA: 1, CoverageB: 0.5, Difference: 0.5},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("schemaCoverage() = %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,26 @@
+package mcpmds
+
+import (
+	"io/fs"
+	"time"
+)
+
+// WithCreatedTime includes the creation time of each markdown file in its
+// metadata when the underlying filesystem provides it.
+// Creation time is read from fs.FileInfo.Sys(), either from a value with a
+// CreatedTime() time.Time method or from the platform's stat structure where
+// it records birth time (macOS, FreeBSD, NetBSD, and Windows).
+// Files without a known creation time omit the field.
+func WithCreatedTime(enabled bool) ServerOption {
+	return func(s *Server) {
+		s.createdTime = enabled
+	}
+}
+
+// createdTime returns the creation time recorded in info, if any.
+func createdTime(info fs.FileInfo) (time.Time, bool) {
+	if c, ok := info.Sys().(interface{ CreatedTime() time.Time }); ok {
+		return c.CreatedTime(), true
+	}
+	return sysCreatedTime(info.Sys())
+}
@@ -0,0 +1,17 @@
+//go:build darwin || freebsd || netbsd
+
+package mcpmds
+
+import (
+	"syscall"
+	"time"
+)
+
+// sysCreatedTime returns the birth time recorded in a syscall.Stat_t.
+func sysCreatedTime(sys any) (time.Time, bool) {
+	st, ok := sys.(*syscall.Stat_t)
+	if !ok {
+		return time.Time{}, false
+	}
+	return time.Unix(st.Birthtimespec.Unix()), true
+}
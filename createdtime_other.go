@@ -0,0 +1,10 @@
+//go:build !darwin && !freebsd && !netbsd && !windows
+
+package mcpmds
+
+import "time"
+
+// sysCreatedTime reports that creation time is unavailable on this platform.
+func sysCreatedTime(sys any) (time.Time, bool) {
+	return time.Time{}, false
+}
@@ -0,0 +1,61 @@
+package mcpmds
+
+import (
+	"context"
+	"testing"
+	"testing/fstest"
+	"time"
+)
+
+// fakeSys is a FileInfo.Sys() value that records a creation time.
+type fakeSys struct {
+	created time.Time
+}
+
+func (f fakeSys) CreatedTime() time.Time { return f.created }
+
+func Test_server_createdTime(t *testing.T) {
+	created := time.Date(2020, 5, 1, 12, 0, 0, 0, time.UTC)
+	testFS := fstest.MapFS{
+		"with_birth.md":    {Data: []byte("a"), ModTime: created.Add(time.Hour), Sys: fakeSys{created: created}},
+		"without_birth.md": {Data: []byte("b"), ModTime: created},
+	}
+
+	tests := []struct {
+		name string
+		opts []ServerOption
+		want map[string]*time.Time
+	}{
+		{
+			name: "Disabled by default",
+			want: map[string]*time.Time{"with_birth.md": nil, "without_birth.md": nil},
+		},
+		{
+			name: "Enabled",
+			opts: []ServerOption{WithCreatedTime(true)},
+			want: map[string]*time.Time{"with_birth.md": &created, "without_birth.md": nil},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Server{fs: testFS}
+			for _, opt := range tt.opts {
+				opt(s)
+			}
+			resp, err := s.listMarkdownFiles(context.Background(), nil)
+			if err != nil {
+				t.Fatalf("listMarkdownFiles() error = %v", err)
+			}
+			for _, f := range resp.Files {
+				want := tt.want[f.Path]
+				switch {
+				case want == nil && f.CreatedTime != nil:
+					t.Errorf("%s: CreatedTime = %v, want nil", f.Path, *f.CreatedTime)
+				case want != nil && (f.CreatedTime == nil || !f.CreatedTime.Equal(*want)):
+					t.Errorf("%s: CreatedTime = %v, want %v", f.Path, f.CreatedTime, *want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,17 @@
+//go:build windows
+
+package mcpmds
+
+import (
+	"syscall"
+	"time"
+)
+
+// sysCreatedTime returns the creation time recorded in a syscall.Win32FileAttributeData.
+func sysCreatedTime(sys any) (time.Time, bool) {
+	data, ok := sys.(*syscall.Win32FileAttributeData)
+	if !ok {
+		return time.Time{}, false
+	}
+	return time.Unix(0, data.CreationTime.Nanoseconds()), true
+}
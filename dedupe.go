@@ -0,0 +1,44 @@
+package mcpmds
+
+import (
+	"crypto/sha256"
+	"iter"
+)
+
+// WithDeduplicateByHash collapses markdown files with identical content into a
+// single listing entry, such as copies produced by symlinks or union
+// filesystems. The lexicographically first path is listed, with the other
+// paths reported as its duplicates. Duplicates remain readable by path.
+func WithDeduplicateByHash(enabled bool) ServerOption {
+	return func(s *Server) {
+		s.deduplicateByHash = enabled
+	}
+}
+
+// deduplicate returns files with entries of identical content merged into the
+// first of them. Files that cannot be read are passed through unchanged.
+func (s *Server) deduplicate(files iter.Seq[markdownFileInfo]) iter.Seq[markdownFileInfo] {
+	return func(yield func(markdownFileInfo) bool) {
+		var unique []markdownFileInfo
+		first := map[[sha256.Size]byte]int{}
+		for f := range files {
+			content, err := s.readMarkdown(f.Path)
+			if err != nil {
+				unique = append(unique, f)
+				continue
+			}
+			sum := sha256.Sum256(content)
+			if i, ok := first[sum]; ok {
+				unique[i].Duplicates = append(unique[i].Duplicates, f.Path)
+				continue
+			}
+			first[sum] = len(unique)
+			unique = append(unique, f)
+		}
+		for _, f := range unique {
+			if !yield(f) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,62 @@
+package mcpmds
+
+import (
+	"context"
+	"reflect"
+	"slices"
+	"testing"
+	"testing/fstest"
+)
+
+func Test_server_deduplicateByHash(t *testing.T) {
+	testFS := fstest.MapFS{
+		"b/copy.md":     {Data: []byte("---\ntitle: Same\n---\nbody\n")},
+		"a/original.md": {Data: []byte("---\ntitle: Same\n---\nbody\n")},
+		"c/copy.md":     {Data: []byte("---\ntitle: Same\n---\nbody\n")},
+		"unique.md":     {Data: []byte("# Unique\n")},
+	}
+
+	tests := []struct {
+		name           string
+		opts           []ServerOption
+		wantPaths      []string
+		wantDuplicates map[string][]string
+	}{
+		{
+			name:           "Enabled",
+			opts:           []ServerOption{WithDeduplicateByHash(true)},
+			wantPaths:      []string{"a/original.md", "unique.md"},
+			wantDuplicates: map[string][]string{"a/original.md": {"b/copy.md", "c/copy.md"}},
+		},
+		{
+			name:      "Disabled",
+			wantPaths: []string{"a/original.md", "b/copy.md", "c/copy.md", "unique.md"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Server{fs: testFS}
+			for _, opt := range tt.opts {
+				opt(s)
+			}
+			files, err := s.collectMarkdownFiles(context.Background())
+			if err != nil {
+				t.Fatalf("collectMarkdownFiles() error = %v", err)
+			}
+			var paths []string
+			for _, f := range files {
+				paths = append(paths, f.Path)
+				if !reflect.DeepEqual(f.Duplicates, tt.wantDuplicates[f.Path]) {
+					t.Errorf("duplicates of %s = %v, want %v", f.Path, f.Duplicates, tt.wantDuplicates[f.Path])
+				}
+			}
+			if !slices.Equal(paths, tt.wantPaths) {
+				t.Errorf("paths = %v, want %v", paths, tt.wantPaths)
+			}
+			if n := len(slices.Collect(s.markdownFiles(context.Background()))); n != len(testFS) {
+				t.Errorf("markdownFiles() returned %d files, want all %d", n, len(testFS))
+			}
+		})
+	}
+}
@@ -0,0 +1,49 @@
+package mcpmds
+
+import (
+	"fmt"
+	"strings"
+)
+
+// frontmatterDelimiter is a custom frontmatter delimiter pair added by
+// WithFrontmatterDelimiter.
+type frontmatterDelimiter struct {
+	open, close string
+	format      string
+}
+
+// WithFrontmatterDelimiter recognizes frontmatter blocks opened by the line
+// open and closed by the line close, parsed as format: "yaml", "toml", or
+// "json". The built-in "---", "+++", and ";;;" delimiters remain recognized
+// and take precedence. It may be given several times.
+func WithFrontmatterDelimiter(open, close, format string) ServerOption {
+	return func(s *Server) {
+		s.frontmatterDelimiters = append(s.frontmatterDelimiters, frontmatterDelimiter{open: open, close: close, format: format})
+	}
+}
+
+// frontmatterFormat returns the frontmatter format of d, or false if d names
+// an unknown format.
+func (d frontmatterDelimiter) frontmatterFormat() (frontmatterFormat, bool) {
+	for _, f := range builtinFrontmatterFormats {
+		if f.Name == d.format {
+			f.Delimiter, f.Close = d.open, d.close
+			return f, true
+		}
+	}
+	return frontmatterFormat{}, false
+}
+
+// validateFrontmatterDelimiters reports an error for custom delimiters that are
+// blank or name an unknown format.
+func (s *Server) validateFrontmatterDelimiters() error {
+	for _, d := range s.frontmatterDelimiters {
+		if strings.TrimSpace(d.open) == "" || strings.TrimSpace(d.close) == "" {
+			return fmt.Errorf("invalid frontmatter delimiter %q %q: delimiters must not be blank", d.open, d.close)
+		}
+		if _, ok := d.frontmatterFormat(); !ok {
+			return fmt.Errorf("invalid frontmatter delimiter %q %q: unknown format %q", d.open, d.close, d.format)
+		}
+	}
+	return nil
+}
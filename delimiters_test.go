@@ -0,0 +1,59 @@
+package mcpmds
+
+import (
+	"reflect"
+	"testing"
+	"testing/fstest"
+)
+
+func TestWithFrontmatterDelimiter(t *testing.T) {
+	testFS := fstest.MapFS{
+		"legacy.md":  {Data: []byte("~~~\ntitle: Legacy\ntags: [old]\n~~~\nbody\n")},
+		"modern.md":  {Data: []byte("---\ntitle: Modern\n---\nbody\n")},
+		"open.md":    {Data: []byte("<!--\ntitle = \"Open\"\n-->\nbody\n")},
+		"unknown.md": {Data: []byte("%%%\ntitle: Unknown\n%%%\nbody\n")},
+	}
+	s := newServer("test", "test", testFS,
+		WithFrontmatterDelimiter("~~~", "~~~", "yaml"),
+		WithFrontmatterDelimiter("<!--", "-->", "toml"),
+	)
+	if _, err := s.server(); err != nil {
+		t.Fatalf("server() error = %v", err)
+	}
+
+	tests := []struct {
+		path string
+		want map[string]any
+	}{
+		{"legacy.md", map[string]any{"title": "Legacy", "tags": []any{"old"}}},
+		{"modern.md", map[string]any{"title": "Modern"}},
+		{"open.md", map[string]any{"title": "Open"}},
+		{"unknown.md", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			content := testFS[tt.path].Data
+			got, err := s.readFrontmatter(content)
+			if err != nil {
+				t.Fatalf("readFrontmatter() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("readFrontmatter() = %#v, want %#v", got, tt.want)
+			}
+			if _, _, body := s.splitFrontmatter(content); tt.want != nil && string(body) != "body\n" {
+				t.Errorf("body = %q, want %q", body, "body\n")
+			}
+		})
+	}
+}
+
+func TestWithFrontmatterDelimiter_invalid(t *testing.T) {
+	for _, opt := range []ServerOption{
+		WithFrontmatterDelimiter("~~~", "~~~", "xml"),
+		WithFrontmatterDelimiter("", "~~~", "yaml"),
+	} {
+		if _, err := newServer("test", "test", fstest.MapFS{}, opt).server(); err == nil {
+			t.Error("server() error = nil, want error")
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package mcpmds
+
+import (
+	"strings"
+	"text/template"
+)
+
+// WithResourceDescriptionKeys limits the frontmatter included in resource
+// descriptions to the given keys, keeping descriptions concise.
+// Keys missing from a file's frontmatter are omitted.
+func WithResourceDescriptionKeys(keys ...string) ServerOption {
+	return func(s *Server) {
+		s.descriptionKeys = append(s.descriptionKeys, keys...)
+	}
+}
+
+// WithResourceDescriptionTemplate renders resource descriptions with tmpl
+// instead of JSON-encoding the frontmatter.
+// The template is executed with the file's metadata, so it can refer to
+// fields such as {{.Path}} and {{.Frontmatter.title}}.
+// It takes precedence over WithResourceDescriptionKeys.
+func WithResourceDescriptionTemplate(tmpl *template.Template) ServerOption {
+	return func(s *Server) {
+		s.descriptionTemplate = tmpl
+	}
+}
+
+// resourceDescription renders the description of the resource registered for f.
+func (s *Server) resourceDescription(f markdownFileInfo) (string, error) {
+	if s.descriptionTemplate != nil {
+		var b strings.Builder
+		if err := s.descriptionTemplate.Execute(&b, f); err != nil {
+			return "", err
+		}
+		return b.String(), nil
+	}
+	frontmatter := f.Frontmatter
+	if len(s.descriptionKeys) > 0 && frontmatter != nil {
+		frontmatter = make(map[string]any, len(s.descriptionKeys))
+		for _, key := range s.descriptionKeys {
+			if v, ok := f.Frontmatter[key]; ok {
+				frontmatter[key] = v
+			}
+		}
+	}
+	desc, err := marshalOrderedFrontmatter(frontmatter, f.frontmatterKeys)
+	if err != nil {
+		return "", err
+	}
+	return string(desc), nil
+}
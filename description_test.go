@@ -0,0 +1,69 @@
+package mcpmds
+
+import (
+	"testing"
+	"text/template"
+)
+
+func Test_server_resourceDescription(t *testing.T) {
+	info := markdownFileInfo{
+		Path: "docs/guide.md",
+		Frontmatter: map[string]any{
+			"title":  "Guide",
+			"tags":   []any{"go", "mcp"},
+			"author": "someone",
+		},
+	}
+
+	tests := []struct {
+		name string
+		opts []ServerOption
+		info markdownFileInfo
+		want string
+	}{
+		{
+			name: "Full frontmatter by default",
+			info: info,
+			want: `{"author":"someone","tags":["go","mcp"],"title":"Guide"}`,
+		},
+		{
+			name: "Key subset",
+			opts: []ServerOption{WithResourceDescriptionKeys("title", "tags", "missing")},
+			info: info,
+			want: `{"tags":["go","mcp"],"title":"Guide"}`,
+		},
+		{
+			name: "Key subset without frontmatter",
+			opts: []ServerOption{WithResourceDescriptionKeys("title")},
+			info: markdownFileInfo{Path: "plain.md"},
+			want: `null`,
+		},
+		{
+			name: "Template",
+			opts: []ServerOption{
+				WithResourceDescriptionKeys("title"),
+				WithResourceDescriptionTemplate(template.Must(template.New("desc").Parse(
+					`{{.Frontmatter.title}} ({{.Path}}){{with .Frontmatter.tags}} - tags: {{range $i, $t := .}}{{if $i}}, {{end}}{{$t}}{{end}}{{end}}`,
+				))),
+			},
+			info: info,
+			want: `Guide (docs/guide.md) - tags: go, mcp`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Server{}
+			for _, opt := range tt.opts {
+				opt(s)
+			}
+			got, err := s.resourceDescription(tt.info)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("resourceDescription() got = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
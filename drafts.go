@@ -0,0 +1,54 @@
+package mcpmds
+
+import "strings"
+
+// defaultDraftKey is the frontmatter key used by WithHideDrafts when no key is given.
+const defaultDraftKey = "draft"
+
+// WithHideDrafts hides markdown files whose frontmatter marks them as drafts.
+// A file is a draft when the given frontmatter key holds a truthy value.
+// Hidden files are excluded from listings and resources, and reading them fails
+// as if they did not exist. If key is empty, "draft" is used.
+func WithHideDrafts(key string) ServerOption {
+	return func(s *Server) {
+		if key == "" {
+			key = defaultDraftKey
+		}
+		s.hideDraftsKey = key
+	}
+}
+
+// hidden reports whether the markdown file with the given content must not be served.
+func (s *Server) hidden(content []byte) (bool, error) {
+	if s.hideDraftsKey == "" {
+		return false, nil
+	}
+	frontmatter, err := s.parseFrontmatter(content)
+	if err != nil {
+		return false, err
+	}
+	return truthy(frontmatter[s.hideDraftsKey]), nil
+}
+
+// truthy reports whether a frontmatter value should be treated as true.
+// Besides booleans, it accepts common boolean-like strings and non-zero numbers.
+func truthy(v any) bool {
+	switch v := v.(type) {
+	case bool:
+		return v
+	case string:
+		switch strings.ToLower(strings.TrimSpace(v)) {
+		case "true", "yes", "on", "1":
+			return true
+		}
+	case int:
+		return v != 0
+	case int64:
+		return v != 0
+	case uint64:
+		return v != 0
+	case float64:
+		return v != 0
+	}
+	return false
+}
@@ -0,0 +1,102 @@
+package mcpmds
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"slices"
+	"testing"
+	"testing/fstest"
+
+	"github.com/Warashi/go-modelcontextprotocol/mcp"
+)
+
+func Test_server_hideDrafts(t *testing.T) {
+	testFS := fstest.MapFS{
+		"published.md":   {Data: []byte("---\ntitle: Published\ndraft: false\n---\nbody")},
+		"plain.md":       {Data: []byte("no frontmatter")},
+		"draft.md":       {Data: []byte("---\ntitle: Draft\ndraft: true\n---\nbody")},
+		"draft_toml.md":  {Data: []byte("+++\ntitle = \"Draft\"\ndraft = true\n+++\nbody")},
+		"string_yes.md":  {Data: []byte("---\ndraft: \"yes\"\n---\nbody")},
+		"wip.md":         {Data: []byte("---\nwip: true\n---\nbody")},
+		"dir/nested.md":  {Data: []byte("---\ndraft: true\n---\nbody")},
+		"dir/visible.md": {Data: []byte("---\ndraft: 0\n---\nbody")},
+	}
+
+	tests := []struct {
+		name        string
+		opts        []ServerOption
+		wantVisible []string
+		wantHidden  []string
+	}{
+		{
+			name:        "Drafts visible without option",
+			opts:        nil,
+			wantVisible: []string{"dir/nested.md", "dir/visible.md", "draft.md", "draft_toml.md", "plain.md", "published.md", "string_yes.md", "wip.md"},
+		},
+		{
+			name:        "Default draft key",
+			opts:        []ServerOption{WithHideDrafts("")},
+			wantVisible: []string{"dir/visible.md", "plain.md", "published.md", "wip.md"},
+			wantHidden:  []string{"dir/nested.md", "draft.md", "draft_toml.md", "string_yes.md"},
+		},
+		{
+			name:        "Custom draft key",
+			opts:        []ServerOption{WithHideDrafts("wip")},
+			wantVisible: []string{"dir/nested.md", "dir/visible.md", "draft.md", "draft_toml.md", "plain.md", "published.md", "string_yes.md"},
+			wantHidden:  []string{"wip.md"},
+		},
+		{
+			name:        "Draft key excluded from frontmatter is still honored",
+			opts:        []ServerOption{WithHideDrafts("draft"), WithExcludeFrontmatter("draft")},
+			wantVisible: []string{"dir/visible.md", "plain.md", "published.md", "wip.md"},
+			wantHidden:  []string{"dir/nested.md", "draft.md", "draft_toml.md", "string_yes.md"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Server{fs: testFS}
+			for _, opt := range tt.opts {
+				opt(s)
+			}
+
+			resp, err := s.listMarkdownFiles(context.Background(), nil)
+			if err != nil {
+				t.Fatalf("listMarkdownFiles() error = %v", err)
+			}
+			var got []string
+			for _, f := range resp.Files {
+				got = append(got, f.Path)
+			}
+			if !slices.Equal(got, tt.wantVisible) {
+				t.Errorf("listMarkdownFiles() got = %v, want %v", got, tt.wantVisible)
+			}
+
+			opts, err := s.listResourcesOption()
+			if err != nil {
+				t.Fatalf("listResourcesOption() error = %v", err)
+			}
+			if len(opts) != len(tt.wantVisible) {
+				t.Errorf("listResourcesOption() registered %d resources, want %d", len(opts), len(tt.wantVisible))
+			}
+
+			for _, path := range tt.wantHidden {
+				if _, err := s.readMarkdownFile(context.Background(), &readMarkdownFileRequest{Path: path}); !errors.Is(err, fs.ErrNotExist) {
+					t.Errorf("readMarkdownFile(%q) error = %v, want fs.ErrNotExist", path, err)
+				}
+				req := &mcp.Request[mcp.ReadResourceRequestParams]{
+					Params: mcp.ReadResourceRequestParams{URI: "file://" + path},
+				}
+				if _, err := s.ReadResource(context.Background(), req); !errors.Is(err, fs.ErrNotExist) {
+					t.Errorf("ReadResource(%q) error = %v, want fs.ErrNotExist", path, err)
+				}
+			}
+			for _, path := range tt.wantVisible {
+				if _, err := s.readMarkdownFile(context.Background(), &readMarkdownFileRequest{Path: path}); err != nil {
+					t.Errorf("readMarkdownFile(%q) unexpected error = %v", path, err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,30 @@
+package mcpmds
+
+import (
+	"fmt"
+
+	"golang.org/x/text/encoding"
+)
+
+// WithSourceEncoding sets the character encoding of the served files, such as
+// japanese.ShiftJIS or charmap.ISO8859_1 from golang.org/x/text/encoding.
+// Files are transcoded to UTF-8 when read, before their frontmatter is
+// parsed. By default, files are assumed to be UTF-8 and passed through.
+func WithSourceEncoding(enc encoding.Encoding) ServerOption {
+	return func(s *Server) {
+		s.sourceEncoding = enc
+	}
+}
+
+// decodeSource transcodes content read from the filesystem to UTF-8
+// according to WithSourceEncoding.
+func (s *Server) decodeSource(path string, content []byte) ([]byte, error) {
+	if s.sourceEncoding == nil {
+		return content, nil
+	}
+	decoded, err := s.sourceEncoding.NewDecoder().Bytes(content)
+	if err != nil {
+		return nil, fmt.Errorf("decode %s: %w", path, err)
+	}
+	return decoded, nil
+}
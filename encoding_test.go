@@ -0,0 +1,69 @@
+package mcpmds
+
+import (
+	"context"
+	"testing"
+	"testing/fstest"
+
+	"golang.org/x/text/encoding/charmap"
+	"golang.org/x/text/encoding/japanese"
+)
+
+func TestWithSourceEncoding(t *testing.T) {
+	// "---\ntitle: 日本語\n---\nこんにちは" encoded in Shift_JIS.
+	shiftJIS := []byte("---\ntitle: \x93\xfa\x96\x7b\x8c\xea\n---\n\x82\xb1\x82\xf1\x82\xc9\x82\xbf\x82\xcd")
+	// "---\ntitle: Café\n---\nNaïve" encoded in ISO 8859-1.
+	latin1 := []byte("---\ntitle: Caf\xe9\n---\nNa\xefve")
+
+	tests := []struct {
+		name        string
+		data        []byte
+		opts        []ServerOption
+		wantTitle   string
+		wantContent string
+	}{
+		{
+			name:        "Shift_JIS",
+			data:        shiftJIS,
+			opts:        []ServerOption{WithSourceEncoding(japanese.ShiftJIS)},
+			wantTitle:   "日本語",
+			wantContent: "---\ntitle: 日本語\n---\nこんにちは",
+		},
+		{
+			name:        "Latin-1",
+			data:        latin1,
+			opts:        []ServerOption{WithSourceEncoding(charmap.ISO8859_1)},
+			wantTitle:   "Café",
+			wantContent: "---\ntitle: Café\n---\nNaïve",
+		},
+		{
+			name:        "UTF-8 passthrough",
+			data:        []byte("---\ntitle: 日本語\n---\nこんにちは"),
+			wantTitle:   "日本語",
+			wantContent: "---\ntitle: 日本語\n---\nこんにちは",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newServer("test", "test", fstest.MapFS{"a.md": {Data: tt.data}}, tt.opts...)
+			got, err := s.readMarkdownFile(context.Background(), &readMarkdownFileRequest{Path: "a.md"})
+			if err != nil {
+				t.Fatalf("readMarkdownFile() error = %v", err)
+			}
+			if got.Content != tt.wantContent {
+				t.Errorf("Content = %q, want %q", got.Content, tt.wantContent)
+			}
+			if got.Frontmatter["title"] != tt.wantTitle {
+				t.Errorf("title = %v, want %q", got.Frontmatter["title"], tt.wantTitle)
+			}
+
+			list, err := s.listMarkdownFiles(context.Background(), nil)
+			if err != nil {
+				t.Fatalf("listMarkdownFiles() error = %v", err)
+			}
+			if len(list.Files) != 1 || list.Files[0].Frontmatter["title"] != tt.wantTitle {
+				t.Errorf("listed files = %+v, want title %q", list.Files, tt.wantTitle)
+			}
+		})
+	}
+}
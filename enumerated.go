@@ -0,0 +1,11 @@
+package mcpmds
+
+// WithReadOnlyEnumerated restricts reads to the files that are enumerated by
+// listings and resources. When enabled, reading any other file in the
+// filesystem, such as a non-markdown file, fails as if it did not exist,
+// so the rules that decide what is enumerated also decide what is readable.
+func WithReadOnlyEnumerated(enabled bool) ServerOption {
+	return func(s *Server) {
+		s.readOnlyEnumerated = enabled
+	}
+}
@@ -0,0 +1,65 @@
+package mcpmds
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"testing"
+	"testing/fstest"
+
+	"github.com/Warashi/go-modelcontextprotocol/mcp"
+)
+
+func Test_server_readOnlyEnumerated(t *testing.T) {
+	testFS := fstest.MapFS{
+		"docs/guide.md": {Data: []byte("# Guide\n")},
+		"notes.txt":     {Data: []byte("not enumerated\n")},
+	}
+
+	tests := []struct {
+		name    string
+		opts    []ServerOption
+		path    string
+		wantErr bool
+	}{
+		{
+			name: "Enumerated file",
+			opts: []ServerOption{WithReadOnlyEnumerated(true)},
+			path: "docs/guide.md",
+		},
+		{
+			name:    "Ignored file when enabled",
+			opts:    []ServerOption{WithReadOnlyEnumerated(true)},
+			path:    "notes.txt",
+			wantErr: true,
+		},
+		{
+			name: "Ignored file when disabled",
+			opts: []ServerOption{WithReadOnlyEnumerated(false)},
+			path: "notes.txt",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Server{fs: testFS}
+			for _, opt := range tt.opts {
+				opt(s)
+			}
+
+			_, err := s.readMarkdownFile(context.Background(), &readMarkdownFileRequest{Path: tt.path})
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("readMarkdownFile() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr && !errors.Is(err, fs.ErrNotExist) {
+				t.Errorf("readMarkdownFile() error = %v, want not-found", err)
+			}
+
+			req := &mcp.Request[mcp.ReadResourceRequestParams]{Params: mcp.ReadResourceRequestParams{URI: "file://" + tt.path}}
+			_, err = s.ReadResource(context.Background(), req)
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("ReadResource() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,95 @@
+package mcpmds
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io/fs"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/Warashi/go-modelcontextprotocol/mcp"
+)
+
+// resourcesPathSegment is the path segment beneath the handler's base path at
+// which resources can be fetched with plain HTTP GET requests.
+const resourcesPathSegment = "/resources/"
+
+// WithETag makes HTTP resource reads return an ETag derived from the content
+// hash and answer requests whose If-None-Match header matches it with
+// 304 Not Modified. It applies only to handlers created by NewHandler.
+func WithETag(enabled bool) ServerOption {
+	return func(s *Server) {
+		s.etag = enabled
+	}
+}
+
+// contentETag returns a strong entity tag for content.
+func contentETag(content []byte) string {
+	sum := sha256.Sum256(content)
+	return `"` + hex.EncodeToString(sum[:]) + `"`
+}
+
+// etagMatches reports whether the If-None-Match header value matches etag.
+func etagMatches(ifNoneMatch, etag string) bool {
+	for candidate := range strings.SplitSeq(ifNoneMatch, ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == "*" || candidate == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// resourceHandler serves GET requests for basePath+"/resources/<path>" with the
+// content that resources/read returns for file://<path>, query included.
+// Other requests are passed to next.
+func (s *Server) resourceHandler(basePath string, next http.Handler) http.Handler {
+	// The path stays escaped so that ReadResource unescapes it exactly once.
+	prefix := (&url.URL{Path: strings.TrimSuffix(basePath, "/") + resourcesPathSegment}).EscapedPath()
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		p, ok := strings.CutPrefix(r.URL.EscapedPath(), prefix)
+		if !ok || (r.Method != http.MethodGet && r.Method != http.MethodHead) {
+			next.ServeHTTP(w, r)
+			return
+		}
+		uri := "file://" + p
+		if r.URL.RawQuery != "" {
+			uri += "?" + r.URL.RawQuery
+		}
+		result, err := s.ReadResource(r.Context(), &mcp.Request[mcp.ReadResourceRequestParams]{
+			Params: mcp.ReadResourceRequestParams{URI: uri},
+		})
+		if errors.Is(err, fs.ErrNotExist) {
+			http.Error(w, "Not found", http.StatusNotFound)
+			return
+		}
+		if errors.Is(err, errInvalidResourceRequest) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if err != nil {
+			slog.Error("reading resource failed", "uri", uri, "error", err)
+			http.Error(w, "Internal server error", http.StatusInternalServerError)
+			return
+		}
+		contents := result.Data.Contents[0].(mcp.TextResourceContents)
+		content := []byte(contents.Text)
+
+		w.Header().Set("Content-Type", contents.MimeType+"; charset=utf-8")
+		if s.etag {
+			etag := contentETag(content)
+			w.Header().Set("ETag", etag)
+			if etagMatches(r.Header.Get("If-None-Match"), etag) {
+				w.WriteHeader(http.StatusNotModified)
+				return
+			}
+		}
+		if r.Method == http.MethodHead {
+			return
+		}
+		w.Write(content)
+	})
+}
@@ -0,0 +1,103 @@
+package mcpmds
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"testing/fstest"
+)
+
+func TestNewHandler_etag(t *testing.T) {
+	testFS := fstest.MapFS{
+		"docs/guide.md": {Data: []byte("# Guide\n")},
+	}
+
+	h, err := NewHandler("test-server", "test description", testFS, "http://example.com/sse", WithETag(true))
+	if err != nil {
+		t.Fatalf("NewHandler() error = %v", err)
+	}
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/sse/resources/docs/guide.md", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("fresh fetch status = %d, want %d", rec.Code, http.StatusOK)
+	}
+	if got := rec.Body.String(); got != "# Guide\n" {
+		t.Errorf("fresh fetch body = %q, want %q", got, "# Guide\n")
+	}
+	etag := rec.Header().Get("ETag")
+	if etag != contentETag([]byte("# Guide\n")) {
+		t.Fatalf("ETag = %q, want content hash", etag)
+	}
+
+	tests := []struct {
+		name        string
+		ifNoneMatch string
+		want        int
+	}{
+		{name: "Matching validator", ifNoneMatch: etag, want: http.StatusNotModified},
+		{name: "Weak matching validator", ifNoneMatch: `"other", W/` + etag, want: http.StatusNotModified},
+		{name: "Stale validator", ifNoneMatch: `"stale"`, want: http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/sse/resources/docs/guide.md", nil)
+			req.Header.Set("If-None-Match", tt.ifNoneMatch)
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, req)
+			if rec.Code != tt.want {
+				t.Errorf("status = %d, want %d", rec.Code, tt.want)
+			}
+			if tt.want == http.StatusNotModified && rec.Body.Len() != 0 {
+				t.Errorf("304 response has body %q", rec.Body.String())
+			}
+		})
+	}
+
+	t.Run("Missing resource", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/sse/resources/missing.md", nil))
+		if rec.Code != http.StatusNotFound {
+			t.Errorf("status = %d, want %d", rec.Code, http.StatusNotFound)
+		}
+	})
+}
+
+func TestNewHandler_resources(t *testing.T) {
+	testFS := &flakyFS{
+		MapFS: fstest.MapFS{
+			"100%.md":   {Data: []byte("# Percent\n")},
+			"broken.md": {Data: []byte("# Broken\n")},
+		},
+		failures: map[string]int{"broken.md": 100},
+		opens:    map[string]int{},
+	}
+	h, err := NewHandler("test-server", "test description", testFS, "http://example.com/sse", WithLazyResources(true))
+	if err != nil {
+		t.Fatalf("NewHandler() error = %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		target   string
+		wantCode int
+		wantBody string
+	}{
+		{name: "Escaped path", target: "/sse/resources/100%25.md", wantCode: http.StatusOK, wantBody: "# Percent\n"},
+		{name: "Read failure", target: "/sse/resources/broken.md", wantCode: http.StatusInternalServerError, wantBody: "Internal server error\n"},
+		{name: "Bad query", target: "/sse/resources/100%25.md?around=x", wantCode: http.StatusBadRequest, wantBody: "invalid around parameter: strconv.Atoi: parsing \"x\": invalid syntax\n"},
+		{name: "Traversing path", target: "/sse/resources/..%2Fsecret.md", wantCode: http.StatusBadRequest, wantBody: "invalid resource path \"..%2Fsecret.md\": must not leave the served directory\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.target, nil))
+			if rec.Code != tt.wantCode {
+				t.Errorf("status = %d, want %d", rec.Code, tt.wantCode)
+			}
+			if got := rec.Body.String(); got != tt.wantBody {
+				t.Errorf("body = %q, want %q", got, tt.wantBody)
+			}
+		})
+	}
+}
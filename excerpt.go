@@ -0,0 +1,68 @@
+package mcpmds
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// excerptKeys are the frontmatter keys used as an excerpt, in order of preference.
+var excerptKeys = []string{"description", "summary"}
+
+// WithExcerpt adds an excerpt of at most maxLength characters to the metadata
+// of each markdown file. The excerpt is the frontmatter description or summary
+// when present, and the first paragraph of the body otherwise.
+// Longer text is cut at a word boundary and marked with an ellipsis.
+func WithExcerpt(maxLength int) ServerOption {
+	return func(s *Server) {
+		s.excerptLength = maxLength
+	}
+}
+
+// excerpt returns the excerpt of a markdown file truncated to maxLength characters.
+func excerpt(frontmatter map[string]any, body []byte, maxLength int) string {
+	for _, key := range excerptKeys {
+		if text, ok := frontmatter[key].(string); ok && strings.TrimSpace(text) != "" {
+			return truncateText(strings.Join(strings.Fields(text), " "), maxLength)
+		}
+	}
+	return truncateText(firstParagraph(body), maxLength)
+}
+
+// firstParagraph returns the first paragraph of body, skipping headings,
+// fenced code blocks, and blank lines. Lines are joined with single spaces.
+func firstParagraph(body []byte) string {
+	var words []string
+	for line := range markdownLines(body) {
+		text := strings.TrimSpace(line.Text)
+		switch {
+		case line.InCode || strings.HasPrefix(text, "#"):
+			if len(words) > 0 {
+				return strings.Join(words, " ")
+			}
+		case text == "":
+			if len(words) > 0 {
+				return strings.Join(words, " ")
+			}
+		default:
+			words = append(words, strings.Fields(text)...)
+		}
+	}
+	return strings.Join(words, " ")
+}
+
+// truncateText shortens text to at most maxLength characters, cutting at the
+// last word boundary when possible and appending an ellipsis, which counts
+// toward maxLength.
+func truncateText(text string, maxLength int) string {
+	if utf8.RuneCountInString(text) <= maxLength {
+		return text
+	}
+	if maxLength < 1 {
+		return ""
+	}
+	cut := string([]rune(text)[:maxLength-1])
+	if i := strings.LastIndex(cut, " "); i > 0 {
+		cut = cut[:i]
+	}
+	return strings.TrimRight(cut, " ,.;:") + "…"
+}
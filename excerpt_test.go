@@ -0,0 +1,69 @@
+package mcpmds
+
+import (
+	"context"
+	"testing"
+	"testing/fstest"
+	"unicode/utf8"
+)
+
+func Test_server_excerpt(t *testing.T) {
+	testFS := fstest.MapFS{
+		"described.md": {Data: []byte("---\ntitle: Described\ndescription: A short description.\nsummary: Not used.\n---\n# Title\n\nBody paragraph.")},
+		"summary.md":   {Data: []byte("+++\nsummary = \"From the summary key.\"\n+++\nBody paragraph.")},
+		"paragraph.md": {Data: []byte("# Heading\n\n```\ncode first\n```\n\nFirst paragraph spans\ntwo lines.\n\nSecond paragraph.")},
+		"long.md":      {Data: []byte("The quick brown fox jumps over the lazy dog again and again.")},
+		"empty.md":     {Data: []byte("# Only a heading\n")},
+	}
+
+	s := &Server{fs: testFS}
+	WithExcerpt(30)(s)
+
+	want := map[string]string{
+		"described.md": "A short description.",
+		"summary.md":   "From the summary key.",
+		"paragraph.md": "First paragraph spans two…",
+		"long.md":      "The quick brown fox jumps…",
+		"empty.md":     "",
+	}
+
+	resp, err := s.listMarkdownFiles(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("listMarkdownFiles() error = %v", err)
+	}
+	for _, f := range resp.Files {
+		if f.Excerpt != want[f.Path] {
+			t.Errorf("%s: Excerpt = %q, want %q", f.Path, f.Excerpt, want[f.Path])
+		}
+	}
+}
+
+func Test_truncateText(t *testing.T) {
+	tests := []struct {
+		name      string
+		text      string
+		maxLength int
+		want      string
+	}{
+		{name: "Shorter than limit", text: "short text", maxLength: 20, want: "short text"},
+		{name: "Exactly at limit", text: "exactly ten", maxLength: 11, want: "exactly ten"},
+		{name: "One over limit", text: "exactly ten!", maxLength: 11, want: "exactly…"},
+		{name: "Cut at word boundary", text: "alpha beta gamma", maxLength: 13, want: "alpha beta…"},
+		{name: "Single long word", text: "supercalifragilistic", maxLength: 5, want: "supe…"},
+		{name: "Ellipsis alone", text: "ab", maxLength: 1, want: "…"},
+		{name: "Zero limit", text: "ab", maxLength: 0, want: ""},
+		{name: "Multibyte characters", text: "日本語のテキストです", maxLength: 4, want: "日本語…"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := truncateText(tt.text, tt.maxLength)
+			if got != tt.want {
+				t.Errorf("truncateText() got = %q, want %q", got, tt.want)
+			}
+			if n := utf8.RuneCountInString(got); n > tt.maxLength {
+				t.Errorf("truncateText() got %d characters, want at most %d", n, tt.maxLength)
+			}
+		})
+	}
+}
@@ -0,0 +1,45 @@
+package mcpmds
+
+import (
+	"maps"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// defaultExtension is the file extension served when WithFileExtensions is not used.
+const defaultExtension = ".md"
+
+// WithFileExtensions sets the file extensions served as markdown, such as
+// ".mdx" or ".markdown". Extensions are matched case-sensitively and may be
+// given with or without the leading dot. If unset, only ".md" files are served.
+func WithFileExtensions(exts ...string) ServerOption {
+	return func(s *Server) {
+		if s.extensions == nil {
+			s.extensions = map[string]bool{}
+		}
+		for _, ext := range exts {
+			if !strings.HasPrefix(ext, ".") {
+				ext = "." + ext
+			}
+			s.extensions[ext] = true
+		}
+	}
+}
+
+// isMarkdown reports whether the file at path is served as a markdown file.
+func (s *Server) isMarkdown(path string) bool {
+	ext := filepath.Ext(path)
+	if len(s.extensions) == 0 {
+		return ext == defaultExtension
+	}
+	return s.extensions[ext]
+}
+
+// fileExtensions returns the served file extensions in sorted order.
+func (s *Server) fileExtensions() []string {
+	if len(s.extensions) == 0 {
+		return []string{defaultExtension}
+	}
+	return slices.Sorted(maps.Keys(s.extensions))
+}
@@ -0,0 +1,69 @@
+package mcpmds
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"testing/fstest"
+
+	"github.com/Warashi/go-modelcontextprotocol/mcp"
+)
+
+func Test_server_fileExtensions(t *testing.T) {
+	testFS := fstest.MapFS{
+		"a.md":       {Data: []byte("# A\n")},
+		"b.mdx":      {Data: []byte("# B\n")},
+		"c.markdown": {Data: []byte("# C\n")},
+		"d.txt":      {Data: []byte("D\n")},
+	}
+
+	tests := []struct {
+		name string
+		opts []ServerOption
+		want []string
+	}{
+		{
+			name: "Default",
+			want: []string{"a.md"},
+		},
+		{
+			name: "Configured extensions",
+			opts: []ServerOption{WithFileExtensions(".md", "mdx")},
+			want: []string{"a.md", "b.mdx"},
+		},
+		{
+			name: "Without .md",
+			opts: []ServerOption{WithFileExtensions(".markdown")},
+			want: []string{"c.markdown"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Server{fs: testFS}
+			for _, opt := range tt.opts {
+				opt(s)
+			}
+			var got []string
+			for f := range s.markdownFiles(context.Background()) {
+				got = append(got, f.Path)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("markdownFiles() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("MIME type", func(t *testing.T) {
+		s := &Server{fs: testFS}
+		WithFileExtensions(".mdx")(s)
+		req := &mcp.Request[mcp.ReadResourceRequestParams]{Params: mcp.ReadResourceRequestParams{URI: "file://b.mdx"}}
+		got, err := s.ReadResource(context.Background(), req)
+		if err != nil {
+			t.Fatalf("ReadResource() error = %v", err)
+		}
+		if mime := got.Data.Contents[0].(mcp.TextResourceContents).MimeType; mime != "text/markdown" {
+			t.Errorf("MimeType = %q, want text/markdown", mime)
+		}
+	})
+}
@@ -0,0 +1,74 @@
+package mcpmds
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/Warashi/go-modelcontextprotocol/jsonschema"
+	"github.com/Warashi/go-modelcontextprotocol/mcp"
+)
+
+func (s *Server) externalDomainsTool() mcp.Tool[*externalDomainsRequest, *externalDomainsResponse] {
+	return mcp.NewToolFunc(
+		fmt.Sprintf("external_domains_%s", s.name),
+		fmt.Sprintf("List the external domains linked from markdown files managed by %s with link counts, most linked first", s.name),
+		jsonschema.Object{},
+		s.externalDomains,
+	)
+}
+
+type externalDomainsRequest struct{}
+
+type externalDomainsResponse struct {
+	Domains []externalDomain `json:"domains"`
+}
+
+// externalDomain is a host linked from the corpus.
+type externalDomain struct {
+	// Domain is the lower-cased host name, without port.
+	Domain string `json:"domain"`
+	// Count is the number of links to the domain.
+	Count int `json:"count"`
+	// Files are the paths of the files linking to the domain, in walk order.
+	Files []string `json:"files"`
+}
+
+func (s *Server) externalDomains(ctx context.Context, _ *externalDomainsRequest) (*externalDomainsResponse, error) {
+	domains := map[string]*externalDomain{}
+	for f := range s.markdownFiles(ctx) {
+		content, err := s.readMarkdown(f.Path)
+		if err != nil {
+			return nil, err
+		}
+		_, _, body := s.splitFrontmatter(content)
+		for _, link := range extractLinks(body) {
+			u, err := url.Parse(link.URL)
+			if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Hostname() == "" {
+				continue
+			}
+			host := strings.ToLower(u.Hostname())
+			d, ok := domains[host]
+			if !ok {
+				d = &externalDomain{Domain: host}
+				domains[host] = d
+			}
+			d.Count++
+			if !slices.Contains(d.Files, f.Path) {
+				d.Files = append(d.Files, f.Path)
+			}
+		}
+	}
+
+	resp := &externalDomainsResponse{Domains: []externalDomain{}}
+	for _, d := range domains {
+		resp.Domains = append(resp.Domains, *d)
+	}
+	slices.SortFunc(resp.Domains, func(a, b externalDomain) int {
+		return cmp.Or(cmp.Compare(b.Count, a.Count), cmp.Compare(a.Domain, b.Domain))
+	})
+	return resp, nil
+}
@@ -0,0 +1,30 @@
+package mcpmds
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"testing/fstest"
+)
+
+func Test_server_externalDomains(t *testing.T) {
+	testFS := fstest.MapFS{
+		"a.md": {Data: []byte("[Go](https://go.dev/doc) and [again](https://GO.dev:443/blog)\n\n[relative](b.md) [root](/docs/c.md) [anchor](#top)\n")},
+		"b.md": {Data: []byte("![logo](http://cdn.example.com/logo.png) [go](https://go.dev/)\n[mail](mailto:me@example.com)\n\n```\n[code](https://ignored.example.org)\n```\n")},
+		"c.md": {Data: []byte("No links here.\n")},
+	}
+
+	s := &Server{fs: testFS}
+
+	got, err := s.externalDomains(context.Background(), &externalDomainsRequest{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []externalDomain{
+		{Domain: "go.dev", Count: 3, Files: []string{"a.md", "b.md"}},
+		{Domain: "cdn.example.com", Count: 1, Files: []string{"b.md"}},
+	}
+	if !reflect.DeepEqual(got.Domains, want) {
+		t.Errorf("externalDomains() = %#v, want %#v", got.Domains, want)
+	}
+}
@@ -0,0 +1,71 @@
+package mcpmds
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/Warashi/go-modelcontextprotocol/jsonschema"
+	"github.com/Warashi/go-modelcontextprotocol/mcp"
+)
+
+// defaultExtremesCount is the number of files returned at each end when none is given.
+const defaultExtremesCount = 5
+
+func (s *Server) extremesTool() mcp.Tool[*extremesRequest, *extremesResponse] {
+	return mcp.NewToolFunc(
+		fmt.Sprintf("extremes_%s", s.name),
+		fmt.Sprintf("Return the longest and shortest markdown files managed by %s by body size, excluding frontmatter", s.name),
+		jsonschema.Object{
+			Properties: map[string]jsonschema.Schema{
+				"n": jsonschema.Integer{
+					Description: fmt.Sprintf("The number of files to return at each end; defaults to %d", defaultExtremesCount),
+				},
+			},
+		},
+		s.extremes,
+	)
+}
+
+type extremesRequest struct {
+	N int `json:"n"`
+}
+
+type extremesResponse struct {
+	// Longest are the files with the largest bodies, largest first.
+	Longest []fileBodySize `json:"longest"`
+	// Shortest are the files with the smallest bodies, smallest first.
+	Shortest []fileBodySize `json:"shortest"`
+}
+
+// fileBodySize is the body size of a markdown file.
+type fileBodySize struct {
+	Path     string `json:"path"`
+	BodySize int64  `json:"body_size"`
+}
+
+func (s *Server) extremes(ctx context.Context, request *extremesRequest) (*extremesResponse, error) {
+	n := request.N
+	if n <= 0 {
+		n = defaultExtremesCount
+	}
+	sizes := []fileBodySize{}
+	for f := range s.markdownFiles(ctx) {
+		content, err := s.readMarkdown(f.Path)
+		if err != nil {
+			return nil, err
+		}
+		sizes = append(sizes, fileBodySize{Path: f.Path, BodySize: s.bodySize(content)})
+	}
+
+	slices.SortStableFunc(sizes, func(a, b fileBodySize) int {
+		return cmp.Or(cmp.Compare(a.BodySize, b.BodySize), cmp.Compare(a.Path, b.Path))
+	})
+	shortest := slices.Clone(sizes[:min(n, len(sizes))])
+	slices.SortStableFunc(sizes, func(a, b fileBodySize) int {
+		return cmp.Or(cmp.Compare(b.BodySize, a.BodySize), cmp.Compare(a.Path, b.Path))
+	})
+	longest := sizes[:min(n, len(sizes))]
+	return &extremesResponse{Longest: longest, Shortest: shortest}, nil
+}
@@ -0,0 +1,61 @@
+package mcpmds
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"testing/fstest"
+)
+
+func Test_server_extremes(t *testing.T) {
+	testFS := fstest.MapFS{
+		"stub.md":    {Data: []byte("---\ntitle: A very long title that does not count\n---\nx")},
+		"short.md":   {Data: []byte("short")},
+		"medium.md":  {Data: []byte("a medium body")},
+		"long.md":    {Data: []byte("---\ntitle: L\n---\nthis is the longest body of all")},
+		"medium2.md": {Data: []byte("another body!")},
+	}
+
+	s := &Server{fs: testFS}
+
+	tests := []struct {
+		name string
+		n    int
+		want *extremesResponse
+	}{
+		{
+			name: "Two at each end",
+			n:    2,
+			want: &extremesResponse{
+				Longest:  []fileBodySize{{Path: "long.md", BodySize: 31}, {Path: "medium.md", BodySize: 13}},
+				Shortest: []fileBodySize{{Path: "stub.md", BodySize: 1}, {Path: "short.md", BodySize: 5}},
+			},
+		},
+		{
+			name: "More than available",
+			n:    10,
+			want: &extremesResponse{
+				Longest: []fileBodySize{
+					{Path: "long.md", BodySize: 31}, {Path: "medium.md", BodySize: 13}, {Path: "medium2.md", BodySize: 13},
+					{Path: "short.md", BodySize: 5}, {Path: "stub.md", BodySize: 1},
+				},
+				Shortest: []fileBodySize{
+					{Path: "stub.md", BodySize: 1}, {Path: "short.md", BodySize: 5}, {Path: "medium.md", BodySize: 13},
+					{Path: "medium2.md", BodySize: 13}, {Path: "long.md", BodySize: 31},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := s.extremes(context.Background(), &extremesRequest{N: tt.n})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("extremes() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,163 @@
+package mcpmds
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"path"
+	"slices"
+	"time"
+
+	"github.com/Warashi/go-modelcontextprotocol/jsonschema"
+	"github.com/Warashi/go-modelcontextprotocol/mcp"
+)
+
+// Filter selects markdown files. A file matches when it satisfies every
+// non-empty criterion.
+type Filter struct {
+	// Globs are path.Match patterns; a file matches if its path matches any of them.
+	Globs []string
+	// Frontmatter maps frontmatter keys to required values. A list value
+	// matches if any of its elements equals the required value.
+	// Values are converted to the type of the frontmatter value before being
+	// compared, so the string "1" matches the number 1.
+	Frontmatter map[string]any
+	// Tags are the tags a file must all have in its frontmatter tags key.
+	Tags []string
+}
+
+// validate reports whether the filter's globs are well-formed.
+func (f Filter) validate() error {
+	for _, glob := range f.Globs {
+		if _, err := path.Match(glob, ""); err != nil {
+			return fmt.Errorf("invalid glob %q: %w", glob, err)
+		}
+	}
+	return nil
+}
+
+// filterMatches reports whether the file satisfies the filter.
+func (s *Server) filterMatches(f Filter, info markdownFileInfo) bool {
+	if len(f.Globs) > 0 && !slices.ContainsFunc(f.Globs, func(glob string) bool {
+		ok, _ := path.Match(glob, info.Path)
+		return ok
+	}) {
+		return false
+	}
+	for key, want := range f.Frontmatter {
+		if !valueMatches(info.Frontmatter[key], want) {
+			return false
+		}
+	}
+	tags := s.frontmatterTags(info.Frontmatter)
+	for _, tag := range f.Tags {
+		if !slices.Contains(tags, s.normalizeTag(tag)) {
+			return false
+		}
+	}
+	return true
+}
+
+// valueMatches reports whether the frontmatter value v equals want or, if v is
+// a list, contains it. want is converted to the type of v as coerce_frontmatter
+// converts values, so the string "123" matches the number 123 and "true" the
+// boolean true.
+func valueMatches(v, want any) bool {
+	switch v := v.(type) {
+	case nil:
+		return false
+	case []any:
+		return slices.ContainsFunc(v, func(e any) bool { return valueMatches(e, want) })
+	case string:
+		w, ok := coerceString(want)
+		return ok && v == w
+	case bool:
+		w, ok := coerceBoolean(want)
+		return ok && v == w
+	case int, int64, uint64, float64:
+		n, _ := frontmatterNumber(v)
+		w, ok := frontmatterNumber(want)
+		return ok && n == w
+	case time.Time:
+		w, ok := frontmatterTime(want)
+		return ok && v.Equal(w)
+	}
+	return fmt.Sprint(v) == fmt.Sprint(want)
+}
+
+// stringList returns v as a list of strings when it is a string or a list,
+// skipping list elements that are not strings.
+func stringList(v any) []string {
+	switch v := v.(type) {
+	case string:
+		return []string{v}
+	case []any:
+		list := make([]string, 0, len(v))
+		for _, e := range v {
+			if s, ok := e.(string); ok {
+				list = append(list, s)
+			}
+		}
+		return list
+	}
+	return nil
+}
+
+// WithSavedFilters defines named filters that clients can run with the
+// saved_filter tool.
+func WithSavedFilters(filters map[string]Filter) ServerOption {
+	return func(s *Server) {
+		if s.savedFilters == nil {
+			s.savedFilters = map[string]Filter{}
+		}
+		maps.Copy(s.savedFilters, filters)
+	}
+}
+
+// validateSavedFilters reports an error for the first malformed saved filter.
+func (s *Server) validateSavedFilters() error {
+	for _, name := range slices.Sorted(maps.Keys(s.savedFilters)) {
+		if err := s.savedFilters[name].validate(); err != nil {
+			return fmt.Errorf("saved filter %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+func (s *Server) savedFilterTool() mcp.Tool[*savedFilterRequest, *savedFilterResponse] {
+	return mcp.NewToolFunc(
+		fmt.Sprintf("saved_filter_%s", s.name),
+		fmt.Sprintf("List markdown files managed by %s that match a filter saved on the server", s.name),
+		jsonschema.Object{
+			Properties: map[string]jsonschema.Schema{
+				"filter": jsonschema.String{
+					Description: "The name of the saved filter",
+				},
+			},
+			Required: []string{"filter"},
+		},
+		s.savedFilter,
+	)
+}
+
+type savedFilterRequest struct {
+	Filter string `json:"filter" jsonschema:"required"`
+}
+
+type savedFilterResponse struct {
+	Files []markdownFileInfo `json:"files"`
+}
+
+func (s *Server) savedFilter(ctx context.Context, request *savedFilterRequest) (*savedFilterResponse, error) {
+	filter, ok := s.savedFilters[request.Filter]
+	if !ok {
+		return nil, fmt.Errorf("unknown saved filter: %s", request.Filter)
+	}
+	files := []markdownFileInfo{}
+	for f := range s.markdownFiles(ctx) {
+		if s.filterMatches(filter, f) {
+			files = append(files, f)
+		}
+	}
+	return &savedFilterResponse{Files: files}, nil
+}
@@ -0,0 +1,90 @@
+package mcpmds
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"testing/fstest"
+	"time"
+)
+
+func Test_server_savedFilter(t *testing.T) {
+	testFS := fstest.MapFS{
+		"guides/setup.md":    {Data: []byte("---\ntags: [go, setup]\nstatus: published\n---\n")},
+		"guides/advanced.md": {Data: []byte("---\ntags: [rust]\nstatus: published\n---\n")},
+		"guides/draft.md":    {Data: []byte("---\ntags: go\nstatus: draft\n---\n")},
+		"notes/go.md":        {Data: []byte("---\ntags: [go]\n---\n")},
+	}
+
+	s := &Server{fs: testFS}
+	WithSavedFilters(map[string]Filter{
+		"go-guides": {Globs: []string{"guides/*.md"}, Tags: []string{"go"}},
+		"published": {Frontmatter: map[string]any{"status": "published"}},
+		"any-go":    {Tags: []string{"go"}},
+	})(s)
+
+	tests := []struct {
+		name    string
+		filter  string
+		want    []string
+		wantErr bool
+	}{
+		{name: "Glob and tag", filter: "go-guides", want: []string{"guides/draft.md", "guides/setup.md"}},
+		{name: "Frontmatter predicate", filter: "published", want: []string{"guides/advanced.md", "guides/setup.md"}},
+		{name: "Tag only", filter: "any-go", want: []string{"guides/draft.md", "guides/setup.md", "notes/go.md"}},
+		{name: "Unknown filter", filter: "missing", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := s.savedFilter(context.Background(), &savedFilterRequest{Filter: tt.filter})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("savedFilter() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			var paths []string
+			for _, f := range got.Files {
+				paths = append(paths, f.Path)
+			}
+			if !slices.Equal(paths, tt.want) {
+				t.Errorf("savedFilter() = %v, want %v", paths, tt.want)
+			}
+		})
+	}
+}
+
+func Test_server_validateSavedFilters(t *testing.T) {
+	s := &Server{fs: fstest.MapFS{}}
+	WithSavedFilters(map[string]Filter{"bad": {Globs: []string{"["}}})(s)
+	if err := s.validateSavedFilters(); err == nil {
+		t.Error("validateSavedFilters() succeeded, want error for malformed glob")
+	}
+}
+
+func Test_valueMatches(t *testing.T) {
+	date := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		v, want any
+		match   bool
+	}{
+		{v: "published", want: "published", match: true},
+		{v: "5", want: 5, match: true},
+		{v: 5, want: "5", match: true},
+		{v: 5, want: 5.0, match: true},
+		{v: 1.5, want: "1.50", match: true},
+		{v: 5, want: "five", match: false},
+		{v: true, want: "true", match: true},
+		{v: false, want: true, match: false},
+		{v: date, want: "2024-01-02", match: true},
+		{v: date, want: "2024-01-03", match: false},
+		{v: []any{"go", 7}, want: "7", match: true},
+		{v: nil, want: "", match: false},
+	}
+	for _, tt := range tests {
+		if got := valueMatches(tt.v, tt.want); got != tt.match {
+			t.Errorf("valueMatches(%#v, %#v) = %v, want %v", tt.v, tt.want, got, tt.match)
+		}
+	}
+}
@@ -0,0 +1,114 @@
+package mcpmds
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+
+	"github.com/Warashi/go-modelcontextprotocol/jsonschema"
+	"github.com/Warashi/go-modelcontextprotocol/mcp"
+)
+
+func (s *Server) folderListingTool() mcp.Tool[*folderListingRequest, *folderListingResponse] {
+	return mcp.NewToolFunc(
+		fmt.Sprintf("folder_listing_%s", s.name),
+		fmt.Sprintf("List the markdown files directly inside a directory managed by %s, without descending into subdirectories", s.name),
+		jsonschema.Object{
+			Properties: map[string]jsonschema.Schema{
+				"path": jsonschema.String{
+					Description: "The path to the directory; defaults to the root",
+				},
+			},
+		},
+		s.folderListing,
+	)
+}
+
+type folderListingRequest struct {
+	Path string `json:"path"`
+}
+
+type folderListingResponse struct {
+	// Path is the listed directory.
+	Path string `json:"path"`
+	// Files are the markdown files directly inside the directory.
+	Files []markdownFileInfo `json:"files"`
+	// Directories are the names of the subdirectories of the directory that
+	// hold served files.
+	Directories []string `json:"directories"`
+}
+
+func (s *Server) folderListing(ctx context.Context, request *folderListingRequest) (*folderListingResponse, error) {
+	dir, err := s.requestPath(request.Path)
+	if err != nil {
+		return nil, err
+	}
+	if dir == "" {
+		dir = "."
+	}
+	entries, err := fs.ReadDir(s.fs, dir)
+	if err != nil {
+		return nil, err
+	}
+	resp := &folderListingResponse{Path: dir, Files: []markdownFileInfo{}, Directories: []string{}}
+	wikiLinks := s.newWikiLinkResolver()
+	ignore := s.newGitignore()
+	for _, d := range entries {
+		p := path.Join(dir, d.Name())
+		served, err := s.walkEntry(ignore, p, d)
+		if errors.Is(err, fs.SkipDir) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if d.IsDir() {
+			served, err = s.hasServedFiles(ignore, p)
+			if err != nil {
+				return nil, err
+			}
+			if served {
+				resp.Directories = append(resp.Directories, d.Name())
+			}
+			continue
+		}
+		if !served {
+			continue
+		}
+		info, err := s.readCachedMarkdownInfo(ctx, p, d)
+		if !s.strictFrontmatter && isFrontmatterParseError(err) {
+			info, err = s.brokenMarkdownInfo(p, d, err)
+		}
+		if errors.Is(err, fs.ErrNotExist) || errors.Is(err, errRetriesExhausted) || errors.Is(err, errFileTooLarge) {
+			continue
+		}
+		if err == nil {
+			err = wikiLinks.resolve(info.Wikilinks)
+		}
+		if err != nil {
+			return nil, err
+		}
+		resp.Files = append(resp.Files, info)
+	}
+	return resp, nil
+}
+
+// hasServedFiles reports whether the directory dir holds a served markdown
+// file at any depth, matching .gitignore files with ignore.
+func (s *Server) hasServedFiles(ignore *gitignore, dir string) (bool, error) {
+	found := false
+	err := fs.WalkDir(s.fs, dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		served, err := s.walkEntry(ignore, p, d)
+		if served {
+			found = true
+			return fs.SkipAll
+		}
+		return err
+	})
+	return found, err
+}
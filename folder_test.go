@@ -0,0 +1,132 @@
+package mcpmds
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"slices"
+	"testing"
+	"testing/fstest"
+)
+
+func Test_server_folderListing(t *testing.T) {
+	testFS := fstest.MapFS{
+		"root.md":                 {Data: []byte("root")},
+		"docs/a.md":               {Data: []byte("---\ntitle: A\n---\na")},
+		"docs/b.md":               {Data: []byte("b")},
+		"docs/notes.txt":          {Data: []byte("not markdown")},
+		"docs/guides/nested.md":   {Data: []byte("nested")},
+		"docs/guides/deep/x.md":   {Data: []byte("deep")},
+		"docs/reference/index.md": {Data: []byte("reference")},
+		"docs/assets/logo.png":    {Data: []byte("not markdown")},
+	}
+
+	s := &Server{fs: testFS}
+
+	tests := []struct {
+		name      string
+		path      string
+		wantFiles []string
+		wantDirs  []string
+		wantErr   error
+	}{
+		{
+			name:      "Direct children only",
+			path:      "docs",
+			wantFiles: []string{"docs/a.md", "docs/b.md"},
+			wantDirs:  []string{"guides", "reference"},
+		},
+		{
+			name:      "Root by default",
+			path:      "",
+			wantFiles: []string{"root.md"},
+			wantDirs:  []string{"docs"},
+		},
+		{
+			name:      "Folder without direct markdown files",
+			path:      "docs/guides/deep",
+			wantFiles: []string{"docs/guides/deep/x.md"},
+			wantDirs:  []string{},
+		},
+		{
+			name:    "Non-existent folder",
+			path:    "missing",
+			wantErr: fs.ErrNotExist,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := s.folderListing(context.Background(), &folderListingRequest{Path: tt.path})
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("folderListing() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			var files []string
+			for _, f := range got.Files {
+				files = append(files, f.Path)
+			}
+			if !slices.Equal(files, tt.wantFiles) {
+				t.Errorf("folderListing() files = %v, want %v", files, tt.wantFiles)
+			}
+			if !slices.Equal(got.Directories, tt.wantDirs) {
+				t.Errorf("folderListing() directories = %v, want %v", got.Directories, tt.wantDirs)
+			}
+		})
+	}
+}
+
+func Test_server_folderListing_brokenFrontmatter(t *testing.T) {
+	testFS := fstest.MapFS{
+		"docs/good.md":   {Data: []byte("---\ntitle: Good\n---\nbody")},
+		"docs/broken.md": {Data: []byte("---\ntitle: [unclosed\n---\nbody")},
+	}
+
+	got, err := (&Server{fs: testFS}).folderListing(context.Background(), &folderListingRequest{Path: "docs"})
+	if err != nil {
+		t.Fatalf("folderListing() error = %v", err)
+	}
+	if len(got.Files) != 2 || got.Files[0].FrontmatterError == "" || got.Files[1].FrontmatterError != "" {
+		t.Errorf("folderListing() files = %+v, want the broken file listed with its frontmatter error", got.Files)
+	}
+
+	s := &Server{fs: testFS}
+	WithStrictFrontmatter(true)(s)
+	if _, err := s.folderListing(context.Background(), &folderListingRequest{Path: "docs"}); err == nil {
+		t.Error("folderListing() with strict frontmatter error = nil, want parse error")
+	}
+}
+
+func Test_server_folderListing_hiddenDirectories(t *testing.T) {
+	testFS := fstest.MapFS{
+		".gitignore":        {Data: []byte("build/\n")},
+		"docs/a.md":         {Data: []byte("a")},
+		"build/out.md":      {Data: []byte("generated")},
+		"private/secret.md": {Data: []byte("secret")},
+	}
+
+	s := newServer("test", "test", testFS, WithGitignore(true), WithExcludeGlobs("private/**"))
+	got, err := s.folderListing(context.Background(), &folderListingRequest{})
+	if err != nil {
+		t.Fatalf("folderListing() error = %v", err)
+	}
+	if want := []string{"docs"}; !slices.Equal(got.Directories, want) {
+		t.Errorf("folderListing() directories = %v, want %v", got.Directories, want)
+	}
+}
+
+func Test_server_folderListing_cache(t *testing.T) {
+	testFS := fstest.MapFS{"a.md": {Data: []byte("---\ntitle: A\n---\na")}}
+	s := newServer("test", "test", testFS, WithCache(true))
+	if _, err := s.folderListing(context.Background(), &folderListingRequest{}); err != nil {
+		t.Fatalf("folderListing() error = %v", err)
+	}
+	if _, ok := s.cachedFiles["a.md"]; !ok {
+		t.Error("folderListing() did not cache a.md")
+	}
+}
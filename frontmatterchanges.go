@@ -0,0 +1,141 @@
+package mcpmds
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"time"
+
+	"github.com/Warashi/go-modelcontextprotocol/jsonschema"
+	"github.com/Warashi/go-modelcontextprotocol/mcp"
+)
+
+// maxFrontmatterChanges is the number of frontmatter changes retained in
+// watch mode; older changes are discarded.
+const maxFrontmatterChanges = 1000
+
+// frontmatterChange is a change to the frontmatter of a markdown file
+// observed in watch mode.
+type frontmatterChange struct {
+	// Path is the relative path to the markdown file.
+	Path string `json:"path"`
+	// Time is the modification time of the changed file, or when its removal
+	// was observed.
+	Time time.Time `json:"time"`
+	// Fields are the changed frontmatter keys in sorted order.
+	Fields []frontmatterFieldChange `json:"fields"`
+}
+
+// frontmatterFieldChange is the change of a single frontmatter key. Before is
+// null for an added key and After is null for a removed one.
+type frontmatterFieldChange struct {
+	Key    string `json:"key"`
+	Before any    `json:"before"`
+	After  any    `json:"after"`
+}
+
+// frontmatterSnapshot is the frontmatter of a served file when last indexed.
+type frontmatterSnapshot struct {
+	modTime     time.Time
+	frontmatter map[string]any
+}
+
+// snapshotFrontmatter records the frontmatter of the served files and, once
+// a previous snapshot exists, the changes since it. Files that appear or
+// disappear count as changes to all of their keys.
+func (s *Server) snapshotFrontmatter() {
+	files, err := s.collectMarkdownFiles(context.Background())
+	if err != nil {
+		return
+	}
+	current := map[string]frontmatterSnapshot{}
+	for _, f := range files {
+		current[f.Path] = frontmatterSnapshot{modTime: f.ModTime, frontmatter: f.Frontmatter}
+	}
+
+	s.frontmatterMu.Lock()
+	defer s.frontmatterMu.Unlock()
+	previous := s.frontmatterSnapshots
+	s.frontmatterSnapshots = current
+	if previous == nil {
+		return
+	}
+	now := time.Now()
+	for _, path := range slices.Sorted(maps.Keys(current)) {
+		if fields := diffFrontmatter(previous[path].frontmatter, current[path].frontmatter); len(fields) > 0 {
+			s.frontmatterChanges = append(s.frontmatterChanges, frontmatterChange{Path: path, Time: current[path].modTime, Fields: fields})
+		}
+	}
+	for _, path := range slices.Sorted(maps.Keys(previous)) {
+		if _, ok := current[path]; ok {
+			continue
+		}
+		if fields := diffFrontmatter(previous[path].frontmatter, nil); len(fields) > 0 {
+			s.frontmatterChanges = append(s.frontmatterChanges, frontmatterChange{Path: path, Time: now, Fields: fields})
+		}
+	}
+	if n := len(s.frontmatterChanges) - maxFrontmatterChanges; n > 0 {
+		s.frontmatterChanges = slices.Delete(s.frontmatterChanges, 0, n)
+	}
+}
+
+// diffFrontmatter returns the keys whose values differ between before and
+// after, in sorted order.
+func diffFrontmatter(before, after map[string]any) []frontmatterFieldChange {
+	keys := slices.Sorted(maps.Keys(before))
+	for key := range after {
+		if _, ok := before[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	slices.Sort(keys)
+	var fields []frontmatterFieldChange
+	for _, key := range keys {
+		if !reflect.DeepEqual(before[key], after[key]) {
+			fields = append(fields, frontmatterFieldChange{Key: key, Before: before[key], After: after[key]})
+		}
+	}
+	return fields
+}
+
+func (s *Server) frontmatterChangesTool() mcp.Tool[*frontmatterChangesRequest, *frontmatterChangesResponse] {
+	return mcp.NewToolFunc(
+		fmt.Sprintf("frontmatter_changes_%s", s.name),
+		fmt.Sprintf("List frontmatter field changes in markdown files managed by %s since a given time, with before and after values; requires watch mode", s.name),
+		jsonschema.Object{
+			Properties: map[string]jsonschema.Schema{
+				"since": jsonschema.String{
+					Description: "Report changes after this time, in RFC 3339 format",
+				},
+			},
+			Required: []string{"since"},
+		},
+		s.frontmatterChangesSince,
+	)
+}
+
+type frontmatterChangesRequest struct {
+	Since time.Time `json:"since" jsonschema:"required"`
+}
+
+type frontmatterChangesResponse struct {
+	Changes []frontmatterChange `json:"changes"`
+}
+
+func (s *Server) frontmatterChangesSince(ctx context.Context, request *frontmatterChangesRequest) (*frontmatterChangesResponse, error) {
+	if !s.watch {
+		return nil, errors.New("frontmatter changes are tracked only in watch mode")
+	}
+	s.frontmatterMu.Lock()
+	defer s.frontmatterMu.Unlock()
+	changes := []frontmatterChange{}
+	for _, c := range s.frontmatterChanges {
+		if c.Time.After(request.Since) {
+			changes = append(changes, c)
+		}
+	}
+	return &frontmatterChangesResponse{Changes: changes}, nil
+}
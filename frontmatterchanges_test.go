@@ -0,0 +1,65 @@
+package mcpmds
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"testing/fstest"
+	"time"
+)
+
+func Test_server_frontmatterChanges(t *testing.T) {
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	testFS := fstest.MapFS{
+		"post.md":  {Data: []byte("---\ntitle: Post\ndraft: true\n---\nbody"), ModTime: base},
+		"same.md":  {Data: []byte("---\ntitle: Same\n---\nbody"), ModTime: base},
+		"gone.md":  {Data: []byte("---\ntitle: Gone\n---\nbody"), ModTime: base},
+		"plain.md": {Data: []byte("no frontmatter"), ModTime: base},
+	}
+	s := newServer("test", "test", testFS, WithWatch(true))
+	s.snapshotFrontmatter()
+
+	edited := base.Add(time.Hour)
+	testFS["post.md"] = &fstest.MapFile{Data: []byte("---\ntitle: Post\ndraft: false\npublished: 2024-01-01\n---\nbody"), ModTime: edited}
+	testFS["same.md"].ModTime = edited
+	delete(testFS, "gone.md")
+	s.snapshotFrontmatter()
+
+	resp, err := s.frontmatterChangesSince(context.Background(), &frontmatterChangesRequest{Since: base})
+	if err != nil {
+		t.Fatalf("frontmatterChangesSince() error = %v", err)
+	}
+	if len(resp.Changes) != 2 {
+		t.Fatalf("changes = %+v, want post.md and gone.md", resp.Changes)
+	}
+	post, gone := resp.Changes[0], resp.Changes[1]
+	if post.Path != "post.md" || !post.Time.Equal(edited) {
+		t.Errorf("first change = %s at %v, want post.md at %v", post.Path, post.Time, edited)
+	}
+	wantPost := []frontmatterFieldChange{
+		{Key: "draft", Before: true, After: false},
+		{Key: "published", Before: nil, After: post.Fields[1].After},
+	}
+	if !reflect.DeepEqual(post.Fields, wantPost) || post.Fields[1].After == nil {
+		t.Errorf("post.md fields = %+v, want %+v", post.Fields, wantPost)
+	}
+	wantGone := []frontmatterFieldChange{{Key: "title", Before: "Gone", After: nil}}
+	if gone.Path != "gone.md" || !reflect.DeepEqual(gone.Fields, wantGone) {
+		t.Errorf("second change = %+v, want gone.md with %+v", gone, wantGone)
+	}
+
+	resp, err = s.frontmatterChangesSince(context.Background(), &frontmatterChangesRequest{Since: time.Now().Add(time.Hour)})
+	if err != nil {
+		t.Fatalf("frontmatterChangesSince() error = %v", err)
+	}
+	if len(resp.Changes) != 0 {
+		t.Errorf("changes after a later cutoff = %+v, want none", resp.Changes)
+	}
+
+	t.Run("NotWatching", func(t *testing.T) {
+		s := newServer("test", "test", testFS)
+		if _, err := s.frontmatterChangesSince(context.Background(), &frontmatterChangesRequest{Since: base}); err == nil {
+			t.Error("frontmatterChangesSince() succeeded, want error")
+		}
+	})
+}
@@ -0,0 +1,146 @@
+package mcpmds
+
+import (
+	"bufio"
+	"bytes"
+	"io/fs"
+	"path"
+	"strings"
+)
+
+// gitignoreFile is the name of the files holding ignore patterns.
+const gitignoreFile = ".gitignore"
+
+// WithGitignore skips the files and directories matched by .gitignore files
+// in the served tree, as git does: patterns in a .gitignore apply beneath its
+// directory, deeper files take precedence, and "!" patterns re-include paths.
+// Without any .gitignore, all files are served as usual.
+func WithGitignore(enabled bool) ServerOption {
+	return func(s *Server) {
+		s.gitignore = enabled
+	}
+}
+
+// gitignoreRule is a pattern read from a .gitignore file.
+type gitignoreRule struct {
+	// pattern is matched against paths relative to the .gitignore directory
+	// with matchGlob.
+	pattern string
+	negate  bool
+	dirOnly bool
+}
+
+// parseGitignore returns the rules in the content of a .gitignore file.
+func parseGitignore(content []byte) []gitignoreRule {
+	var rules []gitignoreRule
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), " \t\r")
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		var rule gitignoreRule
+		if rest, ok := strings.CutPrefix(line, "!"); ok {
+			rule.negate = true
+			line = rest
+		}
+		line = strings.TrimPrefix(line, `\`)
+		if rest, ok := strings.CutSuffix(line, "/"); ok {
+			rule.dirOnly = true
+			line = rest
+		}
+		if strings.Contains(line, "/") {
+			// A pattern with a slash is relative to the .gitignore directory.
+			line = strings.TrimPrefix(line, "/")
+		} else {
+			line = "**/" + line
+		}
+		if line == "" {
+			continue
+		}
+		rule.pattern = line
+		rules = append(rules, rule)
+	}
+	return rules
+}
+
+// gitignore decides which paths are ignored, reading the .gitignore files of
+// each directory once. It is not safe for concurrent use; a nil *gitignore
+// ignores nothing.
+type gitignore struct {
+	fsys  fs.FS
+	rules map[string][]gitignoreRule
+	dirs  map[string]bool
+}
+
+// newGitignore returns a matcher for the served tree, or nil if
+// WithGitignore is not set.
+func (s *Server) newGitignore() *gitignore {
+	if !s.gitignore {
+		return nil
+	}
+	return &gitignore{fsys: s.fs, rules: map[string][]gitignoreRule{}, dirs: map[string]bool{}}
+}
+
+// rulesIn returns the rules of the .gitignore file in dir. A missing or
+// unreadable file has no rules.
+func (g *gitignore) rulesIn(dir string) []gitignoreRule {
+	rules, ok := g.rules[dir]
+	if !ok {
+		if content, err := fs.ReadFile(g.fsys, path.Join(dir, gitignoreFile)); err == nil {
+			rules = parseGitignore(content)
+		}
+		g.rules[dir] = rules
+	}
+	return rules
+}
+
+// ignored reports whether the path p, a directory if isDir, is ignored
+// itself or lies in an ignored directory.
+func (g *gitignore) ignored(p string, isDir bool) bool {
+	if g == nil || p == "." {
+		return false
+	}
+	if isDir {
+		if ignored, ok := g.dirs[p]; ok {
+			return ignored
+		}
+	}
+	dir := path.Dir(p)
+	ignored := g.ignored(dir, true)
+	if !ignored {
+		ignored = g.matches(p, isDir)
+	}
+	if isDir {
+		g.dirs[p] = ignored
+	}
+	return ignored
+}
+
+// matches applies the rules of the .gitignore files from the root down to
+// the directory of p; the last matching rule decides.
+func (g *gitignore) matches(p string, isDir bool) bool {
+	ignored := false
+	dirs := []string{"."}
+	if dir := path.Dir(p); dir != "." {
+		parts := strings.Split(dir, "/")
+		for i := range parts {
+			dirs = append(dirs, strings.Join(parts[:i+1], "/"))
+		}
+	}
+	for _, dir := range dirs {
+		rel := p
+		if dir != "." {
+			rel = strings.TrimPrefix(p, dir+"/")
+		}
+		for _, rule := range g.rulesIn(dir) {
+			if rule.dirOnly && !isDir {
+				continue
+			}
+			if matchGlob(rule.pattern, rel) {
+				ignored = !rule.negate
+			}
+		}
+	}
+	return ignored
+}
@@ -0,0 +1,116 @@
+package mcpmds
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"path"
+	"slices"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func TestWithGitignore(t *testing.T) {
+	testFS := fstest.MapFS{
+		".gitignore":               {Data: []byte("# drafts are private\ndrafts/\n*.gen.md\n")},
+		"index.md":                 {Data: []byte("index")},
+		"api.gen.md":               {Data: []byte("generated")},
+		"drafts/idea.md":           {Data: []byte("idea")},
+		"docs/drafts/old.md":       {Data: []byte("old")},
+		"docs/guide.md":            {Data: []byte("guide")},
+		"docs/.gitignore":          {Data: []byte("/scratch.md\ntmp/\n!keep.gen.md\n")},
+		"docs/scratch.md":          {Data: []byte("scratch")},
+		"docs/keep.gen.md":         {Data: []byte("kept")},
+		"docs/tmp/notes.md":        {Data: []byte("notes")},
+		"docs/sub/scratch.md":      {Data: []byte("not anchored here")},
+		"other/scratch.md":         {Data: []byte("other scratch")},
+		"other/nested/deep.gen.md": {Data: []byte("deep generated")},
+	}
+
+	list := func(t *testing.T, fsys fs.FS, opts ...ServerOption) []string {
+		t.Helper()
+		s := newServer("test", "test", fsys, opts...)
+		resp, err := s.listMarkdownFiles(context.Background(), nil)
+		if err != nil {
+			t.Fatalf("listMarkdownFiles() error = %v", err)
+		}
+		got := []string{}
+		for _, f := range resp.Files {
+			got = append(got, f.Path)
+		}
+		return got
+	}
+
+	want := []string{"docs/guide.md", "docs/keep.gen.md", "docs/sub/scratch.md", "index.md", "other/scratch.md"}
+	if got := list(t, testFS, WithGitignore(true)); !slices.Equal(got, want) {
+		t.Errorf("files = %v, want %v", got, want)
+	}
+	if got := list(t, testFS); len(got) != 11 {
+		t.Errorf("files without WithGitignore = %v, want all 11", got)
+	}
+	resources, err := newServer("test", "test", testFS, WithGitignore(true), WithLazyResources(true)).resourceList()
+	if err != nil {
+		t.Fatalf("resourceList() error = %v", err)
+	}
+	uris := []string{}
+	for _, r := range resources {
+		uris = append(uris, strings.TrimPrefix(r.URI, "file://"))
+	}
+	if !slices.Equal(uris, want) {
+		t.Errorf("lazy resources = %v, want %v", uris, want)
+	}
+
+	t.Run("Ignored file is not readable", func(t *testing.T) {
+		s := newServer("test", "test", testFS, WithGitignore(true))
+		for _, p := range []string{"drafts/idea.md", "docs/tmp/notes.md", "api.gen.md"} {
+			_, err := s.readMarkdownFile(context.Background(), &readMarkdownFileRequest{Path: p})
+			if !errors.Is(err, fs.ErrNotExist) {
+				t.Errorf("readMarkdownFile(%s) error = %v, want fs.ErrNotExist", p, err)
+			}
+		}
+	})
+
+	t.Run("No gitignore", func(t *testing.T) {
+		plain := fstest.MapFS{"a.md": {Data: []byte("a")}, "drafts/b.md": {Data: []byte("b")}}
+		if got := list(t, plain, WithGitignore(true)); !slices.Equal(got, []string{"a.md", "drafts/b.md"}) {
+			t.Errorf("files = %v, want all files", got)
+		}
+	})
+}
+
+// gitignoreCountingFS counts the .gitignore files opened.
+type gitignoreCountingFS struct {
+	fs.FS
+	opened int
+}
+
+func (c *gitignoreCountingFS) Open(name string) (fs.File, error) {
+	if path.Base(name) == gitignoreFile {
+		c.opened++
+	}
+	return c.FS.Open(name)
+}
+
+func TestWithGitignore_readsRulesOncePerWalk(t *testing.T) {
+	testFS := &gitignoreCountingFS{FS: fstest.MapFS{
+		".gitignore":      {Data: []byte("*.gen.md\n")},
+		"docs/.gitignore": {Data: []byte("private.md\n")},
+		"docs/a.md":       {Data: []byte("# A\n")},
+		"docs/b.md":       {Data: []byte("# B\n")},
+		"docs/c.md":       {Data: []byte("# C\n")},
+		"docs/private.md": {Data: []byte("# Private\n")},
+	}}
+	s := newServer("test", "test", testFS, WithGitignore(true))
+
+	files, err := s.collectMarkdownFiles(context.Background())
+	if err != nil {
+		t.Fatalf("collectMarkdownFiles() error = %v", err)
+	}
+	if len(files) != 3 {
+		t.Errorf("collectMarkdownFiles() returned %d files, want 3", len(files))
+	}
+	if testFS.opened != 2 {
+		t.Errorf("walk opened .gitignore files %d times, want 2", testFS.opened)
+	}
+}
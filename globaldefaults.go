@@ -0,0 +1,83 @@
+package mcpmds
+
+import (
+	"errors"
+	"io/fs"
+	"maps"
+	"path"
+	"time"
+)
+
+// WithGlobalFrontmatterDefaults merges the frontmatter of the file at the given
+// path of the filesystem under the frontmatter of every served file. Keys set
+// by a file take precedence over the defaults. The defaults file itself is not
+// served. If the file does not exist, no defaults are applied.
+// The file is re-read whenever its modification time or size changes.
+func WithGlobalFrontmatterDefaults(path string) ServerOption {
+	return func(s *Server) {
+		s.globalDefaultsFile = path
+	}
+}
+
+// globalDefaults is the parsed frontmatter of the global defaults file.
+type globalDefaults struct {
+	modTime time.Time
+	size    int64
+	content []byte
+}
+
+// isGlobalDefaultsFile reports whether p is the global defaults file.
+func (s *Server) isGlobalDefaultsFile(p string) bool {
+	return s.globalDefaultsFile != "" && path.Clean(p) == path.Clean(s.globalDefaultsFile)
+}
+
+// mergeGlobalDefaults returns frontmatter with the global defaults merged under it.
+func (s *Server) mergeGlobalDefaults(frontmatter map[string]any) (map[string]any, error) {
+	if s.globalDefaultsFile == "" {
+		return frontmatter, nil
+	}
+	content, err := s.loadGlobalDefaults()
+	if err != nil {
+		return nil, err
+	}
+	// Unmarshal on every call so that callers may modify the returned map freely.
+	defaults, _, err := s.unmarshalFrontmatter(content)
+	if err != nil {
+		return nil, err
+	}
+	if len(defaults) == 0 {
+		return frontmatter, nil
+	}
+	maps.Copy(defaults, frontmatter)
+	return defaults, nil
+}
+
+// loadGlobalDefaults returns the content of the global defaults file,
+// re-reading the file if it changed.
+func (s *Server) loadGlobalDefaults() ([]byte, error) {
+	s.globalDefaultsMu.Lock()
+	defer s.globalDefaultsMu.Unlock()
+
+	info, err := fs.Stat(s.fs, s.globalDefaultsFile)
+	if errors.Is(err, fs.ErrNotExist) {
+		s.globalDefaults = nil
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if s.globalDefaults != nil && s.globalDefaults.modTime.Equal(info.ModTime()) && s.globalDefaults.size == info.Size() {
+		return s.globalDefaults.content, nil
+	}
+
+	content, err := fs.ReadFile(s.fs, s.globalDefaultsFile)
+	if err != nil {
+		return nil, err
+	}
+	content, err = s.decodeSource(s.globalDefaultsFile, content)
+	if err != nil {
+		return nil, err
+	}
+	s.globalDefaults = &globalDefaults{modTime: info.ModTime(), size: info.Size(), content: content}
+	return content, nil
+}
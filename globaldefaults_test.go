@@ -0,0 +1,54 @@
+package mcpmds
+
+import (
+	"context"
+	"reflect"
+	"slices"
+	"testing"
+	"testing/fstest"
+)
+
+func Test_server_globalFrontmatterDefaults(t *testing.T) {
+	testFS := fstest.MapFS{
+		"_defaults.md": {Data: []byte("---\nlicense: MIT\norg: example\n---\n")},
+		"plain.md":     {Data: []byte("# Plain\n")},
+		"own.md":       {Data: []byte("---\ntitle: Own\nlicense: Apache-2.0\n---\n")},
+		"toml.md":      {Data: []byte("+++\ntitle = \"TOML\"\n+++\n")},
+	}
+
+	s := &Server{fs: testFS}
+	WithGlobalFrontmatterDefaults("_defaults.md")(s)
+
+	want := map[string]map[string]any{
+		"plain.md": {"license": "MIT", "org": "example"},
+		"own.md":   {"title": "Own", "license": "Apache-2.0", "org": "example"},
+		"toml.md":  {"title": "TOML", "license": "MIT", "org": "example"},
+	}
+
+	var paths []string
+	for f := range s.markdownFiles(context.Background()) {
+		paths = append(paths, f.Path)
+		if !reflect.DeepEqual(f.Frontmatter, want[f.Path]) {
+			t.Errorf("frontmatter of %s = %#v, want %#v", f.Path, f.Frontmatter, want[f.Path])
+		}
+	}
+	if !slices.Equal(paths, []string{"own.md", "plain.md", "toml.md"}) {
+		t.Errorf("paths = %v, want the defaults file to be hidden", paths)
+	}
+
+	if _, err := s.readMarkdownFile(context.Background(), &readMarkdownFileRequest{Path: "_defaults.md"}); err == nil {
+		t.Errorf("reading the defaults file succeeded, want error")
+	}
+
+	t.Run("Missing defaults file", func(t *testing.T) {
+		s := &Server{fs: testFS}
+		WithGlobalFrontmatterDefaults("missing.md")(s)
+		got, err := s.readMarkdownFile(context.Background(), &readMarkdownFileRequest{Path: "plain.md"})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got.Frontmatter != nil {
+			t.Errorf("frontmatter = %#v, want nil", got.Frontmatter)
+		}
+	})
+}
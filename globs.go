@@ -0,0 +1,102 @@
+package mcpmds
+
+import (
+	"fmt"
+	"io/fs"
+	"path"
+	"slices"
+	"strings"
+)
+
+// WithIncludeGlobs restricts the served files to those whose path matches at
+// least one of the patterns. Patterns use path.Match syntax against the path
+// relative to the served directory, and a "**" segment matches any number of
+// directories, as in "docs/**". Directories that cannot hold matching files
+// are not walked.
+func WithIncludeGlobs(patterns ...string) ServerOption {
+	return func(s *Server) {
+		s.includeGlobs = append(s.includeGlobs, patterns...)
+	}
+}
+
+// WithExcludeGlobs hides the files whose path matches any of the patterns,
+// with the same syntax as WithIncludeGlobs. Exclusion takes precedence over
+// inclusion, and excluded directories, such as "vendor/**", are not walked.
+func WithExcludeGlobs(patterns ...string) ServerOption {
+	return func(s *Server) {
+		s.excludeGlobs = append(s.excludeGlobs, patterns...)
+	}
+}
+
+// validateGlobs reports whether the include and exclude patterns are well-formed.
+func (s *Server) validateGlobs() error {
+	for _, pattern := range slices.Concat(s.includeGlobs, s.excludeGlobs) {
+		for segment := range strings.SplitSeq(pattern, "/") {
+			if _, err := path.Match(segment, ""); err != nil {
+				return fmt.Errorf("invalid glob %q: %w", pattern, err)
+			}
+		}
+	}
+	return nil
+}
+
+// globIncluded reports whether the file at p is served according to the
+// include and exclude patterns.
+func (s *Server) globIncluded(p string) bool {
+	if slices.ContainsFunc(s.excludeGlobs, func(pattern string) bool { return matchGlob(pattern, p) }) {
+		return false
+	}
+	return len(s.includeGlobs) == 0 || slices.ContainsFunc(s.includeGlobs, func(pattern string) bool { return matchGlob(pattern, p) })
+}
+
+// skipGlobDir reports whether the walk can skip the directory at p because
+// it is excluded or no include pattern can match a file beneath it.
+func (s *Server) skipGlobDir(p string) bool {
+	if p == "." {
+		return false
+	}
+	if slices.ContainsFunc(s.excludeGlobs, func(pattern string) bool { return matchGlob(pattern, p) }) {
+		return true
+	}
+	return len(s.includeGlobs) > 0 && !slices.ContainsFunc(s.includeGlobs, func(pattern string) bool {
+		return matchGlobSegments(strings.Split(pattern, "/"), strings.Split(p, "/"), true)
+	})
+}
+
+// walkGlobDir returns fs.SkipDir for the directory at p if it is skipped by
+// skipGlobDir.
+func (s *Server) walkGlobDir(p string) error {
+	if s.skipGlobDir(p) {
+		return fs.SkipDir
+	}
+	return nil
+}
+
+// matchGlob reports whether name matches pattern, a path.Match pattern in
+// which a "**" segment matches zero or more path segments.
+func matchGlob(pattern, name string) bool {
+	return matchGlobSegments(strings.Split(pattern, "/"), strings.Split(name, "/"), false)
+}
+
+// matchGlobSegments matches name against pattern segment by segment. With
+// prefix, it instead reports whether a path beneath name could match.
+func matchGlobSegments(pattern, name []string, prefix bool) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			for i := range len(name) + 1 {
+				if matchGlobSegments(pattern[1:], name[i:], prefix) {
+					return true
+				}
+			}
+			return false
+		}
+		if len(name) == 0 {
+			return prefix
+		}
+		if ok, _ := path.Match(pattern[0], name[0]); !ok {
+			return false
+		}
+		pattern, name = pattern[1:], name[1:]
+	}
+	return len(name) == 0
+}
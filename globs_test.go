@@ -0,0 +1,121 @@
+package mcpmds
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"slices"
+	"testing"
+	"testing/fstest"
+)
+
+// readDirRecorder records the directories read.
+type readDirRecorder struct {
+	fs.FS
+	dirs []string
+}
+
+func (r *readDirRecorder) ReadDir(name string) ([]fs.DirEntry, error) {
+	r.dirs = append(r.dirs, name)
+	return fs.ReadDir(r.FS, name)
+}
+
+func TestWithIncludeExcludeGlobs(t *testing.T) {
+	testFS := fstest.MapFS{
+		"README.md":                  {Data: []byte("readme")},
+		"docs/index.md":              {Data: []byte("index")},
+		"docs/guide/install.md":      {Data: []byte("install")},
+		"docs/vendor/notes.md":       {Data: []byte("notes")},
+		"vendor/lib/README.md":       {Data: []byte("vendored")},
+		"node_modules/pkg/README.md": {Data: []byte("module")},
+		"src/main.md":                {Data: []byte("main")},
+	}
+
+	tests := []struct {
+		name     string
+		opts     []ServerOption
+		want     []string
+		wantDirs []string
+	}{
+		{
+			name:     "Exclude vendor",
+			opts:     []ServerOption{WithExcludeGlobs("vendor/**", "**/node_modules/**")},
+			want:     []string{"README.md", "docs/guide/install.md", "docs/index.md", "docs/vendor/notes.md", "src/main.md"},
+			wantDirs: []string{".", "docs", "docs/guide", "docs/vendor", "src"},
+		},
+		{
+			name:     "Include docs",
+			opts:     []ServerOption{WithIncludeGlobs("docs/**")},
+			want:     []string{"docs/guide/install.md", "docs/index.md", "docs/vendor/notes.md"},
+			wantDirs: []string{".", "docs", "docs/guide", "docs/vendor"},
+		},
+		{
+			name:     "Exclude wins over include",
+			opts:     []ServerOption{WithIncludeGlobs("docs/**"), WithExcludeGlobs("**/vendor/**")},
+			want:     []string{"docs/guide/install.md", "docs/index.md"},
+			wantDirs: []string{".", "docs", "docs/guide"},
+		},
+		{
+			name:     "File patterns",
+			opts:     []ServerOption{WithIncludeGlobs("*.md", "docs/*/*.md")},
+			want:     []string{"README.md", "docs/guide/install.md", "docs/vendor/notes.md"},
+			wantDirs: []string{".", "docs", "docs/guide", "docs/vendor"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := &readDirRecorder{FS: testFS}
+			s := newServer("test", "test", rec, tt.opts...)
+			resp, err := s.listMarkdownFiles(context.Background(), nil)
+			if err != nil {
+				t.Fatalf("listMarkdownFiles() error = %v", err)
+			}
+			got := []string{}
+			for _, f := range resp.Files {
+				got = append(got, f.Path)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("files = %v, want %v", got, tt.want)
+			}
+			if !slices.Equal(rec.dirs, tt.wantDirs) {
+				t.Errorf("walked directories = %v, want %v", rec.dirs, tt.wantDirs)
+			}
+		})
+	}
+
+	t.Run("Excluded file is not readable", func(t *testing.T) {
+		s := newServer("test", "test", testFS, WithExcludeGlobs("vendor/**"))
+		_, err := s.readMarkdownFile(context.Background(), &readMarkdownFileRequest{Path: "vendor/lib/README.md"})
+		if !errors.Is(err, fs.ErrNotExist) {
+			t.Errorf("readMarkdownFile() error = %v, want fs.ErrNotExist", err)
+		}
+	})
+
+	t.Run("Invalid pattern", func(t *testing.T) {
+		if _, err := newServer("test", "test", testFS, WithIncludeGlobs("docs/[")).server(); err == nil {
+			t.Error("server() succeeded, want error")
+		}
+	})
+}
+
+func Test_matchGlob(t *testing.T) {
+	tests := []struct {
+		pattern string
+		name    string
+		want    bool
+	}{
+		{"vendor/**", "vendor", true},
+		{"vendor/**", "vendor/a/b.md", true},
+		{"vendor/**", "docs/vendor/b.md", false},
+		{"**/vendor/**", "docs/vendor/b.md", true},
+		{"**/*.md", "a.md", true},
+		{"**/*.md", "a/b/c.md", true},
+		{"docs/*.md", "docs/a/b.md", false},
+		{"docs/**/*.md", "docs/a.md", true},
+	}
+	for _, tt := range tests {
+		if got := matchGlob(tt.pattern, tt.name); got != tt.want {
+			t.Errorf("matchGlob(%q, %q) = %v, want %v", tt.pattern, tt.name, got, tt.want)
+		}
+	}
+}
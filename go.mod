@@ -5,5 +5,10 @@ go 1.24.1
 require (
 	github.com/BurntSushi/toml v1.5.0
 	github.com/Warashi/go-modelcontextprotocol v0.0.7
+	github.com/fsnotify/fsnotify v1.9.0
 	github.com/goccy/go-yaml v1.17.1
+	golang.org/x/text v0.34.0
+	golang.org/x/time v0.14.0
 )
+
+require golang.org/x/sys v0.13.0 // indirect
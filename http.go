@@ -0,0 +1,65 @@
+package mcpmds
+
+import (
+	"crypto/subtle"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/Warashi/go-modelcontextprotocol/transport"
+)
+
+// WithAuthToken requires HTTP requests to present the given bearer token in
+// the Authorization header. It applies only to handlers created by NewHandler;
+// the stdio transport is not affected.
+func WithAuthToken(token string) ServerOption {
+	return WithAuthorizer(func(r *http.Request) bool {
+		presented, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		return ok && subtle.ConstantTimeCompare([]byte(presented), []byte(token)) == 1
+	})
+}
+
+// WithAuthorizer sets a function deciding whether an HTTP request may access
+// the server. Rejected requests receive 401 Unauthorized. It applies only to
+// handlers created by NewHandler; the stdio transport is not affected.
+func WithAuthorizer(authorize func(r *http.Request) bool) ServerOption {
+	return func(s *Server) {
+		s.authorizer = authorize
+	}
+}
+
+// NewHandler creates an http.Handler serving markdown files from the provided
+// filesystem over the MCP SSE transport.
+// baseURL is the URL at which the handler is reachable, such as
+// "http://localhost:8080/sse"; clients connect to it and post messages to the
+// session endpoints beneath it. Resources can also be fetched directly with
+// GET requests for "<baseURL>/resources/<path>".
+func NewHandler(name, description string, fs fs.FS, baseURL string, opts ...ServerOption) (http.Handler, error) {
+	s := newServer(name, description, fs, opts...)
+	srv, err := s.server()
+	if err != nil {
+		return nil, err
+	}
+	h, err := transport.NewSSE(baseURL, s.sessionHandler(srv))
+	if err != nil {
+		return nil, err
+	}
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		return nil, err
+	}
+	return s.httpMiddleware(s.resourceHandler(u.Path, h)), nil
+}
+
+// httpMiddleware wraps h with the server's HTTP request checks.
+func (s *Server) httpMiddleware(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if s.authorizer != nil && !s.authorizer(r) {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+		h.ServeHTTP(w, s.withRateLimitClient(r))
+	})
+}
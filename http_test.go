@@ -0,0 +1,82 @@
+package mcpmds
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func TestNewHandler_authToken(t *testing.T) {
+	testFS := fstest.MapFS{
+		"file1.md": {Data: []byte("content1")},
+	}
+
+	tests := []struct {
+		name       string
+		opts       []ServerOption
+		header     string
+		wantStatus int
+	}{
+		{
+			name:       "No token configured",
+			opts:       nil,
+			header:     "",
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "Missing token",
+			opts:       []ServerOption{WithAuthToken("secret")},
+			header:     "",
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "Wrong token",
+			opts:       []ServerOption{WithAuthToken("secret")},
+			header:     "Bearer wrong",
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "Wrong scheme",
+			opts:       []ServerOption{WithAuthToken("secret")},
+			header:     "Basic secret",
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "Matching token",
+			opts:       []ServerOption{WithAuthToken("secret")},
+			header:     "Bearer secret",
+			wantStatus: http.StatusNotFound, // authorized, but the session does not exist
+		},
+		{
+			name: "Custom authorizer",
+			opts: []ServerOption{WithAuthorizer(func(r *http.Request) bool {
+				return r.Header.Get("X-Api-Key") == "key"
+			})},
+			header:     "Bearer secret",
+			wantStatus: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h, err := NewHandler("test-server", "test description", testFS, "http://example.com/sse", tt.opts...)
+			if err != nil {
+				t.Fatalf("NewHandler() error = %v", err)
+			}
+			req := httptest.NewRequest(http.MethodPost, "http://example.com/sse/12345", strings.NewReader(`{}`))
+			if tt.header != "" {
+				req.Header.Set("Authorization", tt.header)
+			}
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, req)
+			if rec.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+			if rec.Code == http.StatusUnauthorized && rec.Header().Get("WWW-Authenticate") != "Bearer" {
+				t.Errorf("WWW-Authenticate = %q, want Bearer", rec.Header().Get("WWW-Authenticate"))
+			}
+		})
+	}
+}
@@ -0,0 +1,96 @@
+package mcpmds
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+
+	"github.com/Warashi/go-modelcontextprotocol/jsonschema"
+	"github.com/Warashi/go-modelcontextprotocol/mcp"
+)
+
+// indexFileNames are the names of the files whose frontmatter a directory
+// passes down to the files beneath it, in the order they are applied.
+var indexFileNames = []string{"index.md", "_index.md"}
+
+func (s *Server) inheritedFrontmatterTool() mcp.Tool[*inheritedFrontmatterRequest, *inheritedFrontmatterResponse] {
+	return mcp.NewToolFunc(
+		fmt.Sprintf("inherited_frontmatter_%s", s.name),
+		fmt.Sprintf("Return the effective frontmatter of a markdown file managed by %s, merged from the index files of its ancestor directories down to the file itself, closest first", s.name),
+		jsonschema.Object{
+			Properties: map[string]jsonschema.Schema{
+				"path": jsonschema.String{
+					Description: "The path to the markdown file",
+				},
+			},
+			Required: []string{"path"},
+		},
+		s.inheritedFrontmatter,
+	)
+}
+
+type inheritedFrontmatterRequest struct {
+	Path string `json:"path" jsonschema:"required"`
+}
+
+type inheritedFrontmatterResponse struct {
+	// Path is the relative path to the markdown file.
+	Path string `json:"path"`
+	// Frontmatter is the merged frontmatter, where each top-level key takes
+	// the value of the closest file setting it.
+	Frontmatter map[string]any `json:"frontmatter"`
+	// Sources maps each top-level key to the path of the file its value comes from.
+	Sources map[string]string `json:"sources"`
+	// Chain lists the files merged, from the root down to the file itself.
+	Chain []string `json:"chain"`
+}
+
+func (s *Server) inheritedFrontmatter(ctx context.Context, request *inheritedFrontmatterRequest) (*inheritedFrontmatterResponse, error) {
+	p, _, err := s.readRequestedMarkdown(request.Path)
+	if err != nil {
+		return nil, err
+	}
+	resp := &inheritedFrontmatterResponse{Path: p, Frontmatter: map[string]any{}, Sources: map[string]string{}, Chain: []string{}}
+	for _, source := range append(ancestorIndexFiles(p), p) {
+		content, err := s.readMarkdown(source)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		frontmatter, err := s.readFrontmatter(content)
+		if err != nil {
+			return nil, err
+		}
+		for key, value := range frontmatter {
+			resp.Frontmatter[key] = value
+			resp.Sources[key] = source
+		}
+		resp.Chain = append(resp.Chain, source)
+	}
+	return resp, nil
+}
+
+// ancestorIndexFiles returns the candidate index files of the directories
+// containing p, from the root down, excluding p itself.
+func ancestorIndexFiles(p string) []string {
+	var dirs []string
+	for dir := path.Dir(p); ; dir = path.Dir(dir) {
+		dirs = append(dirs, dir)
+		if dir == "." {
+			break
+		}
+	}
+	var files []string
+	for i := len(dirs) - 1; i >= 0; i-- {
+		for _, name := range indexFileNames {
+			if f := path.Join(dirs[i], name); f != p {
+				files = append(files, f)
+			}
+		}
+	}
+	return files
+}
@@ -0,0 +1,83 @@
+package mcpmds
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"testing/fstest"
+)
+
+func TestInheritedFrontmatter(t *testing.T) {
+	testFS := fstest.MapFS{
+		"index.md":               {Data: []byte("---\nsite: Docs\nlayout: default\nauthor: team\n---\n")},
+		"guide/_index.md":        {Data: []byte("---\nsection: Guide\nlayout: guide\n---\n")},
+		"guide/setup/index.md":   {Data: []byte("---\nlayout: setup\nauthor: alice\n---\n")},
+		"guide/setup/install.md": {Data: []byte("---\nauthor: bob\ntitle: Install\n---\n")},
+		"guide/setup/plain.md":   {Data: []byte("no frontmatter")},
+	}
+	s := newServer("test", "test", testFS)
+
+	tests := []struct {
+		path string
+		want *inheritedFrontmatterResponse
+	}{
+		{
+			path: "guide/setup/install.md",
+			want: &inheritedFrontmatterResponse{
+				Path:        "guide/setup/install.md",
+				Frontmatter: map[string]any{"site": "Docs", "section": "Guide", "layout": "setup", "author": "bob", "title": "Install"},
+				Sources: map[string]string{
+					"site":    "index.md",
+					"section": "guide/_index.md",
+					"layout":  "guide/setup/index.md",
+					"author":  "guide/setup/install.md",
+					"title":   "guide/setup/install.md",
+				},
+				Chain: []string{"index.md", "guide/_index.md", "guide/setup/index.md", "guide/setup/install.md"},
+			},
+		},
+		{
+			path: "guide/setup/plain.md",
+			want: &inheritedFrontmatterResponse{
+				Path:        "guide/setup/plain.md",
+				Frontmatter: map[string]any{"site": "Docs", "section": "Guide", "layout": "setup", "author": "alice"},
+				Sources: map[string]string{
+					"site":    "index.md",
+					"section": "guide/_index.md",
+					"layout":  "guide/setup/index.md",
+					"author":  "guide/setup/index.md",
+				},
+				Chain: []string{"index.md", "guide/_index.md", "guide/setup/index.md", "guide/setup/plain.md"},
+			},
+		},
+		{
+			path: "guide/setup/index.md",
+			want: &inheritedFrontmatterResponse{
+				Path:        "guide/setup/index.md",
+				Frontmatter: map[string]any{"site": "Docs", "section": "Guide", "layout": "setup", "author": "alice"},
+				Sources: map[string]string{
+					"site":    "index.md",
+					"section": "guide/_index.md",
+					"layout":  "guide/setup/index.md",
+					"author":  "guide/setup/index.md",
+				},
+				Chain: []string{"index.md", "guide/_index.md", "guide/setup/index.md"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			got, err := s.inheritedFrontmatter(context.Background(), &inheritedFrontmatterRequest{Path: tt.path})
+			if err != nil {
+				t.Fatalf("inheritedFrontmatter() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("inheritedFrontmatter() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := s.inheritedFrontmatter(context.Background(), &inheritedFrontmatterRequest{Path: "guide/missing.md"}); err == nil {
+		t.Error("inheritedFrontmatter() for a missing file error = nil, want error")
+	}
+}
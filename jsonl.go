@@ -0,0 +1,73 @@
+package mcpmds
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"path"
+
+	"github.com/Warashi/go-modelcontextprotocol/jsonschema"
+	"github.com/Warashi/go-modelcontextprotocol/mcp"
+)
+
+func (s *Server) jsonlExportTool() mcp.Tool[*jsonlExportRequest, string] {
+	return mcp.NewToolFunc(
+		fmt.Sprintf("jsonl_export_%s", s.name),
+		fmt.Sprintf("Export the markdown files managed by %s as JSON Lines, one object per file with path, frontmatter, and body", s.name),
+		jsonschema.Object{
+			Properties: map[string]jsonschema.Schema{
+				"glob": jsonschema.String{
+					Description: "Only export files whose path matches this pattern (path.Match syntax)",
+				},
+				"frontmatter_only": jsonschema.Boolean{
+					Description: "Omit the body and export only path and frontmatter",
+				},
+			},
+		},
+		s.jsonlExport,
+	)
+}
+
+type jsonlExportRequest struct {
+	Glob            string `json:"glob"`
+	FrontmatterOnly bool   `json:"frontmatter_only"`
+}
+
+// jsonlRecord is a single line of the JSON Lines export.
+type jsonlRecord struct {
+	Path        string         `json:"path"`
+	Frontmatter map[string]any `json:"frontmatter"`
+	Body        *string        `json:"body,omitempty"`
+}
+
+func (s *Server) jsonlExport(ctx context.Context, request *jsonlExportRequest) (string, error) {
+	if request.Glob != "" {
+		if _, err := path.Match(request.Glob, ""); err != nil {
+			return "", fmt.Errorf("invalid glob: %w", err)
+		}
+	}
+	var b bytes.Buffer
+	enc := json.NewEncoder(&b)
+	for f := range s.markdownFiles(ctx) {
+		if request.Glob != "" {
+			if ok, _ := path.Match(request.Glob, f.Path); !ok {
+				continue
+			}
+		}
+		record := jsonlRecord{Path: f.Path, Frontmatter: f.Frontmatter}
+		if !request.FrontmatterOnly {
+			content, err := s.readMarkdown(f.Path)
+			if err != nil {
+				return "", err
+			}
+			_, _, body := s.splitFrontmatter(content)
+			text := string(body)
+			record.Body = &text
+		}
+		if err := enc.Encode(record); err != nil {
+			return "", err
+		}
+	}
+	return b.String(), nil
+}
@@ -0,0 +1,88 @@
+package mcpmds
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func Test_server_jsonlExport(t *testing.T) {
+	testFS := fstest.MapFS{
+		"a.md":           {Data: []byte("---\ntitle: A\n---\nbody of a\n")},
+		"docs/b.md":      {Data: []byte("body of b\n")},
+		"docs/c.md":      {Data: []byte("+++\ntitle = \"C\"\n+++\nbody of c\n")},
+		"docs/deep/d.md": {Data: []byte("body of d\n")},
+		"skip.txt":       {Data: []byte("not markdown")},
+	}
+
+	s := &Server{fs: testFS}
+
+	tests := []struct {
+		name            string
+		request         *jsonlExportRequest
+		wantPaths       []string
+		wantBody        map[string]string
+		frontmatterOnly bool
+		wantErr         bool
+	}{
+		{
+			name:      "All files",
+			request:   &jsonlExportRequest{},
+			wantPaths: []string{"a.md", "docs/b.md", "docs/c.md", "docs/deep/d.md"},
+			wantBody:  map[string]string{"a.md": "body of a\n", "docs/c.md": "body of c\n"},
+		},
+		{
+			name:      "Glob filter",
+			request:   &jsonlExportRequest{Glob: "docs/*.md"},
+			wantPaths: []string{"docs/b.md", "docs/c.md"},
+		},
+		{
+			name:            "Frontmatter only",
+			request:         &jsonlExportRequest{FrontmatterOnly: true},
+			wantPaths:       []string{"a.md", "docs/b.md", "docs/c.md", "docs/deep/d.md"},
+			frontmatterOnly: true,
+		},
+		{
+			name:    "Invalid glob",
+			request: &jsonlExportRequest{Glob: "["},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := s.jsonlExport(context.Background(), tt.request)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected an error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			lines := strings.Split(strings.TrimSuffix(got, "\n"), "\n")
+			if len(lines) != len(tt.wantPaths) {
+				t.Fatalf("got %d lines, want %d:\n%s", len(lines), len(tt.wantPaths), got)
+			}
+			for i, line := range lines {
+				var record map[string]any
+				if err := json.Unmarshal([]byte(line), &record); err != nil {
+					t.Fatalf("line %d is not valid JSON: %v", i+1, err)
+				}
+				if record["path"] != tt.wantPaths[i] {
+					t.Errorf("line %d path = %v, want %v", i+1, record["path"], tt.wantPaths[i])
+				}
+				body, hasBody := record["body"]
+				if hasBody == tt.frontmatterOnly {
+					t.Errorf("line %d has body = %v, want %v", i+1, hasBody, !tt.frontmatterOnly)
+				}
+				if want, ok := tt.wantBody[tt.wantPaths[i]]; ok && body != want {
+					t.Errorf("line %d body = %q, want %q", i+1, body, want)
+				}
+			}
+		})
+	}
+}
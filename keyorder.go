@@ -0,0 +1,146 @@
+package mcpmds
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+
+	"github.com/BurntSushi/toml"
+	"github.com/goccy/go-yaml"
+)
+
+// unmarshalYAML parses a YAML frontmatter block, returning its top-level keys
+// in source order.
+func unmarshalYAML(block []byte) (map[string]any, []string, error) {
+	var m yaml.MapSlice
+	if err := yaml.Unmarshal(block, &m); err != nil {
+		return nil, nil, err
+	}
+	if m == nil {
+		return nil, nil, nil
+	}
+	frontmatter := make(map[string]any, len(m))
+	keys := make([]string, 0, len(m))
+	for _, item := range m {
+		key := fmt.Sprint(item.Key)
+		if _, ok := frontmatter[key]; !ok {
+			keys = append(keys, key)
+		}
+		frontmatter[key] = item.Value
+	}
+	return frontmatter, keys, nil
+}
+
+// unmarshalTOML parses a TOML frontmatter block, returning its top-level keys
+// in source order.
+func unmarshalTOML(block []byte) (map[string]any, []string, error) {
+	var frontmatter map[string]any
+	md, err := toml.Decode(string(block), &frontmatter)
+	if err != nil {
+		return nil, nil, err
+	}
+	var keys []string
+	for _, key := range md.Keys() {
+		if !slices.Contains(keys, key[0]) {
+			keys = append(keys, key[0])
+		}
+	}
+	return frontmatter, keys, nil
+}
+
+// unmarshalJSON parses a JSON frontmatter block, returning its top-level keys
+// in source order.
+func unmarshalJSON(block []byte) (map[string]any, []string, error) {
+	dec := json.NewDecoder(bytes.NewReader(block))
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, nil, err
+	}
+	if tok == nil {
+		return nil, nil, checkJSONEnd(dec)
+	}
+	if tok != json.Delim('{') {
+		return nil, nil, fmt.Errorf("frontmatter is %v, not an object", tok)
+	}
+	frontmatter := map[string]any{}
+	var keys []string
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, nil, err
+		}
+		key := tok.(string)
+		var value any
+		if err := dec.Decode(&value); err != nil {
+			return nil, nil, err
+		}
+		if _, ok := frontmatter[key]; !ok {
+			keys = append(keys, key)
+		}
+		frontmatter[key] = value
+	}
+	if _, err := dec.Token(); err != nil {
+		return nil, nil, err
+	}
+	if err := checkJSONEnd(dec); err != nil {
+		return nil, nil, err
+	}
+	return frontmatter, keys, nil
+}
+
+// checkJSONEnd reports an error if dec has input left after a JSON value.
+func checkJSONEnd(dec *json.Decoder) error {
+	if _, err := dec.Token(); err != io.EOF {
+		return errors.New("invalid character after top-level value")
+	}
+	return nil
+}
+
+// orderedKeys returns the keys of frontmatter ordered as in order, followed
+// by keys missing from order, such as merged defaults, in sorted order.
+func orderedKeys(frontmatter map[string]any, order []string) []string {
+	keys := make([]string, 0, len(frontmatter))
+	for _, key := range order {
+		if _, ok := frontmatter[key]; ok && !slices.Contains(keys, key) {
+			keys = append(keys, key)
+		}
+	}
+	for _, key := range slices.Sorted(maps.Keys(frontmatter)) {
+		if !slices.Contains(keys, key) {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// marshalOrderedFrontmatter encodes frontmatter as a JSON object with its
+// top-level keys ordered as in order. Nested objects have sorted keys.
+func marshalOrderedFrontmatter(frontmatter map[string]any, order []string) ([]byte, error) {
+	if frontmatter == nil {
+		return []byte("null"), nil
+	}
+	var b bytes.Buffer
+	b.WriteByte('{')
+	for i, key := range orderedKeys(frontmatter, order) {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		k, err := json.Marshal(key)
+		if err != nil {
+			return nil, err
+		}
+		v, err := json.Marshal(frontmatter[key])
+		if err != nil {
+			return nil, err
+		}
+		b.Write(k)
+		b.WriteByte(':')
+		b.Write(v)
+	}
+	b.WriteByte('}')
+	return b.Bytes(), nil
+}
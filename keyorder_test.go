@@ -0,0 +1,85 @@
+package mcpmds
+
+import (
+	"reflect"
+	"slices"
+	"testing"
+	"testing/fstest"
+)
+
+func Test_server_resourceDescription_keyOrder(t *testing.T) {
+	testFS := fstest.MapFS{
+		"yaml.md":  {Data: []byte("---\ntitle: Y\nzeta: 1\nauthor: a\nmeta:\n  b: 2\n  a: 1\n---\n")},
+		"toml.md":  {Data: []byte("+++\ntitle = \"T\"\nzeta = 1\nauthor = \"a\"\n\n[meta]\nb = 2\na = 1\n+++\n")},
+		"json.md":  {Data: []byte(";;;\n{\"title\": \"J\", \"zeta\": 1, \"author\": \"a\"}\n;;;\n")},
+		"plain.md": {Data: []byte("no frontmatter")},
+	}
+	want := map[string]string{
+		"file://yaml.md":  `{"title":"Y","zeta":1,"author":"a","meta":{"a":1,"b":2}}`,
+		"file://toml.md":  `{"title":"T","zeta":1,"author":"a","meta":{"a":1,"b":2}}`,
+		"file://json.md":  `{"title":"J","zeta":1,"author":"a"}`,
+		"file://plain.md": `null`,
+	}
+
+	var first map[string]string
+	for range 20 {
+		s := &Server{fs: testFS}
+		resources, err := s.resourceList()
+		if err != nil {
+			t.Fatalf("resourceList() error = %v", err)
+		}
+		got := map[string]string{}
+		for _, r := range resources {
+			got[r.URI] = r.Description
+		}
+		if first == nil {
+			first = got
+			for uri, desc := range want {
+				if got[uri] != desc {
+					t.Errorf("description of %s = %s, want %s", uri, got[uri], desc)
+				}
+			}
+			continue
+		}
+		for uri, desc := range got {
+			if first[uri] != desc {
+				t.Fatalf("description of %s changed between parses: %s, then %s", uri, first[uri], desc)
+			}
+		}
+	}
+
+	t.Run("Keys outside the file follow in sorted order", func(t *testing.T) {
+		got, err := marshalOrderedFrontmatter(map[string]any{"title": "Y", "org": "x", "license": "MIT"}, []string{"title"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := `{"title":"Y","license":"MIT","org":"x"}`; string(got) != want {
+			t.Errorf("marshalOrderedFrontmatter() = %s, want %s", got, want)
+		}
+	})
+}
+
+func Test_unmarshalJSON(t *testing.T) {
+	tests := []struct {
+		block    string
+		want     map[string]any
+		wantKeys []string
+		wantErr  bool
+	}{
+		{block: `{"b": 1, "a": {"c": true}, "b": 2}`, want: map[string]any{"a": map[string]any{"c": true}, "b": 2.0}, wantKeys: []string{"b", "a"}},
+		{block: `null`},
+		{block: `[1, 2]`, wantErr: true},
+		{block: `{"a": 1} {}`, wantErr: true},
+		{block: `{"a": }`, wantErr: true},
+	}
+	for _, tt := range tests {
+		got, keys, err := unmarshalJSON([]byte(tt.block))
+		if (err != nil) != tt.wantErr {
+			t.Errorf("unmarshalJSON(%s) error = %v, wantErr %v", tt.block, err, tt.wantErr)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) || !slices.Equal(keys, tt.wantKeys) {
+			t.Errorf("unmarshalJSON(%s) = %v, %v, want %v, %v", tt.block, got, keys, tt.want, tt.wantKeys)
+		}
+	}
+}
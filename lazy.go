@@ -0,0 +1,64 @@
+package mcpmds
+
+import (
+	"io/fs"
+	"path/filepath"
+
+	"github.com/Warashi/go-modelcontextprotocol/mcp"
+)
+
+// WithLazyResources registers resources without reading the files, so that
+// startup cost does not grow with the size of the files. Frontmatter is parsed
+// only when a file is read. As a consequence, resources have no description
+// or annotations, files hidden by WithHideDrafts or duplicates hidden by
+// WithDeduplicateByHash are still listed as resources (reading them fails as
+// usual), and conflicting aliases are reported on first alias lookup rather
+// than when the server is created.
+func WithLazyResources(enabled bool) ServerOption {
+	return func(s *Server) {
+		s.lazyResources = enabled
+	}
+}
+
+// lazyResourceList returns the resources for the served files using only
+// directory entries, without reading file contents.
+func (s *Server) lazyResourceList() ([]annotatedResource, error) {
+	resources := []annotatedResource{}
+	ignore := s.newGitignore()
+	err := fs.WalkDir(s.fs, ".", func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		served, err := s.walkEntry(ignore, path, d)
+		if !served || err != nil {
+			return err
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		if s.tooLarge(info.Size()) {
+			return nil
+		}
+		resources = append(resources, annotatedResource{Resource: mcp.Resource{
+			URI:      resourceURI(path),
+			Name:     filepath.Base(path),
+			MimeType: s.mimeType(path),
+			Size:     info.Size(),
+		}})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if s.sitemap {
+		resources = append(resources, annotatedResource{Resource: sitemapResource()})
+	}
+	if s.manifest {
+		resources = append(resources, annotatedResource{Resource: manifestResource()})
+	}
+	if s.permalinkIndex {
+		resources = append(resources, annotatedResource{Resource: permalinkIndexResource()})
+	}
+	return resources, nil
+}
@@ -0,0 +1,142 @@
+package mcpmds
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"path"
+	"testing"
+	"testing/fstest"
+
+	"github.com/Warashi/go-modelcontextprotocol/mcp"
+)
+
+func TestWithLazyResources(t *testing.T) {
+	testFS := fstest.MapFS{
+		"a.md":     {Data: []byte("---\ndescription: Eager only\n---\nbody")},
+		"dir/b.md": {Data: []byte("body")},
+		"c.txt":    {Data: []byte("text")},
+	}
+
+	type resource struct {
+		URI         string `json:"uri"`
+		Description string `json:"description"`
+		Size        int64  `json:"size"`
+	}
+	list := func(t *testing.T, opts ...ServerOption) []resource {
+		t.Helper()
+		srv, err := newServer("test", "test", testFS, opts...).server()
+		if err != nil {
+			t.Fatalf("server() error = %v", err)
+		}
+		var result struct {
+			Resources []resource `json:"resources"`
+		}
+		if err := json.Unmarshal(callMCP(t, srv, "resources/list", map[string]any{}), &result); err != nil {
+			t.Fatal(err)
+		}
+		return result.Resources
+	}
+
+	lazy := list(t, WithLazyResources(true))
+	eager := list(t)
+	if len(lazy) != 2 || len(eager) != 2 {
+		t.Fatalf("got %d lazy and %d eager resources, want 2 each", len(lazy), len(eager))
+	}
+	for i := range lazy {
+		if lazy[i].URI != eager[i].URI || lazy[i].Size != eager[i].Size {
+			t.Errorf("lazy resource %+v differs from eager %+v", lazy[i], eager[i])
+		}
+		if lazy[i].Description != "" {
+			t.Errorf("lazy resource %s description = %q, want empty", lazy[i].URI, lazy[i].Description)
+		}
+	}
+}
+
+// countingFS counts the markdown files opened.
+type countingFS struct {
+	fs.FS
+	opened int
+}
+
+func (c *countingFS) Open(name string) (fs.File, error) {
+	if path.Ext(name) == ".md" {
+		c.opened++
+	}
+	return c.FS.Open(name)
+}
+
+func (c *countingFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	return fs.ReadDir(c.FS, name)
+}
+
+func TestWithLazyResources_readsNoFiles(t *testing.T) {
+	cfs := &countingFS{FS: fstest.MapFS{
+		"a.md":     {Data: []byte("---\ntitle: A\n---\nbody")},
+		"dir/b.md": {Data: []byte("body")},
+	}}
+	if _, err := newServer("test", "test", cfs, WithLazyResources(true)).server(); err != nil {
+		t.Fatalf("server() error = %v", err)
+	}
+	if cfs.opened != 0 {
+		t.Errorf("opened %d files at startup, want 0", cfs.opened)
+	}
+}
+
+func BenchmarkServerStartup(b *testing.B) {
+	testFS := fstest.MapFS{}
+	for i := range 1000 {
+		testFS[fmt.Sprintf("dir%d/file%d.md", i%10, i)] = &fstest.MapFile{
+			Data: []byte(fmt.Sprintf("---\ntitle: File %d\ntags: [a, b]\n---\n# File %d\n\nSome body text.\n", i, i)),
+		}
+	}
+	for _, bm := range []struct {
+		name string
+		opts []ServerOption
+	}{
+		{name: "eager"},
+		{name: "lazy", opts: []ServerOption{WithLazyResources(true)}},
+	} {
+		b.Run(bm.name, func(b *testing.B) {
+			for b.Loop() {
+				if _, err := newServer("bench", "bench", testFS, bm.opts...).server(); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func Test_server_resourceURIsRoundTrip(t *testing.T) {
+	testFS := fstest.MapFS{
+		"100%.md":      {Data: []byte("percent")},
+		"dir/a b#?.md": {Data: []byte("reserved")},
+	}
+	for _, opts := range [][]ServerOption{nil, {WithLazyResources(true)}} {
+		s := newServer("test", "test", testFS, opts...)
+		srv, err := s.server()
+		if err != nil {
+			t.Fatalf("server() error = %v", err)
+		}
+		var result struct {
+			Resources []mcp.Resource `json:"resources"`
+		}
+		if err := json.Unmarshal(callMCP(t, srv, "resources/list", map[string]any{}), &result); err != nil {
+			t.Fatal(err)
+		}
+		if len(result.Resources) != 2 {
+			t.Fatalf("got %d resources, want 2", len(result.Resources))
+		}
+		for _, r := range result.Resources {
+			got, err := s.ReadResource(t.Context(), &mcp.Request[mcp.ReadResourceRequestParams]{
+				Params: mcp.ReadResourceRequestParams{URI: r.URI},
+			})
+			if err != nil {
+				t.Fatalf("ReadResource(%q) error = %v", r.URI, err)
+			}
+			if text := got.Data.Contents[0].(mcp.TextResourceContents).Text; text == "" {
+				t.Errorf("ReadResource(%q) returned empty content", r.URI)
+			}
+		}
+	}
+}
@@ -0,0 +1,132 @@
+package mcpmds
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/Warashi/go-modelcontextprotocol/jsonschema"
+	"github.com/Warashi/go-modelcontextprotocol/mcp"
+)
+
+// prerequisitesKey is the frontmatter key listing the files to read before a file.
+const prerequisitesKey = "prerequisites"
+
+func (s *Server) learningPathTool() mcp.Tool[*learningPathRequest, *bundleResponse] {
+	return mcp.NewToolFunc(
+		fmt.Sprintf("learning_path_%s", s.name),
+		fmt.Sprintf("Return a single document concatenating markdown files managed by %s in reading order, each after the files listed in its prerequisites frontmatter", s.name),
+		jsonschema.Object{
+			Properties: map[string]jsonschema.Schema{
+				"path": jsonschema.String{
+					Description: "Only include this file and its transitive prerequisites; by default all files are included",
+				},
+				"max_bytes": jsonschema.Integer{
+					Description: fmt.Sprintf("The size cap of the bundle in bytes; defaults to %d", defaultBundleMaxBytes),
+				},
+			},
+		},
+		s.learningPath,
+	)
+}
+
+type learningPathRequest struct {
+	Path     string `json:"path"`
+	MaxBytes int    `json:"max_bytes"`
+}
+
+func (s *Server) learningPath(ctx context.Context, request *learningPathRequest) (*bundleResponse, error) {
+	root, err := s.requestPath(request.Path)
+	if err != nil {
+		return nil, err
+	}
+	prerequisites := map[string][]string{}
+	roots := []string{}
+	for f := range s.markdownFiles(ctx) {
+		roots = append(roots, f.Path)
+		prerequisites[f.Path] = stringList(f.Frontmatter[prerequisitesKey])
+	}
+	for _, source := range roots {
+		resolved := []string{}
+		for _, target := range prerequisites[source] {
+			p, ok := resolveLink(source, strings.TrimSpace(target))
+			if _, exists := prerequisites[p]; !ok || !exists {
+				return nil, fmt.Errorf("prerequisite %q of %s is not a served markdown file", target, source)
+			}
+			resolved = append(resolved, p)
+		}
+		prerequisites[source] = resolved
+	}
+	if root != "" {
+		if _, ok := prerequisites[root]; !ok {
+			_, err := s.readMarkdown(root)
+			if err == nil {
+				err = fmt.Errorf("%s is not a served markdown file", root)
+			}
+			return nil, err
+		}
+		roots = []string{root}
+	}
+	order, err := topologicalOrder(prerequisites, roots)
+	if err != nil {
+		return nil, err
+	}
+
+	maxBytes := request.MaxBytes
+	if maxBytes <= 0 {
+		maxBytes = defaultBundleMaxBytes
+	}
+	b := &bundleBuilder{maxBytes: maxBytes, files: []string{}}
+	for _, path := range order {
+		content, err := s.readMarkdown(path)
+		if err != nil {
+			return nil, err
+		}
+		if !b.add(path, s.renderContent(path, content)) {
+			break
+		}
+	}
+	return &bundleResponse{Files: b.files, Truncated: b.truncated, Content: b.b.String()}, nil
+}
+
+// topologicalOrder returns roots and the nodes they depend on, each after all
+// of its dependencies. Nodes are visited in the order of roots and of their
+// dependency lists, so the result is deterministic. It reports an error
+// naming the cycle if the dependencies are cyclic.
+func topologicalOrder(dependencies map[string][]string, roots []string) ([]string, error) {
+	const (
+		unvisited = iota
+		visiting
+		done
+	)
+	state := map[string]int{}
+	var order, stack []string
+	var visit func(node string) error
+	visit = func(node string) error {
+		switch state[node] {
+		case done:
+			return nil
+		case visiting:
+			cycle := append(stack[slices.Index(stack, node):], node)
+			return fmt.Errorf("prerequisite cycle: %s", strings.Join(cycle, " -> "))
+		}
+		state[node] = visiting
+		stack = append(stack, node)
+		for _, dep := range dependencies[node] {
+			if err := visit(dep); err != nil {
+				return err
+			}
+		}
+		stack = stack[:len(stack)-1]
+		state[node] = done
+		order = append(order, node)
+		return nil
+	}
+	for _, root := range roots {
+		if err := visit(root); err != nil {
+			return nil, err
+		}
+	}
+	return order, nil
+}
@@ -0,0 +1,105 @@
+package mcpmds
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func Test_server_learningPath(t *testing.T) {
+	dag := fstest.MapFS{
+		"advanced.md":     {Data: []byte("---\nprerequisites: [basics/intro.md, basics/setup.md]\n---\nAdvanced\n")},
+		"basics/intro.md": {Data: []byte("Intro\n")},
+		"basics/setup.md": {Data: []byte("---\nprerequisites: intro.md\n---\nSetup\n")},
+		"appendix.md":     {Data: []byte("---\nprerequisites: [/advanced.md]\n---\nAppendix\n")},
+	}
+
+	tests := []struct {
+		name          string
+		fs            fstest.MapFS
+		request       *learningPathRequest
+		wantFiles     []string
+		wantTruncated bool
+		wantErr       string
+	}{
+		{
+			name:      "All files in dependency order",
+			fs:        dag,
+			request:   &learningPathRequest{},
+			wantFiles: []string{"basics/intro.md", "basics/setup.md", "advanced.md", "appendix.md"},
+		},
+		{
+			name:      "Prerequisites of one file",
+			fs:        dag,
+			request:   &learningPathRequest{Path: "basics/setup.md"},
+			wantFiles: []string{"basics/intro.md", "basics/setup.md"},
+		},
+		{
+			name:          "Size cap",
+			fs:            dag,
+			request:       &learningPathRequest{MaxBytes: 30},
+			wantFiles:     []string{"basics/intro.md", "basics/setup.md"},
+			wantTruncated: true,
+		},
+		{
+			name: "Cycle is reported",
+			fs: fstest.MapFS{
+				"a.md": {Data: []byte("---\nprerequisites: [b.md]\n---\n")},
+				"b.md": {Data: []byte("---\nprerequisites: [c.md]\n---\n")},
+				"c.md": {Data: []byte("---\nprerequisites: [a.md]\n---\n")},
+			},
+			request: &learningPathRequest{},
+			wantErr: "prerequisite cycle: a.md -> b.md -> c.md -> a.md",
+		},
+		{
+			name: "Missing prerequisite",
+			fs: fstest.MapFS{
+				"a.md": {Data: []byte("---\nprerequisites: [nope.md]\n---\n")},
+			},
+			request: &learningPathRequest{},
+			wantErr: `prerequisite "nope.md" of a.md is not a served markdown file`,
+		},
+		{
+			name:    "Missing path",
+			fs:      dag,
+			request: &learningPathRequest{Path: "nope.md"},
+			wantErr: "file does not exist",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Server{fs: tt.fs}
+			got, err := s.learningPath(context.Background(), tt.request)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("learningPath() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("learningPath() error = %v", err)
+			}
+			if !reflect.DeepEqual(got.Files, tt.wantFiles) {
+				t.Errorf("learningPath() files = %v, want %v", got.Files, tt.wantFiles)
+			}
+			if got.Truncated != tt.wantTruncated {
+				t.Errorf("learningPath() truncated = %v, want %v", got.Truncated, tt.wantTruncated)
+			}
+		})
+	}
+
+	t.Run("Content has a section per file", func(t *testing.T) {
+		s := &Server{fs: dag}
+		got, err := s.learningPath(context.Background(), &learningPathRequest{Path: "basics/setup.md"})
+		if err != nil {
+			t.Fatalf("learningPath() error = %v", err)
+		}
+		want := "## basics/intro.md\n\nIntro\n\n## basics/setup.md\n\n---\nprerequisites: intro.md\n---\nSetup\n"
+		if got.Content != want {
+			t.Errorf("learningPath() content = %q, want %q", got.Content, want)
+		}
+	})
+}
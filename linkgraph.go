@@ -0,0 +1,90 @@
+package mcpmds
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/Warashi/go-modelcontextprotocol/jsonschema"
+	"github.com/Warashi/go-modelcontextprotocol/mcp"
+)
+
+func (s *Server) linkGraphTool() mcp.Tool[*linkGraphRequest, string] {
+	return mcp.NewToolFunc(
+		fmt.Sprintf("link_graph_%s", s.name),
+		fmt.Sprintf("Return the internal link graph between markdown files managed by %s", s.name),
+		jsonschema.Object{
+			Properties: map[string]jsonschema.Schema{
+				"format": jsonschema.String{
+					Description: "The output format, either \"dot\" (Graphviz) or \"json\"; defaults to \"json\"",
+				},
+			},
+		},
+		s.linkGraph,
+	)
+}
+
+type linkGraphRequest struct {
+	Format string `json:"format"`
+}
+
+// linkGraph is the link graph between markdown files.
+type linkGraph struct {
+	// Nodes are the paths of all served markdown files.
+	Nodes []string `json:"nodes"`
+	// Edges are the links between markdown files.
+	Edges []linkGraphEdge `json:"edges"`
+}
+
+// linkGraphEdge is a link from one markdown file to another.
+type linkGraphEdge struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+func (s *Server) linkGraph(ctx context.Context, request *linkGraphRequest) (string, error) {
+	index, err := s.linkIndex(ctx)
+	if err != nil {
+		return "", err
+	}
+	graph := linkGraph{
+		Nodes: slices.Sorted(maps.Keys(index)),
+		Edges: []linkGraphEdge{},
+	}
+	for _, from := range graph.Nodes {
+		for _, to := range slices.Sorted(slices.Values(index[from])) {
+			graph.Edges = append(graph.Edges, linkGraphEdge{From: from, To: to})
+		}
+	}
+
+	switch request.Format {
+	case "", "json":
+		b, err := json.Marshal(graph)
+		if err != nil {
+			return "", err
+		}
+		return string(b), nil
+	case "dot":
+		return graph.dot(s.name), nil
+	default:
+		return "", fmt.Errorf("unsupported format: %s", request.Format)
+	}
+}
+
+// dot renders the graph in the Graphviz DOT language.
+func (g linkGraph) dot(name string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "digraph %s {\n", strconv.Quote(name))
+	for _, n := range g.Nodes {
+		fmt.Fprintf(&b, "  %s;\n", strconv.Quote(n))
+	}
+	for _, e := range g.Edges {
+		fmt.Fprintf(&b, "  %s -> %s;\n", strconv.Quote(e.From), strconv.Quote(e.To))
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
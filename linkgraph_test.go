@@ -0,0 +1,90 @@
+package mcpmds
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"testing/fstest"
+)
+
+func Test_server_linkGraph(t *testing.T) {
+	testFS := fstest.MapFS{
+		"index.md": {Data: []byte(`---
+title: Index
+---
+See [the guide](docs/guide.md) and [the API](docs/api.md#usage).
+Also [external](https://example.com/doc.md) and [anchor](#top).
+`)},
+		"docs/guide.md": {Data: []byte(`Back to [index](../index.md), [api](./api.md) and [api again](api.md).
+![diagram](../img/diagram.png)
+
+` + "```" + `
+[not a link](index.md)
+` + "```" + `
+`)},
+		"docs/api.md":     {Data: []byte("Go to [index](/index.md) or [missing](missing.md).\n")},
+		"img/diagram.png": {Data: []byte("png")},
+	}
+
+	s := &Server{name: "docs", fs: testFS}
+
+	tests := []struct {
+		name    string
+		format  string
+		want    string
+		wantErr bool
+	}{
+		{
+			name:   "JSON format",
+			format: "json",
+			want:   `{"nodes":["docs/api.md","docs/guide.md","index.md"],"edges":[{"from":"docs/api.md","to":"index.md"},{"from":"docs/guide.md","to":"docs/api.md"},{"from":"docs/guide.md","to":"index.md"},{"from":"index.md","to":"docs/api.md"},{"from":"index.md","to":"docs/guide.md"}]}`,
+		},
+		{
+			name:   "DOT format",
+			format: "dot",
+			want: `digraph "docs" {
+  "docs/api.md";
+  "docs/guide.md";
+  "index.md";
+  "docs/api.md" -> "index.md";
+  "docs/guide.md" -> "docs/api.md";
+  "docs/guide.md" -> "index.md";
+  "index.md" -> "docs/api.md";
+  "index.md" -> "docs/guide.md";
+}
+`,
+		},
+		{
+			name:    "Unsupported format",
+			format:  "svg",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := s.linkGraph(context.Background(), &linkGraphRequest{Format: tt.format})
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected an error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("linkGraph() got =\n%s\nwant =\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_server_linkGraph_canceled(t *testing.T) {
+	s := &Server{name: "docs", fs: fstest.MapFS{"index.md": {Data: []byte("[self](index.md)\n")}}}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := s.linkGraph(ctx, &linkGraphRequest{}); !errors.Is(err, context.Canceled) {
+		t.Errorf("linkGraph() error = %v, want context.Canceled", err)
+	}
+}
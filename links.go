@@ -0,0 +1,207 @@
+package mcpmds
+
+import (
+	"context"
+	"io/fs"
+	"net/url"
+	"path"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// markdownLink is a link or image reference found in a markdown body.
+type markdownLink struct {
+	// Text is the link text or image alt text.
+	Text string `json:"text"`
+	// URL is the link destination as written in the document.
+	URL string `json:"url"`
+	// Image reports whether the link is an image.
+	Image bool `json:"image"`
+	// Kind classifies the destination as "internal" for a relative or
+	// root-absolute path, "external" for a URL with a scheme or host, or
+	// "anchor" for a fragment within the same document.
+	Kind string `json:"kind"`
+	// Reference reports whether the link is reference-style, with its
+	// destination given in a separate [label]: url definition.
+	Reference bool `json:"reference,omitempty"`
+	// Line is the 1-based line number of the link within the body.
+	Line int `json:"line"`
+}
+
+// Kinds of link destinations.
+const (
+	linkKindInternal = "internal"
+	linkKindExternal = "external"
+	linkKindAnchor   = "anchor"
+)
+
+var (
+	inlineLinkPattern          = regexp.MustCompile(`(!?)\[([^\]]*)\]\(\s*<?([^)\s>]*)>?(?:\s+["'(][^)]*)?\)`)
+	referenceLinkPattern       = regexp.MustCompile(`(!?)\[([^\]]*)\](?:\[([^\]]*)\])?`)
+	referenceDefinitionPattern = regexp.MustCompile(`^ {0,3}\[([^\]^][^\]]*)\]:\s*<?([^\s>]+)>?(?:\s+["'(].*)?$`)
+	codeSpanPattern            = regexp.MustCompile("`+[^`]*`+")
+)
+
+// extractLinks returns the inline and reference-style links and images in
+// body, in document order, skipping fenced code blocks and inline code spans.
+// Reference-style links are reported only when their label is defined.
+func extractLinks(body []byte) []markdownLink {
+	definitions := linkDefinitions(body)
+	var links []markdownLink
+	for line := range markdownLines(body) {
+		if line.InCode || referenceDefinitionPattern.MatchString(line.Text) {
+			continue
+		}
+		text := codeSpanPattern.ReplaceAllString(line.Text, "")
+		inline := inlineLinkPattern.FindAllStringSubmatchIndex(text, -1)
+		refs := referenceLinkPattern.FindAllStringSubmatchIndex(text, -1)
+		for len(inline) > 0 || len(refs) > 0 {
+			if len(refs) == 0 || len(inline) > 0 && inline[0][0] <= refs[0][0] {
+				m := inline[0]
+				inline = inline[1:]
+				links = append(links, newMarkdownLink(text[m[4]:m[5]], text[m[6]:m[7]], m[3] > m[2], false, line.Number))
+				for len(refs) > 0 && refs[0][0] < m[1] {
+					refs = refs[1:]
+				}
+				continue
+			}
+			m := refs[0]
+			refs = refs[1:]
+			label := text[m[4]:m[5]]
+			if m[6] >= 0 && m[7] > m[6] {
+				label = text[m[6]:m[7]]
+			}
+			if dest, ok := definitions[normalizeLinkLabel(label)]; ok {
+				links = append(links, newMarkdownLink(text[m[4]:m[5]], dest, m[3] > m[2], true, line.Number))
+			}
+		}
+	}
+	return links
+}
+
+// newMarkdownLink returns a link with its kind derived from dest.
+func newMarkdownLink(text, dest string, image, reference bool, line int) markdownLink {
+	return markdownLink{Text: text, URL: dest, Image: image, Kind: linkKind(dest), Reference: reference, Line: line}
+}
+
+// linkDefinitions returns the destinations of the [label]: url definitions in
+// body, keyed by normalized label. The first definition of a label wins.
+func linkDefinitions(body []byte) map[string]string {
+	definitions := map[string]string{}
+	for line := range markdownLines(body) {
+		if line.InCode {
+			continue
+		}
+		m := referenceDefinitionPattern.FindStringSubmatch(line.Text)
+		if m == nil {
+			continue
+		}
+		label := normalizeLinkLabel(m[1])
+		if _, ok := definitions[label]; !ok {
+			definitions[label] = m[2]
+		}
+	}
+	return definitions
+}
+
+// normalizeLinkLabel returns label folded to lower case with runs of
+// whitespace collapsed, so that equivalent labels match.
+func normalizeLinkLabel(label string) string {
+	return strings.ToLower(strings.Join(strings.Fields(label), " "))
+}
+
+// linkKind classifies a link destination as internal, external, or an anchor.
+func linkKind(target string) string {
+	if strings.HasPrefix(target, "#") {
+		return linkKindAnchor
+	}
+	if u, err := url.Parse(target); err == nil && (u.Scheme != "" || u.Host != "") {
+		return linkKindExternal
+	}
+	return linkKindInternal
+}
+
+// resolveLink resolves a link destination found in the file at source to a
+// clean path relative to the root of the filesystem.
+// It reports false for external URLs, pure anchors, and paths escaping the root.
+func resolveLink(source, target string) (string, bool) {
+	u, err := url.Parse(target)
+	if err != nil || u.Scheme != "" || u.Host != "" || u.Path == "" {
+		return "", false
+	}
+	var p string
+	if strings.HasPrefix(u.Path, "/") {
+		p = path.Clean(strings.TrimPrefix(u.Path, "/"))
+	} else {
+		p = path.Join(path.Dir(source), u.Path)
+	}
+	if !fs.ValidPath(p) || p == "." {
+		return "", false
+	}
+	return p, true
+}
+
+// linkIndex maps each served markdown file to the markdown files it links to,
+// in order of first appearance and without duplicates.
+func (s *Server) linkIndex(ctx context.Context) (map[string][]string, error) {
+	files := map[string]bool{}
+	for f := range s.markdownFiles(ctx) {
+		files[f.Path] = true
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	index := make(map[string][]string, len(files))
+	for source := range files {
+		content, err := s.readMarkdown(source)
+		if err != nil {
+			return nil, err
+		}
+		_, _, body := s.splitFrontmatter(content)
+		seen := map[string]bool{}
+		targets := []string{}
+		for _, link := range extractLinks(body) {
+			target, ok := resolveLink(source, link.URL)
+			if !ok || !files[target] || seen[target] {
+				continue
+			}
+			seen[target] = true
+			targets = append(targets, target)
+		}
+		index[source] = targets
+	}
+	return index, nil
+}
+
+// rewriteLinks returns body with the destination of each inline link and image
+// replaced by the result of rewrite, leaving fenced code blocks and inline code
+// spans untouched. Links for which rewrite reports false are kept as written.
+func rewriteLinks(body []byte, rewrite func(link markdownLink) (string, bool)) []byte {
+	lines := strings.Split(string(body), "\n")
+	for line := range markdownLines(body) {
+		if line.InCode {
+			continue
+		}
+		text := lines[line.Number-1]
+		spans := codeSpanPattern.FindAllStringIndex(text, -1)
+		var b strings.Builder
+		last := 0
+		for _, m := range inlineLinkPattern.FindAllStringSubmatchIndex(text, -1) {
+			if slices.ContainsFunc(spans, func(span []int) bool { return m[0] < span[1] && span[0] < m[1] }) {
+				continue
+			}
+			link := newMarkdownLink(text[m[4]:m[5]], text[m[6]:m[7]], m[3] > m[2], false, line.Number)
+			url, ok := rewrite(link)
+			if !ok {
+				continue
+			}
+			b.WriteString(text[last:m[6]])
+			b.WriteString(url)
+			last = m[7]
+		}
+		b.WriteString(text[last:])
+		lines[line.Number-1] = b.String()
+	}
+	return []byte(strings.Join(lines, "\n"))
+}
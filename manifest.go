@@ -0,0 +1,86 @@
+package mcpmds
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/Warashi/go-modelcontextprotocol/mcp"
+)
+
+// manifestURI is the URI of the synthetic manifest resource.
+const manifestURI = "file://_manifest.json"
+
+// WithManifest registers a synthetic resource, file://_manifest.json, holding
+// a JSON catalog of the served markdown files with their paths, content
+// hashes, sizes, modification times, and frontmatter titles. The catalog is
+// generated when the resource is read, so clients can sync the whole corpus
+// with one read.
+func WithManifest(enabled bool) ServerOption {
+	return func(s *Server) {
+		s.manifest = enabled
+	}
+}
+
+// manifestResource is the resource entry of the manifest.
+func manifestResource() mcp.Resource {
+	return mcp.Resource{
+		URI:         manifestURI,
+		Name:        "_manifest.json",
+		Description: "A JSON catalog of the markdown files with their hashes, sizes, and modification times",
+		MimeType:    "application/json",
+	}
+}
+
+type manifest struct {
+	Files []manifestEntry `json:"files"`
+}
+
+type manifestEntry struct {
+	Path string `json:"path"`
+	// SHA256 is the hex-encoded SHA-256 hash of the file content.
+	SHA256  string    `json:"sha256"`
+	Size    int64     `json:"size"`
+	ModTime time.Time `json:"mod_time"`
+	Title   string    `json:"title,omitempty"`
+}
+
+// renderManifest returns the manifest of the served markdown files.
+func (s *Server) renderManifest(ctx context.Context) ([]byte, error) {
+	files, err := s.collectMarkdownFiles(ctx)
+	if err != nil {
+		return nil, err
+	}
+	m := manifest{Files: []manifestEntry{}}
+	for _, f := range files {
+		content, err := s.readMarkdown(f.Path)
+		if err != nil {
+			return nil, err
+		}
+		entry := manifestEntry{
+			Path:    f.Path,
+			SHA256:  contentSHA256(content),
+			Size:    f.Size,
+			ModTime: f.ModTime,
+		}
+		if title, ok := f.Frontmatter["title"].(string); ok {
+			entry.Title = strings.TrimSpace(title)
+		}
+		m.Files = append(m.Files, entry)
+	}
+	b, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(b, '\n'), nil
+}
+
+// contentSHA256 returns the hex-encoded SHA-256 hash of content, which
+// identifies a file's content in the manifest.
+func contentSHA256(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,90 @@
+package mcpmds
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/Warashi/go-modelcontextprotocol/mcp"
+)
+
+func Test_server_manifest(t *testing.T) {
+	modTime := time.Date(2024, 5, 6, 7, 8, 9, 0, time.UTC)
+	testFS := fstest.MapFS{
+		"index.md":      {Data: []byte("---\ntitle: Home\n---\nwelcome"), ModTime: modTime},
+		"docs/guide.md": {Data: []byte("# Guide\n"), ModTime: modTime},
+		"notes.txt":     {Data: []byte("not markdown")},
+	}
+	hash := func(path string) string {
+		sum := sha256.Sum256(testFS[path].Data)
+		return hex.EncodeToString(sum[:])
+	}
+
+	s := &Server{fs: testFS}
+	WithManifest(true)(s)
+
+	resources, err := s.resourceList()
+	if err != nil {
+		t.Fatalf("resourceList() error = %v", err)
+	}
+	if last := resources[len(resources)-1]; last.URI != manifestURI {
+		t.Errorf("last resource = %s, want %s", last.URI, manifestURI)
+	}
+
+	req := &mcp.Request[mcp.ReadResourceRequestParams]{Params: mcp.ReadResourceRequestParams{URI: manifestURI}}
+	got, err := s.ReadResource(context.Background(), req)
+	if err != nil {
+		t.Fatalf("ReadResource() error = %v", err)
+	}
+	contents := got.Data.Contents[0].(mcp.TextResourceContents)
+	if contents.MimeType != "application/json" {
+		t.Errorf("MimeType = %q, want application/json", contents.MimeType)
+	}
+	var m manifest
+	if err := json.Unmarshal([]byte(contents.Text), &m); err != nil {
+		t.Fatalf("unmarshal manifest: %v", err)
+	}
+	want := []manifestEntry{
+		{Path: "docs/guide.md", SHA256: hash("docs/guide.md"), Size: 8, ModTime: modTime},
+		{Path: "index.md", SHA256: hash("index.md"), Size: 27, ModTime: modTime, Title: "Home"},
+	}
+	if len(m.Files) != len(want) {
+		t.Fatalf("manifest files = %+v, want %+v", m.Files, want)
+	}
+	for i := range want {
+		if !m.Files[i].ModTime.Equal(want[i].ModTime) {
+			t.Errorf("files[%d].ModTime = %v, want %v", i, m.Files[i].ModTime, want[i].ModTime)
+		}
+		m.Files[i].ModTime = want[i].ModTime
+		if m.Files[i] != want[i] {
+			t.Errorf("files[%d] = %+v, want %+v", i, m.Files[i], want[i])
+		}
+	}
+
+	t.Run("ReflectsChanges", func(t *testing.T) {
+		testFS["new.md"] = &fstest.MapFile{Data: []byte("new")}
+		defer delete(testFS, "new.md")
+		got, err := s.ReadResource(context.Background(), req)
+		if err != nil {
+			t.Fatalf("ReadResource() error = %v", err)
+		}
+		var m manifest
+		if err := json.Unmarshal([]byte(got.Data.Contents[0].(mcp.TextResourceContents).Text), &m); err != nil {
+			t.Fatal(err)
+		}
+		if len(m.Files) != 3 || m.Files[1].Path != "index.md" || m.Files[2].Path != "new.md" {
+			t.Errorf("manifest files = %+v, want docs/guide.md, index.md, new.md", m.Files)
+		}
+	})
+
+	t.Run("Disabled", func(t *testing.T) {
+		s := &Server{fs: testFS}
+		if _, err := s.ReadResource(context.Background(), req); err == nil {
+			t.Error("ReadResource() succeeded, want error")
+		}
+	})
+}
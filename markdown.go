@@ -0,0 +1,76 @@
+package mcpmds
+
+import (
+	"iter"
+	"strings"
+)
+
+// markdownLine is a single line of a markdown body.
+type markdownLine struct {
+	// Number is the 1-based line number within the body.
+	Number int
+	// Text is the line without its trailing line terminator.
+	Text string
+	// InCode reports whether the line belongs to a fenced code block,
+	// including the opening and closing fence lines.
+	InCode bool
+	// OpensCode reports whether the line is the opening fence of a code block.
+	OpensCode bool
+}
+
+// markdownLines returns an iterator over the lines of body, tracking whether
+// each line is part of a fenced code block.
+func markdownLines(body []byte) iter.Seq[markdownLine] {
+	return func(yield func(markdownLine) bool) {
+		var fence string
+		for i, text := range strings.Split(string(body), "\n") {
+			text = strings.TrimSuffix(text, "\r")
+			line := markdownLine{Number: i + 1, Text: text, InCode: fence != ""}
+			if marker := fenceMarker(text); marker != "" {
+				switch {
+				case fence == "":
+					fence = marker
+					line.InCode = true
+					line.OpensCode = true
+				case marker[0] == fence[0] && len(marker) >= len(fence) && strings.TrimSpace(text) == marker:
+					fence = ""
+				}
+			}
+			if !yield(line) {
+				return
+			}
+		}
+	}
+}
+
+// fenceMarker returns the run of backticks or tildes opening a code fence on
+// line, or an empty string if line is not a fence.
+func fenceMarker(line string) string {
+	trimmed := strings.TrimLeft(line, " ")
+	if len(line)-len(trimmed) > 3 || len(trimmed) < 3 {
+		return ""
+	}
+	c := trimmed[0]
+	if c != '`' && c != '~' {
+		return ""
+	}
+	n := 0
+	for n < len(trimmed) && trimmed[n] == c {
+		n++
+	}
+	if n < 3 {
+		return ""
+	}
+	return trimmed[:n]
+}
+
+// fenceLanguage returns the language of a code block from its opening fence
+// line: the first word of the info string, or an empty string if there is none.
+func fenceLanguage(line string) string {
+	info := strings.TrimPrefix(strings.TrimLeft(line, " "), fenceMarker(line))
+	fields := strings.Fields(info)
+	if len(fields) == 0 {
+		return ""
+	}
+	return fields[0]
+}
@@ -0,0 +1,77 @@
+package mcpmds
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/Warashi/go-modelcontextprotocol/jsonschema"
+	"github.com/Warashi/go-modelcontextprotocol/mcp"
+)
+
+// WithRootRelativeLinks makes the links tool report internal link and image
+// targets as paths relative to the root of the filesystem instead of as
+// written relative to the linking file, so targets are comparable across files.
+// External links, anchors, and targets escaping the root are reported as written.
+func WithRootRelativeLinks(enabled bool) ServerOption {
+	return func(s *Server) {
+		s.rootRelativeLinks = enabled
+	}
+}
+
+func (s *Server) listMarkdownLinksTool() mcp.Tool[*listMarkdownLinksRequest, *listMarkdownLinksResponse] {
+	return mcp.NewToolFunc(
+		fmt.Sprintf("list_%s_markdown_links", s.name),
+		fmt.Sprintf("List the links and images in a markdown file managed by %s", s.name),
+		jsonschema.Object{
+			Properties: map[string]jsonschema.Schema{
+				"path": jsonschema.String{
+					Description: "The path to the markdown file",
+				},
+			},
+			Required: []string{"path"},
+		},
+		s.listMarkdownLinks,
+	)
+}
+
+type listMarkdownLinksRequest struct {
+	Path string `json:"path" jsonschema:"required"`
+}
+
+type listMarkdownLinksResponse struct {
+	// Path is the relative path to the markdown file.
+	Path string `json:"path"`
+	// Links are the links and images in document order.
+	Links []markdownLink `json:"links"`
+}
+
+func (s *Server) listMarkdownLinks(ctx context.Context, request *listMarkdownLinksRequest) (*listMarkdownLinksResponse, error) {
+	path, content, err := s.readMarkdownOrAlias(ctx, request.Path)
+	if err != nil {
+		return nil, err
+	}
+	_, _, body := s.splitFrontmatter(content)
+
+	resp := &listMarkdownLinksResponse{Path: path, Links: []markdownLink{}}
+	for _, link := range extractLinks(body) {
+		if s.rootRelativeLinks {
+			link.URL = rootRelativeLink(path, link.URL)
+		}
+		resp.Links = append(resp.Links, link)
+	}
+	return resp, nil
+}
+
+// rootRelativeLink returns target, found in the file at source, as a path
+// relative to the root, keeping its query and fragment. Targets that do not
+// resolve to a path inside the root are returned unchanged.
+func rootRelativeLink(source, target string) string {
+	p, ok := resolveLink(source, target)
+	if !ok {
+		return target
+	}
+	u, _ := url.Parse(target)
+	u.Path = p
+	return u.String()
+}
@@ -0,0 +1,84 @@
+package mcpmds
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"testing/fstest"
+)
+
+func Test_server_listMarkdownLinks(t *testing.T) {
+	testFS := fstest.MapFS{
+		"docs/guide/a.md": {Data: []byte("---\ntitle: A\n---\n![diagram](../img/x.png)\n[b](b.md#setup) [root](/README.md) [go](https://go.dev) [up](../../../out.md)\n")},
+	}
+
+	tests := []struct {
+		name string
+		opts []ServerOption
+		want []markdownLink
+	}{
+		{
+			name: "As written",
+			want: []markdownLink{
+				{Text: "diagram", URL: "../img/x.png", Image: true, Kind: "internal", Line: 1},
+				{Text: "b", URL: "b.md#setup", Kind: "internal", Line: 2},
+				{Text: "root", URL: "/README.md", Kind: "internal", Line: 2},
+				{Text: "go", URL: "https://go.dev", Kind: "external", Line: 2},
+				{Text: "up", URL: "../../../out.md", Kind: "internal", Line: 2},
+			},
+		},
+		{
+			name: "Root-relative",
+			opts: []ServerOption{WithRootRelativeLinks(true)},
+			want: []markdownLink{
+				{Text: "diagram", URL: "docs/img/x.png", Image: true, Kind: "internal", Line: 1},
+				{Text: "b", URL: "docs/guide/b.md#setup", Kind: "internal", Line: 2},
+				{Text: "root", URL: "README.md", Kind: "internal", Line: 2},
+				{Text: "go", URL: "https://go.dev", Kind: "external", Line: 2},
+				{Text: "up", URL: "../../../out.md", Kind: "internal", Line: 2},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Server{fs: testFS}
+			for _, opt := range tt.opts {
+				opt(s)
+			}
+			got, err := s.listMarkdownLinks(context.Background(), &listMarkdownLinksRequest{Path: "docs/guide/a.md"})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got.Links, tt.want) {
+				t.Errorf("listMarkdownLinks() = %#v, want %#v", got.Links, tt.want)
+			}
+		})
+	}
+}
+
+func Test_extractLinks(t *testing.T) {
+	body := "See [intro](#intro), [the spec][spec], and [Spec][] or [spec].\n" +
+		"![logo][img] [missing][nope] [^1] - [ ] task\n" +
+		"\n" +
+		"```\n" +
+		"[in code](code.md) [spec]\n" +
+		"```\n" +
+		"`[span](span.md)` [mail](mailto:a@example.com)\n" +
+		"\n" +
+		"[spec]: ./spec.md \"The spec\"\n" +
+		"[img]: <https://example.com/logo.png>\n" +
+		"[SPEC]: ignored.md\n" +
+		"[^1]: A footnote.\n"
+	want := []markdownLink{
+		{Text: "intro", URL: "#intro", Kind: "anchor", Line: 1},
+		{Text: "the spec", URL: "./spec.md", Kind: "internal", Reference: true, Line: 1},
+		{Text: "Spec", URL: "./spec.md", Kind: "internal", Reference: true, Line: 1},
+		{Text: "spec", URL: "./spec.md", Kind: "internal", Reference: true, Line: 1},
+		{Text: "logo", URL: "https://example.com/logo.png", Image: true, Kind: "external", Reference: true, Line: 2},
+		{Text: "mail", URL: "mailto:a@example.com", Kind: "external", Line: 7},
+	}
+	if got := extractLinks([]byte(body)); !reflect.DeepEqual(got, want) {
+		t.Errorf("extractLinks() = %#v, want %#v", got, want)
+	}
+}
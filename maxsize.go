@@ -0,0 +1,41 @@
+package mcpmds
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+)
+
+// errFileTooLarge is reported for files larger than the limit set by WithMaxFileSize.
+var errFileTooLarge = errors.New("file too large")
+
+// WithMaxFileSize excludes markdown files larger than the given number of
+// bytes from listings, and fails reads of them with an error instead of
+// loading them. Zero means no limit.
+func WithMaxFileSize(bytes int64) ServerOption {
+	return func(s *Server) {
+		s.maxFileSize = bytes
+	}
+}
+
+// tooLarge reports whether a file of the given size exceeds the limit set by
+// WithMaxFileSize.
+func (s *Server) tooLarge(size int64) bool {
+	return s.maxFileSize > 0 && size > s.maxFileSize
+}
+
+// checkFileSize reports errFileTooLarge if the file at path exceeds the limit
+// set by WithMaxFileSize, without reading it.
+func (s *Server) checkFileSize(path string) error {
+	if s.maxFileSize <= 0 {
+		return nil
+	}
+	info, err := fs.Stat(s.fs, path)
+	if err != nil {
+		return err
+	}
+	if s.tooLarge(info.Size()) {
+		return fmt.Errorf("markdown file %q is %d bytes, over the limit of %d bytes: %w", path, info.Size(), s.maxFileSize, errFileTooLarge)
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+package mcpmds
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/Warashi/go-modelcontextprotocol/mcp"
+)
+
+func TestWithMaxFileSize(t *testing.T) {
+	testFS := fstest.MapFS{
+		"under.md": {Data: []byte("# 9 bytes")},
+		"limit.md": {Data: []byte("# 10 bytes")},
+		"over.md":  {Data: []byte("# 11 bytes!")},
+	}
+
+	for _, tt := range []struct {
+		name string
+		opts []ServerOption
+		want []string
+	}{
+		{name: "limit", opts: []ServerOption{WithMaxFileSize(10)}, want: []string{"limit.md", "under.md"}},
+		{name: "lazy", opts: []ServerOption{WithMaxFileSize(10), WithLazyResources(true)}, want: []string{"limit.md", "under.md"}},
+		{name: "no limit", opts: []ServerOption{WithMaxFileSize(0)}, want: []string{"limit.md", "over.md", "under.md"}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newServer("test", "test", testFS, tt.opts...)
+			resources, err := s.resourceList()
+			if err != nil {
+				t.Fatalf("resourceList() error = %v", err)
+			}
+			var got []string
+			for _, r := range resources {
+				got = append(got, strings.TrimPrefix(r.URI, "file://"))
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("resourceList() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	s := newServer("test", "test", testFS, WithMaxFileSize(10))
+	resp, err := s.listMarkdownFiles(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("listMarkdownFiles() error = %v", err)
+	}
+	if len(resp.Files) != 2 {
+		t.Errorf("listMarkdownFiles() returned %d files, want 2", len(resp.Files))
+	}
+	if _, err := s.readMarkdownFile(context.Background(), &readMarkdownFileRequest{Path: "limit.md"}); err != nil {
+		t.Errorf("readMarkdownFile(limit.md) error = %v", err)
+	}
+	_, err = s.readMarkdownFile(context.Background(), &readMarkdownFileRequest{Path: "over.md"})
+	if !errors.Is(err, errFileTooLarge) || !strings.Contains(err.Error(), `"over.md" is 11 bytes`) {
+		t.Errorf("readMarkdownFile(over.md) error = %v, want file too large", err)
+	}
+	req := &mcp.Request[mcp.ReadResourceRequestParams]{Params: mcp.ReadResourceRequestParams{URI: "file://over.md"}}
+	if _, err := s.ReadResource(context.Background(), req); !errors.Is(err, errFileTooLarge) {
+		t.Errorf("ReadResource(over.md) error = %v, want file too large", err)
+	}
+}
@@ -0,0 +1,36 @@
+package mcpmds
+
+// frontmatterMetaKey is the key under which WithFrontmatterMeta puts the
+// frontmatter in _meta.
+const frontmatterMetaKey = "frontmatter"
+
+// frontmatterErrorMetaKey is the key under which WithFrontmatterMeta reports
+// frontmatter that cannot be parsed, in place of the frontmatter.
+const frontmatterErrorMetaKey = "frontmatter_error"
+
+// WithFrontmatterMeta adds the parsed frontmatter, after exclusions and
+// redactions, to the _meta field of listed resources and of resources/read
+// results, under the "frontmatter" key. The JSON description is kept, so
+// clients reading either stay compatible. With WithLazyResources, only read
+// results carry the frontmatter. Frontmatter that cannot be parsed is reported
+// under the "frontmatter_error" key instead, and the content is still served.
+func WithFrontmatterMeta(enabled bool) ServerOption {
+	return func(s *Server) {
+		s.frontmatterMeta = enabled
+	}
+}
+
+// frontmatterMeta returns the _meta bag holding frontmatter, or nil if there
+// is no frontmatter.
+func frontmatterMeta(frontmatter map[string]any) map[string]any {
+	if len(frontmatter) == 0 {
+		return nil
+	}
+	return map[string]any{frontmatterMetaKey: frontmatter}
+}
+
+// frontmatterErrorMeta returns the _meta bag reporting that the frontmatter
+// cannot be parsed, with the parse error message.
+func frontmatterErrorMeta(message string) map[string]any {
+	return map[string]any{frontmatterErrorMetaKey: message}
+}
@@ -0,0 +1,106 @@
+package mcpmds
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"testing/fstest"
+)
+
+func TestWithFrontmatterMeta(t *testing.T) {
+	testFS := fstest.MapFS{
+		"a.md":     {Data: []byte("---\ntitle: A\ntags: [x, y]\nsecret: s\n---\nbody")},
+		"plain.md": {Data: []byte("body")},
+	}
+	wantMeta := map[string]any{"frontmatter": map[string]any{"title": "A", "tags": []any{"x", "y"}}}
+
+	type resource struct {
+		URI         string         `json:"uri"`
+		Description string         `json:"description"`
+		Meta        map[string]any `json:"_meta"`
+	}
+	tests := []struct {
+		name    string
+		enabled bool
+		want    map[string]map[string]any
+	}{
+		{name: "enabled", enabled: true, want: map[string]map[string]any{"file://a.md": wantMeta, "file://plain.md": nil}},
+		{name: "disabled", want: map[string]map[string]any{"file://a.md": nil, "file://plain.md": nil}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv, err := newServer("test", "test", testFS, WithFrontmatterMeta(tt.enabled), WithExcludeFrontmatter("secret")).server()
+			if err != nil {
+				t.Fatalf("server() error = %v", err)
+			}
+
+			var list struct {
+				Resources []resource `json:"resources"`
+			}
+			if err := json.Unmarshal(callMCP(t, srv, "resources/list", map[string]any{}), &list); err != nil {
+				t.Fatal(err)
+			}
+			if len(list.Resources) != 2 {
+				t.Fatalf("got %d resources, want 2", len(list.Resources))
+			}
+			for _, r := range list.Resources {
+				if !reflect.DeepEqual(r.Meta, tt.want[r.URI]) {
+					t.Errorf("resource %s _meta = %#v, want %#v", r.URI, r.Meta, tt.want[r.URI])
+				}
+				if r.URI == "file://a.md" && r.Description == "" {
+					t.Errorf("resource %s description is empty, want frontmatter JSON", r.URI)
+				}
+			}
+
+			for uri, want := range tt.want {
+				var read struct {
+					Meta map[string]any `json:"_meta"`
+				}
+				if err := json.Unmarshal(callMCP(t, srv, "resources/read", map[string]any{"uri": uri}), &read); err != nil {
+					t.Fatal(err)
+				}
+				if !reflect.DeepEqual(read.Meta, want) {
+					t.Errorf("read %s _meta = %#v, want %#v", uri, read.Meta, want)
+				}
+			}
+		})
+	}
+}
+
+func TestWithFrontmatterMeta_brokenFrontmatter(t *testing.T) {
+	testFS := fstest.MapFS{
+		"broken.md": {Data: []byte("---\ntitle: [unclosed\n---\nbody")},
+	}
+	srv, err := newServer("test", "test", testFS, WithFrontmatterMeta(true)).server()
+	if err != nil {
+		t.Fatalf("server() error = %v", err)
+	}
+
+	var list struct {
+		Resources []struct {
+			Meta map[string]any `json:"_meta"`
+		} `json:"resources"`
+	}
+	if err := json.Unmarshal(callMCP(t, srv, "resources/list", map[string]any{}), &list); err != nil {
+		t.Fatal(err)
+	}
+	if len(list.Resources) != 1 || list.Resources[0].Meta["frontmatter_error"] == nil {
+		t.Errorf("resources = %+v, want the frontmatter error in _meta", list.Resources)
+	}
+
+	var read struct {
+		Meta     map[string]any `json:"_meta"`
+		Contents []struct {
+			Text string `json:"text"`
+		} `json:"contents"`
+	}
+	if err := json.Unmarshal(callMCP(t, srv, "resources/read", map[string]any{"uri": "file://broken.md"}), &read); err != nil {
+		t.Fatal(err)
+	}
+	if len(read.Contents) != 1 || read.Contents[0].Text == "" {
+		t.Errorf("read contents = %+v, want the file content", read.Contents)
+	}
+	if read.Meta["frontmatter_error"] == nil {
+		t.Errorf("read _meta = %#v, want the frontmatter error", read.Meta)
+	}
+}
@@ -0,0 +1,35 @@
+package mcpmds
+
+import (
+	"bytes"
+
+	"github.com/goccy/go-yaml"
+)
+
+// frontmatterAsCodeBlock returns content with its frontmatter replaced by a
+// yaml fenced code block holding the given frontmatter, so that plain markdown
+// renderers show the metadata as code. The frontmatter is passed in rather than
+// taken from content so that excluded and redacted keys stay hidden.
+// Content without frontmatter is returned unchanged.
+func (s *Server) frontmatterAsCodeBlock(content []byte, frontmatter map[string]any) ([]byte, error) {
+	_, format, body := s.splitFrontmatter(content)
+	if format == nil {
+		return content, nil
+	}
+	if len(frontmatter) == 0 {
+		return body, nil
+	}
+	metadata, err := yaml.Marshal(frontmatter)
+	if err != nil {
+		return nil, err
+	}
+	var b bytes.Buffer
+	b.WriteString("```yaml\n")
+	b.Write(metadata)
+	if !bytes.HasSuffix(metadata, []byte("\n")) {
+		b.WriteString("\n")
+	}
+	b.WriteString("```\n")
+	b.Write(body)
+	return b.Bytes(), nil
+}
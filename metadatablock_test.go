@@ -0,0 +1,61 @@
+package mcpmds
+
+import (
+	"context"
+	"testing"
+	"testing/fstest"
+)
+
+func Test_server_frontmatterAsCodeBlock(t *testing.T) {
+	testFS := fstest.MapFS{
+		"yaml.md":   {Data: []byte("---\ntitle: YAML\ntags:\n  - a\n---\n# YAML\n")},
+		"toml.md":   {Data: []byte("+++\ntitle = \"TOML\"\ncount = 3\n+++\n# TOML\n")},
+		"plain.md":  {Data: []byte("# Plain\n")},
+		"secret.md": {Data: []byte("---\ntitle: Secret\ntoken: abc\n---\n# Secret\n")},
+	}
+
+	tests := []struct {
+		name string
+		opts []ServerOption
+		path string
+		want string
+	}{
+		{
+			name: "YAML frontmatter",
+			path: "yaml.md",
+			want: "```yaml\ntags:\n- a\ntitle: YAML\n```\n# YAML\n",
+		},
+		{
+			name: "TOML frontmatter",
+			path: "toml.md",
+			want: "```yaml\ncount: 3\ntitle: TOML\n```\n# TOML\n",
+		},
+		{
+			name: "No frontmatter",
+			path: "plain.md",
+			want: "# Plain\n",
+		},
+		{
+			name: "Redacted keys stay redacted",
+			opts: []ServerOption{WithRedactFrontmatter("token")},
+			path: "secret.md",
+			want: "```yaml\ntitle: Secret\ntoken: \"***\"\n```\n# Secret\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Server{fs: testFS}
+			for _, opt := range tt.opts {
+				opt(s)
+			}
+			got, err := s.readMarkdownFile(context.Background(), &readMarkdownFileRequest{Path: tt.path, FrontmatterAsCodeBlock: true})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got.Content != tt.want {
+				t.Errorf("Content = %q, want %q", got.Content, tt.want)
+			}
+		})
+	}
+}
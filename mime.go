@@ -0,0 +1,50 @@
+package mcpmds
+
+import (
+	"path/filepath"
+	"strings"
+)
+
+// markdownMimeType is the MIME type of markdown files, also reported for
+// served files whose extension has no known MIME type.
+const markdownMimeType = "text/markdown"
+
+// defaultMimeTypes maps file extensions to the MIME types reported for them.
+var defaultMimeTypes = map[string]string{
+	".md":       markdownMimeType,
+	".markdown": markdownMimeType,
+	".mdx":      markdownMimeType,
+	".txt":      "text/plain",
+	".csv":      "text/csv",
+}
+
+// WithMimeTypes sets the MIME types reported for served files by extension,
+// overriding the defaults: text/markdown for ".md", ".markdown", and ".mdx",
+// text/plain for ".txt", and text/csv for ".csv". Extensions may be given with
+// or without the leading dot. Files with other extensions are reported as
+// text/markdown.
+func WithMimeTypes(types map[string]string) ServerOption {
+	return func(s *Server) {
+		if s.mimeTypes == nil {
+			s.mimeTypes = map[string]string{}
+		}
+		for ext, typ := range types {
+			if !strings.HasPrefix(ext, ".") {
+				ext = "." + ext
+			}
+			s.mimeTypes[ext] = typ
+		}
+	}
+}
+
+// mimeType returns the MIME type reported for the file at path.
+func (s *Server) mimeType(path string) string {
+	ext := filepath.Ext(path)
+	if typ, ok := s.mimeTypes[ext]; ok {
+		return typ
+	}
+	if typ, ok := defaultMimeTypes[ext]; ok {
+		return typ
+	}
+	return markdownMimeType
+}
@@ -0,0 +1,98 @@
+package mcpmds
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"testing/fstest"
+
+	"github.com/Warashi/go-modelcontextprotocol/mcp"
+)
+
+func TestWithMimeTypes(t *testing.T) {
+	testFS := fstest.MapFS{
+		"a.md":       {Data: []byte("# A")},
+		"b.markdown": {Data: []byte("# B")},
+		"c.mdx":      {Data: []byte("# C")},
+		"d.txt":      {Data: []byte("D")},
+		"e.csv":      {Data: []byte("a,b")},
+		"f.rst":      {Data: []byte("F")},
+	}
+	exts := []string{".md", ".markdown", ".mdx", ".txt", ".csv", ".rst"}
+
+	tests := []struct {
+		name string
+		opts []ServerOption
+		want map[string]string
+	}{
+		{
+			name: "defaults",
+			want: map[string]string{
+				"file://a.md":       "text/markdown",
+				"file://b.markdown": "text/markdown",
+				"file://c.mdx":      "text/markdown",
+				"file://d.txt":      "text/plain",
+				"file://e.csv":      "text/csv",
+				"file://f.rst":      "text/markdown",
+			},
+		},
+		{
+			name: "overridden",
+			opts: []ServerOption{WithMimeTypes(map[string]string{"mdx": "text/mdx", ".rst": "text/x-rst"})},
+			want: map[string]string{
+				"file://a.md":       "text/markdown",
+				"file://b.markdown": "text/markdown",
+				"file://c.mdx":      "text/mdx",
+				"file://d.txt":      "text/plain",
+				"file://e.csv":      "text/csv",
+				"file://f.rst":      "text/x-rst",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := append([]ServerOption{WithFileExtensions(exts...)}, tt.opts...)
+			for _, lazy := range []bool{false, true} {
+				opts := opts
+				if lazy {
+					opts = append(opts, WithLazyResources(true))
+				}
+				s := newServer("test", "test", testFS, opts...)
+				srv, err := s.server()
+				if err != nil {
+					t.Fatalf("server() error = %v", err)
+				}
+				var list struct {
+					Resources []struct {
+						URI      string `json:"uri"`
+						MimeType string `json:"mimeType"`
+					} `json:"resources"`
+				}
+				if err := json.Unmarshal(callMCP(t, srv, "resources/list", map[string]any{}), &list); err != nil {
+					t.Fatal(err)
+				}
+				if len(list.Resources) != len(tt.want) {
+					t.Fatalf("lazy=%v: got %d resources, want %d", lazy, len(list.Resources), len(tt.want))
+				}
+				for _, r := range list.Resources {
+					if r.MimeType != tt.want[r.URI] {
+						t.Errorf("lazy=%v: listed %s MimeType = %q, want %q", lazy, r.URI, r.MimeType, tt.want[r.URI])
+					}
+				}
+			}
+
+			s := newServer("test", "test", testFS, opts...)
+			for uri, want := range tt.want {
+				got, err := s.ReadResource(context.Background(), &mcp.Request[mcp.ReadResourceRequestParams]{
+					Params: mcp.ReadResourceRequestParams{URI: uri},
+				})
+				if err != nil {
+					t.Fatalf("ReadResource(%s) error = %v", uri, err)
+				}
+				if typ := got.Data.Contents[0].(mcp.TextResourceContents).MimeType; typ != want {
+					t.Errorf("read %s MimeType = %q, want %q", uri, typ, want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,239 @@
+package mcpmds
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"log/slog"
+	"maps"
+	"path"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// mount is a filesystem served beneath a path prefix.
+type mount struct {
+	prefix string
+	fsys   fs.FS
+}
+
+// WithMount serves fsys beneath prefix, next to the filesystem the server was
+// created with, so its files are exposed as file://<prefix>/<path> and read
+// from fsys. It may be given several times. When the same path exists in
+// several filesystems, the one registered first wins, the server's own
+// filesystem first of all, and the collision is logged as an error.
+// Watching with WithWatch covers the server's own filesystem only.
+func WithMount(prefix string, fsys fs.FS) ServerOption {
+	return func(s *Server) {
+		s.mounts = append(s.mounts, mount{prefix: strings.Trim(prefix, "/"), fsys: fsys})
+	}
+}
+
+// validateMounts reports an error for mount prefixes that are not valid
+// slash-separated paths.
+func (s *Server) validateMounts() error {
+	for _, m := range s.mounts {
+		if m.prefix == "." || !fs.ValidPath(m.prefix) {
+			return fmt.Errorf("invalid mount prefix %q", m.prefix)
+		}
+	}
+	return nil
+}
+
+// mountFS combines filesystems mounted beneath path prefixes into one.
+// Directories present in several filesystems are merged; for any other path,
+// the first filesystem holding it wins.
+type mountFS struct {
+	// mounts are in registration order, starting with the root filesystem
+	// mounted at "".
+	mounts []mount
+
+	loggedMu sync.Mutex
+	logged   map[string]bool
+}
+
+// newMountFS returns root with the mounts added beneath their prefixes.
+func newMountFS(root fs.FS, mounts []mount) *mountFS {
+	return &mountFS{mounts: slices.Concat([]mount{{fsys: root}}, mounts)}
+}
+
+// rel returns the path within m that name refers to.
+func (m mount) rel(name string) (string, bool) {
+	switch {
+	case m.prefix == "":
+		return name, true
+	case name == m.prefix:
+		return ".", true
+	}
+	rel, ok := strings.CutPrefix(name, m.prefix+"/")
+	return rel, ok
+}
+
+// child returns the entry of the directory name on the way to the prefix of
+// m, if name is a strict ancestor of the prefix.
+func (m mount) child(name string) (string, bool) {
+	if m.prefix == "" {
+		return "", false
+	}
+	rest := m.prefix
+	if name != "." {
+		var ok bool
+		if rest, ok = strings.CutPrefix(m.prefix, name+"/"); !ok {
+			return "", false
+		}
+	}
+	child, _, _ := strings.Cut(rest, "/")
+	return child, true
+}
+
+func (f *mountFS) Open(name string) (fs.File, error) {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrInvalid}
+	}
+	for _, m := range f.mounts {
+		if _, ok := m.child(name); ok {
+			return &mountDir{fsys: f, name: name, info: mountPointInfo(path.Base(name))}, nil
+		}
+		rel, ok := m.rel(name)
+		if !ok {
+			continue
+		}
+		file, err := m.fsys.Open(rel)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		info, err := file.Stat()
+		if err != nil {
+			file.Close()
+			return nil, err
+		}
+		if !info.IsDir() {
+			return file, nil
+		}
+		file.Close()
+		return &mountDir{fsys: f, name: name, info: renamedInfo{FileInfo: info, name: path.Base(name)}}, nil
+	}
+	return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+}
+
+// readDir returns the merged entries of the directory name, sorted by name.
+func (f *mountFS) readDir(name string) ([]fs.DirEntry, error) {
+	entries := map[string]fs.DirEntry{}
+	add := func(e fs.DirEntry) {
+		if prev, ok := entries[e.Name()]; ok {
+			if !prev.IsDir() || !e.IsDir() {
+				f.logCollision(path.Join(name, e.Name()))
+			}
+			return
+		}
+		entries[e.Name()] = e
+	}
+	for _, m := range f.mounts {
+		if child, ok := m.child(name); ok {
+			var info fs.FileInfo = mountPointInfo(child)
+			if path.Join(name, child) == m.prefix {
+				if root, err := fs.Stat(m.fsys, "."); err == nil {
+					info = renamedInfo{FileInfo: root, name: child}
+				}
+			}
+			add(fs.FileInfoToDirEntry(info))
+			continue
+		}
+		rel, ok := m.rel(name)
+		if !ok {
+			continue
+		}
+		list, err := fs.ReadDir(m.fsys, rel)
+		if err != nil {
+			continue
+		}
+		for _, e := range list {
+			add(e)
+		}
+	}
+	result := make([]fs.DirEntry, 0, len(entries))
+	for _, key := range slices.Sorted(maps.Keys(entries)) {
+		result = append(result, entries[key])
+	}
+	return result, nil
+}
+
+// logCollision logs the first collision at each path as an error.
+func (f *mountFS) logCollision(p string) {
+	f.loggedMu.Lock()
+	defer f.loggedMu.Unlock()
+	if f.logged[p] {
+		return
+	}
+	if f.logged == nil {
+		f.logged = map[string]bool{}
+	}
+	f.logged[p] = true
+	slog.Error("path exists in several mounted filesystems; serving the first registered", "path", p)
+}
+
+// mountDir is a directory of a mountFS, listing the merged entries of the
+// mounted filesystems.
+type mountDir struct {
+	fsys    *mountFS
+	name    string
+	info    fs.FileInfo
+	entries []fs.DirEntry
+	read    bool
+}
+
+func (d *mountDir) Stat() (fs.FileInfo, error) { return d.info, nil }
+
+func (d *mountDir) Read([]byte) (int, error) {
+	return 0, &fs.PathError{Op: "read", Path: d.name, Err: errors.New("is a directory")}
+}
+
+func (d *mountDir) Close() error { return nil }
+
+func (d *mountDir) ReadDir(n int) ([]fs.DirEntry, error) {
+	if !d.read {
+		entries, err := d.fsys.readDir(d.name)
+		if err != nil {
+			return nil, err
+		}
+		d.entries, d.read = entries, true
+	}
+	if n <= 0 {
+		entries := d.entries
+		d.entries = nil
+		return entries, nil
+	}
+	if len(d.entries) == 0 {
+		return nil, io.EOF
+	}
+	n = min(n, len(d.entries))
+	entries := d.entries[:n]
+	d.entries = d.entries[n:]
+	return entries, nil
+}
+
+// renamedInfo is file info under the name a directory has in a mountFS, such
+// as the root of a mounted filesystem, named "." within it.
+type renamedInfo struct {
+	fs.FileInfo
+	name string
+}
+
+func (i renamedInfo) Name() string { return i.name }
+
+// mountPointInfo describes a directory that exists only because a filesystem
+// is mounted beneath it.
+type mountPointInfo string
+
+func (i mountPointInfo) Name() string       { return string(i) }
+func (i mountPointInfo) Size() int64        { return 0 }
+func (i mountPointInfo) Mode() fs.FileMode  { return fs.ModeDir | 0o555 }
+func (i mountPointInfo) ModTime() time.Time { return time.Time{} }
+func (i mountPointInfo) IsDir() bool        { return true }
+func (i mountPointInfo) Sys() any           { return nil }
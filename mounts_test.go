@@ -0,0 +1,79 @@
+package mcpmds
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"testing/fstest"
+
+	"github.com/Warashi/go-modelcontextprotocol/mcp"
+)
+
+func TestWithMount(t *testing.T) {
+	root := fstest.MapFS{
+		"index.md":           {Data: []byte("# Home\n")},
+		"public/shadowed.md": {Data: []byte("root wins\n")},
+	}
+	public := fstest.MapFS{
+		"guide.md":    {Data: []byte("---\ntitle: Guide\n---\npublic guide\n")},
+		"shadowed.md": {Data: []byte("public loses\n")},
+	}
+	internal := fstest.MapFS{
+		"runbook.md":    {Data: []byte("internal runbook\n")},
+		"ops/oncall.md": {Data: []byte("on call\n")},
+	}
+	s := newServer("test", "test", root, WithMount("public", public), WithMount("/team/internal/", internal))
+	if _, err := s.server(); err != nil {
+		t.Fatalf("server() error = %v", err)
+	}
+
+	resp, err := s.listMarkdownFiles(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("listMarkdownFiles() error = %v", err)
+	}
+	var paths []string
+	for _, f := range resp.Files {
+		paths = append(paths, f.Path)
+	}
+	want := []string{"index.md", "public/guide.md", "public/shadowed.md", "team/internal/ops/oncall.md", "team/internal/runbook.md"}
+	if !slices.Equal(paths, want) {
+		t.Errorf("listMarkdownFiles() = %v, want %v", paths, want)
+	}
+
+	for uri, want := range map[string]string{
+		"file://public/guide.md":             "---\ntitle: Guide\n---\npublic guide\n",
+		"file://public/shadowed.md":          "root wins\n",
+		"file://team/internal/ops/oncall.md": "on call\n",
+	} {
+		req := &mcp.Request[mcp.ReadResourceRequestParams]{Params: mcp.ReadResourceRequestParams{URI: uri}}
+		got, err := s.ReadResource(context.Background(), req)
+		if err != nil {
+			t.Errorf("ReadResource(%s) error = %v", uri, err)
+			continue
+		}
+		if text := got.Data.Contents[0].(mcp.TextResourceContents).Text; text != want {
+			t.Errorf("ReadResource(%s) = %q, want %q", uri, text, want)
+		}
+	}
+}
+
+func TestWithMount_invalidPrefix(t *testing.T) {
+	for _, prefix := range []string{"", "a/../b"} {
+		if _, err := newServer("test", "test", fstest.MapFS{}, WithMount(prefix, fstest.MapFS{})).server(); err == nil {
+			t.Errorf("WithMount(%q): server() error = nil, want error", prefix)
+		}
+	}
+}
+
+func Test_mountFS(t *testing.T) {
+	fsys := newMountFS(
+		fstest.MapFS{"a.md": {Data: []byte("a")}, "x/b.md": {Data: []byte("b")}},
+		[]mount{
+			{prefix: "x/y", fsys: fstest.MapFS{"c.md": {Data: []byte("c")}}},
+			{prefix: "z", fsys: fstest.MapFS{"d/e.md": {Data: []byte("e")}}},
+		},
+	)
+	if err := fstest.TestFS(fsys, "a.md", "x/b.md", "x/y/c.md", "z/d/e.md"); err != nil {
+		t.Error(err)
+	}
+}
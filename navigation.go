@@ -0,0 +1,151 @@
+package mcpmds
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/Warashi/go-modelcontextprotocol/jsonschema"
+	"github.com/Warashi/go-modelcontextprotocol/mcp"
+	"github.com/goccy/go-yaml"
+)
+
+// WithNavigationFile loads a navigation manifest from the data file at the
+// given path of the filesystem for the navigation tool. The file is JSON,
+// YAML, or TOML, chosen by its extension, and holds a "nav" list of entries,
+// each with an optional "title", an optional "path" to a markdown file, and
+// optional nested "children". The file is re-read on every call.
+func WithNavigationFile(path string) ServerOption {
+	return func(s *Server) {
+		s.navigationFile = path
+	}
+}
+
+// navigationManifest is the content of a navigation file.
+type navigationManifest struct {
+	Nav []navigationEntry `json:"nav" yaml:"nav" toml:"nav"`
+}
+
+// navigationEntry is an entry of a navigation file.
+type navigationEntry struct {
+	Title    string            `json:"title" yaml:"title" toml:"title"`
+	Path     string            `json:"path" yaml:"path" toml:"path"`
+	Children []navigationEntry `json:"children" yaml:"children" toml:"children"`
+}
+
+func (s *Server) navigationTool() mcp.Tool[*navigationRequest, *navigationResponse] {
+	return mcp.NewToolFunc(
+		fmt.Sprintf("navigation_%s", s.name),
+		fmt.Sprintf("Return the navigation structure of the markdown files managed by %s, as ordered by its navigation file", s.name),
+		jsonschema.Object{},
+		s.navigation,
+	)
+}
+
+type navigationRequest struct{}
+
+type navigationResponse struct {
+	// Nav are the top-level navigation entries in order.
+	Nav []navigationNode `json:"nav"`
+	// Unlisted are the served files that no entry points to, in path order.
+	Unlisted []string `json:"unlisted"`
+}
+
+// navigationNode is a navigation entry resolved against the served files.
+type navigationNode struct {
+	// Title is the title given by the entry, or else the title of its file.
+	Title string `json:"title"`
+	// Path is the path of the entry's file, if any.
+	Path string `json:"path,omitempty"`
+	// Missing reports whether the entry points to a file that is not served.
+	Missing bool `json:"missing,omitempty"`
+	// Children are the nested entries in order.
+	Children []navigationNode `json:"children,omitempty"`
+}
+
+func (s *Server) navigation(ctx context.Context, _ *navigationRequest) (*navigationResponse, error) {
+	if s.navigationFile == "" {
+		return nil, errors.New("no navigation file is configured")
+	}
+	manifest, err := s.loadNavigation()
+	if err != nil {
+		return nil, err
+	}
+	files := map[string]markdownFileInfo{}
+	var paths []string
+	for f := range s.markdownFiles(ctx) {
+		files[f.Path] = f
+		paths = append(paths, f.Path)
+	}
+	listed := map[string]bool{}
+	nav, err := s.resolveNavigation(manifest.Nav, files, listed)
+	if err != nil {
+		return nil, err
+	}
+	resp := &navigationResponse{Nav: nav, Unlisted: []string{}}
+	for _, p := range paths {
+		if !listed[p] {
+			resp.Unlisted = append(resp.Unlisted, p)
+		}
+	}
+	return resp, nil
+}
+
+// loadNavigation reads and decodes the navigation file.
+func (s *Server) loadNavigation() (*navigationManifest, error) {
+	content, err := fs.ReadFile(s.fs, s.navigationFile)
+	if err != nil {
+		return nil, fmt.Errorf("read navigation file: %w", err)
+	}
+	var manifest navigationManifest
+	switch ext := strings.ToLower(path.Ext(s.navigationFile)); ext {
+	case ".json":
+		err = json.Unmarshal(content, &manifest)
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(content, &manifest)
+	case ".toml":
+		err = toml.Unmarshal(content, &manifest)
+	default:
+		return nil, fmt.Errorf("unsupported navigation file extension %q: want .json, .yaml, .yml, or .toml", ext)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("parse navigation file %s: %w", s.navigationFile, err)
+	}
+	return &manifest, nil
+}
+
+// resolveNavigation resolves entries against the served files, recording in
+// listed the paths they point to.
+func (s *Server) resolveNavigation(entries []navigationEntry, files map[string]markdownFileInfo, listed map[string]bool) ([]navigationNode, error) {
+	nodes := make([]navigationNode, 0, len(entries))
+	for _, e := range entries {
+		node := navigationNode{Title: strings.TrimSpace(e.Title)}
+		if e.Path != "" {
+			node.Path = path.Clean(strings.TrimPrefix(e.Path, "/"))
+			f, ok := files[node.Path]
+			node.Missing = !ok
+			listed[node.Path] = true
+			if ok && node.Title == "" {
+				title, err := s.documentTitle(f)
+				if err != nil {
+					return nil, err
+				}
+				node.Title = title
+			}
+		}
+		children, err := s.resolveNavigation(e.Children, files, listed)
+		if err != nil {
+			return nil, err
+		}
+		if len(children) > 0 {
+			node.Children = children
+		}
+		nodes = append(nodes, node)
+	}
+	return nodes, nil
+}
@@ -0,0 +1,84 @@
+package mcpmds
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"testing/fstest"
+)
+
+func TestNavigation(t *testing.T) {
+	docs := fstest.MapFS{
+		"intro.md":         {Data: []byte("---\ntitle: Introduction\n---\n")},
+		"guide/install.md": {Data: []byte("# Installing\n")},
+		"guide/usage.md":   {Data: []byte("# Usage\n")},
+		"extra.md":         {Data: []byte("# Extra\n")},
+	}
+	manifests := map[string]string{
+		"nav.yaml": `nav:
+  - path: intro.md
+  - title: Guide
+    children:
+      - path: guide/install.md
+      - title: Using it
+        path: /guide/usage.md
+      - path: guide/gone.md
+`,
+		"nav.json": `{"nav": [{"path": "intro.md"}, {"title": "Guide", "children": [{"path": "guide/install.md"}, {"title": "Using it", "path": "/guide/usage.md"}, {"path": "guide/gone.md"}]}]}`,
+		"nav.toml": `[[nav]]
+path = "intro.md"
+
+[[nav]]
+title = "Guide"
+
+[[nav.children]]
+path = "guide/install.md"
+
+[[nav.children]]
+title = "Using it"
+path = "/guide/usage.md"
+
+[[nav.children]]
+path = "guide/gone.md"
+`,
+	}
+	want := &navigationResponse{
+		Nav: []navigationNode{
+			{Title: "Introduction", Path: "intro.md"},
+			{Title: "Guide", Children: []navigationNode{
+				{Title: "Installing", Path: "guide/install.md"},
+				{Title: "Using it", Path: "guide/usage.md"},
+				{Path: "guide/gone.md", Missing: true},
+			}},
+		},
+		Unlisted: []string{"extra.md"},
+	}
+
+	for name, manifest := range manifests {
+		t.Run(name, func(t *testing.T) {
+			testFS := fstest.MapFS{name: {Data: []byte(manifest)}}
+			for p, f := range docs {
+				testFS[p] = f
+			}
+			s := newServer("test", "test", testFS, WithNavigationFile(name))
+			got, err := s.navigation(context.Background(), &navigationRequest{})
+			if err != nil {
+				t.Fatalf("navigation() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("navigation() = %+v, want %+v", got, want)
+			}
+		})
+	}
+
+	for name, opts := range map[string][]ServerOption{
+		"not configured":        nil,
+		"missing file":          {WithNavigationFile("missing.yaml")},
+		"unsupported extension": {WithNavigationFile("intro.md")},
+	} {
+		s := newServer("test", "test", docs, opts...)
+		if _, err := s.navigation(context.Background(), &navigationRequest{}); err == nil {
+			t.Errorf("%s: navigation() error = nil, want error", name)
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package mcpmds
+
+import (
+	"cmp"
+	"fmt"
+	"io/fs"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/goccy/go-yaml"
+)
+
+// WithNavigationOrder orders markdown files by the order file at the given
+// path of the filesystem, such as "_order.yaml": a YAML or JSON list of file
+// paths. Files are listed, and siblings are found, in the declared order, with
+// the unlisted files after them in path order. An explicit sort_by or
+// order_by takes precedence. The file is re-read on every call.
+func WithNavigationOrder(file string) ServerOption {
+	return func(s *Server) {
+		s.navigationOrderFile = file
+	}
+}
+
+// loadNavigationOrder returns the position of each path declared in the order
+// file. A path listed more than once keeps its first position.
+func (s *Server) loadNavigationOrder() (map[string]int, error) {
+	content, err := fs.ReadFile(s.fs, s.navigationOrderFile)
+	if err != nil {
+		return nil, fmt.Errorf("read navigation order file: %w", err)
+	}
+	var paths []string
+	if err := yaml.Unmarshal(content, &paths); err != nil {
+		return nil, fmt.Errorf("parse navigation order file %s: %w", s.navigationOrderFile, err)
+	}
+	positions := make(map[string]int, len(paths))
+	for i, p := range paths {
+		p = path.Clean(strings.TrimPrefix(strings.TrimSpace(p), "/"))
+		if _, ok := positions[p]; !ok {
+			positions[p] = i
+		}
+	}
+	return positions, nil
+}
+
+// navigationOrder returns a comparison of files by their position in the
+// order file, placing unlisted files last in path order.
+func (s *Server) navigationOrder() (func(a, b markdownFileInfo) int, error) {
+	positions, err := s.loadNavigationOrder()
+	if err != nil {
+		return nil, err
+	}
+	return func(a, b markdownFileInfo) int {
+		x, okX := positions[a.Path]
+		y, okY := positions[b.Path]
+		return cmp.Or(compareMissing(okX, okY), cmp.Compare(x, y), cmp.Compare(a.Path, b.Path))
+	}, nil
+}
+
+// sortByNavigationOrder sorts files by the order file, if WithNavigationOrder
+// is set.
+func (s *Server) sortByNavigationOrder(files []markdownFileInfo) error {
+	if s.navigationOrderFile == "" {
+		return nil
+	}
+	order, err := s.navigationOrder()
+	if err != nil {
+		return err
+	}
+	slices.SortFunc(files, order)
+	return nil
+}
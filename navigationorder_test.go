@@ -0,0 +1,67 @@
+package mcpmds
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"testing/fstest"
+)
+
+func TestWithNavigationOrder(t *testing.T) {
+	testFS := fstest.MapFS{
+		"_order.yaml":       {Data: []byte("- guide/setup.md\n- /index.md\n- guide/intro.md\n- missing.md\n")},
+		"index.md":          {Data: []byte("# Home\n")},
+		"guide/intro.md":    {Data: []byte("---\nweight: 1\n---\n")},
+		"guide/setup.md":    {Data: []byte("---\nweight: 2\n---\n")},
+		"guide/appendix.md": {Data: []byte("---\nweight: 3\n---\n")},
+		"about.md":          {Data: []byte("# About\n")},
+	}
+	s := &Server{fs: testFS}
+	WithNavigationOrder("_order.yaml")(s)
+
+	paths := func(request *listMarkdownFilesRequest) []string {
+		t.Helper()
+		resp, err := s.listMarkdownFiles(context.Background(), request)
+		if err != nil {
+			t.Fatalf("listMarkdownFiles() error = %v", err)
+		}
+		var paths []string
+		for _, f := range resp.Files {
+			paths = append(paths, f.Path)
+		}
+		return paths
+	}
+	want := []string{"guide/setup.md", "index.md", "guide/intro.md", "about.md", "guide/appendix.md"}
+	if got := paths(nil); !slices.Equal(got, want) {
+		t.Errorf("listMarkdownFiles() = %v, want %v", got, want)
+	}
+	want = []string{"about.md", "guide/appendix.md", "guide/intro.md", "guide/setup.md", "index.md"}
+	if got := paths(&listMarkdownFilesRequest{SortBy: "path"}); !slices.Equal(got, want) {
+		t.Errorf("listMarkdownFiles(sort_by=path) = %v, want %v", got, want)
+	}
+
+	siblings, err := s.siblings(context.Background(), &siblingsRequest{Path: "guide/intro.md"})
+	if err != nil {
+		t.Fatalf("siblings() error = %v", err)
+	}
+	if siblings.Previous == nil || siblings.Previous.Path != "guide/setup.md" || siblings.Next == nil || siblings.Next.Path != "guide/appendix.md" {
+		t.Errorf("siblings() = %+v, %+v, want guide/setup.md and guide/appendix.md", siblings.Previous, siblings.Next)
+	}
+	siblings, err = s.siblings(context.Background(), &siblingsRequest{Path: "guide/intro.md", OrderBy: "weight"})
+	if err != nil {
+		t.Fatalf("siblings(order_by=weight) error = %v", err)
+	}
+	if siblings.Previous != nil || siblings.Next == nil || siblings.Next.Path != "guide/setup.md" {
+		t.Errorf("siblings(order_by=weight) = %+v, %+v, want nil and guide/setup.md", siblings.Previous, siblings.Next)
+	}
+}
+
+func TestWithNavigationOrder_invalidFile(t *testing.T) {
+	s := &Server{fs: fstest.MapFS{"a.md": {Data: []byte("a")}, "_order.yaml": {Data: []byte("order: [a.md]\n")}}}
+	for _, file := range []string{"_order.yaml", "missing.yaml"} {
+		WithNavigationOrder(file)(s)
+		if _, err := s.listMarkdownFiles(context.Background(), nil); err == nil {
+			t.Errorf("listMarkdownFiles() with %s: error = nil, want error", file)
+		}
+	}
+}
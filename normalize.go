@@ -0,0 +1,42 @@
+package mcpmds
+
+import "strings"
+
+// WithNormalizeFrontmatterValues normalizes boolean-like strings and null-like
+// strings in returned frontmatter, so that YAML and TOML files look alike to clients.
+// Strings such as "true", "yes", and "on" become true, "false", "no", and "off"
+// become false, and "null" and "~" become null. Matching is case-insensitive and
+// applies to nested maps and lists. Files and filters still see the original values.
+func WithNormalizeFrontmatterValues(enabled bool) ServerOption {
+	return func(s *Server) {
+		s.normalizeFrontmatter = enabled
+	}
+}
+
+// normalizeValue returns v with boolean-like and null-like strings replaced by
+// their canonical values.
+func normalizeValue(v any) any {
+	switch v := v.(type) {
+	case string:
+		switch strings.ToLower(strings.TrimSpace(v)) {
+		case "true", "yes", "on":
+			return true
+		case "false", "no", "off":
+			return false
+		case "null", "~":
+			return nil
+		}
+		return v
+	case map[string]any:
+		for key, value := range v {
+			v[key] = normalizeValue(value)
+		}
+		return v
+	case []any:
+		for i, value := range v {
+			v[i] = normalizeValue(value)
+		}
+		return v
+	}
+	return v
+}
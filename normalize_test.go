@@ -0,0 +1,55 @@
+package mcpmds
+
+import (
+	"reflect"
+	"testing"
+)
+
+func Test_server_normalizeFrontmatter(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    []ServerOption
+		content string
+		want    map[string]any
+	}{
+		{
+			name:    "YAML yes",
+			opts:    []ServerOption{WithNormalizeFrontmatterValues(true)},
+			content: "---\npublished: \"yes\"\narchived: \"No\"\ntitle: yesterday\n---\n",
+			want:    map[string]any{"published": true, "archived": false, "title": "yesterday"},
+		},
+		{
+			name:    "TOML true",
+			opts:    []ServerOption{WithNormalizeFrontmatterValues(true)},
+			content: "+++\npublished = true\nflag = \"TRUE\"\n[nested]\nenabled = \"on\"\n+++\n",
+			want:    map[string]any{"published": true, "flag": true, "nested": map[string]any{"enabled": true}},
+		},
+		{
+			name:    "Explicit null",
+			opts:    []ServerOption{WithNormalizeFrontmatterValues(true)},
+			content: "---\nempty: null\ntilde: ~\nquoted: \"null\"\nlist: [\"~\", \"off\", x]\n---\n",
+			want:    map[string]any{"empty": nil, "tilde": nil, "quoted": nil, "list": []any{nil, false, "x"}},
+		},
+		{
+			name:    "Default off",
+			content: "---\npublished: \"yes\"\nquoted: \"null\"\n---\n",
+			want:    map[string]any{"published": "yes", "quoted": "null"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Server{}
+			for _, opt := range tt.opts {
+				opt(s)
+			}
+			got, err := s.readFrontmatter([]byte(tt.content))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("readFrontmatter() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
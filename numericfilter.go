@@ -0,0 +1,68 @@
+package mcpmds
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Warashi/go-modelcontextprotocol/jsonschema"
+	"github.com/Warashi/go-modelcontextprotocol/mcp"
+)
+
+// numericComparisons are the operators accepted by the numeric filter tool.
+var numericComparisons = map[string]func(a, b float64) bool{
+	"gt":  func(a, b float64) bool { return a > b },
+	"gte": func(a, b float64) bool { return a >= b },
+	"lt":  func(a, b float64) bool { return a < b },
+	"lte": func(a, b float64) bool { return a <= b },
+	"eq":  func(a, b float64) bool { return a == b },
+}
+
+func (s *Server) filterNumericTool() mcp.Tool[*filterNumericRequest, *filterNumericResponse] {
+	return mcp.NewToolFunc(
+		fmt.Sprintf("filter_numeric_%s", s.name),
+		fmt.Sprintf("List markdown files managed by %s whose numeric frontmatter field compares to a value, such as weight > 5", s.name),
+		jsonschema.Object{
+			Properties: map[string]jsonschema.Schema{
+				"field": jsonschema.String{
+					Description: "The frontmatter key holding the number to compare",
+				},
+				"op": jsonschema.String{
+					Description: "The comparison operator: gt, gte, lt, lte, or eq",
+				},
+				"value": jsonschema.Number{
+					Description: "The number to compare the field against",
+				},
+			},
+			Required: []string{"field", "op", "value"},
+		},
+		s.filterNumeric,
+	)
+}
+
+type filterNumericRequest struct {
+	Field string  `json:"field" jsonschema:"required"`
+	Op    string  `json:"op" jsonschema:"required"`
+	Value float64 `json:"value" jsonschema:"required"`
+}
+
+type filterNumericResponse struct {
+	Files []markdownFileInfo `json:"files"`
+}
+
+// filterNumeric returns the files whose frontmatter field, read as a number,
+// satisfies the comparison. Files where the field is missing or not numeric
+// are excluded.
+func (s *Server) filterNumeric(ctx context.Context, request *filterNumericRequest) (*filterNumericResponse, error) {
+	compare, ok := numericComparisons[request.Op]
+	if !ok {
+		return nil, fmt.Errorf("unknown op %q: want gt, gte, lt, lte, or eq", request.Op)
+	}
+	files := []markdownFileInfo{}
+	for f := range s.markdownFiles(ctx) {
+		n, ok := frontmatterNumber(f.Frontmatter[request.Field])
+		if ok && compare(n, request.Value) {
+			files = append(files, f)
+		}
+	}
+	return &filterNumericResponse{Files: files}, nil
+}
@@ -0,0 +1,60 @@
+package mcpmds
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"testing/fstest"
+)
+
+func Test_server_filterNumeric(t *testing.T) {
+	testFS := fstest.MapFS{
+		"yaml_int.md":   {Data: []byte("---\nweight: 5\n---\n")},
+		"yaml_float.md": {Data: []byte("---\nweight: 2.5\n---\n")},
+		"yaml_neg.md":   {Data: []byte("---\nweight: -3\n---\n")},
+		"toml_int.md":   {Data: []byte("+++\nweight = 10\n+++\n")},
+		"json_float.md": {Data: []byte(";;;\n{\"weight\": 5.0}\n;;;\n")},
+		"text.md":       {Data: []byte("---\nweight: heavy\n---\n")},
+		"bool.md":       {Data: []byte("---\nweight: true\n---\n")},
+		"missing.md":    {Data: []byte("---\ntitle: None\n---\n")},
+		"plain.md":      {Data: []byte("no frontmatter")},
+	}
+	s := &Server{fs: testFS}
+
+	tests := []struct {
+		name    string
+		op      string
+		value   float64
+		want    []string
+		wantErr bool
+	}{
+		{name: "gt", op: "gt", value: 5, want: []string{"toml_int.md"}},
+		{name: "gte", op: "gte", value: 5, want: []string{"json_float.md", "toml_int.md", "yaml_int.md"}},
+		{name: "lt", op: "lt", value: 5, want: []string{"yaml_float.md", "yaml_neg.md"}},
+		{name: "lte", op: "lte", value: 2.5, want: []string{"yaml_float.md", "yaml_neg.md"}},
+		{name: "eq", op: "eq", value: 5, want: []string{"json_float.md", "yaml_int.md"}},
+		{name: "eq negative", op: "eq", value: -3, want: []string{"yaml_neg.md"}},
+		{name: "unknown op", op: "ne", value: 5, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := s.filterNumeric(context.Background(), &filterNumericRequest{Field: "weight", Op: tt.op, Value: tt.value})
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("filterNumeric() succeeded, want error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("filterNumeric() error = %v", err)
+			}
+			got := []string{}
+			for _, f := range resp.Files {
+				got = append(got, f.Path)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("filterNumeric() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,134 @@
+package mcpmds
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// heading is a markdown heading.
+type heading struct {
+	// Level is the heading level from 1 to 6.
+	Level int `json:"level"`
+	// Text is the heading text without markers.
+	Text string `json:"text"`
+	// Line is the 1-based line number of the heading within the body.
+	Line int `json:"line"`
+	// Anchor is the GitHub-style anchor slug of the heading, unique within the
+	// document. It is set only by withAnchors.
+	Anchor string `json:"anchor,omitempty"`
+}
+
+var (
+	atxHeadingPattern    = regexp.MustCompile(`^ {0,3}(#{1,6})(?:[ \t]+(.*?))?(?:[ \t]+#+)?[ \t]*$`)
+	setextHeadingPattern = regexp.MustCompile(`^ {0,3}(=+|-+)[ \t]*$`)
+)
+
+// scanHeadings returns the ATX and setext headings in body, skipping fenced code blocks.
+func scanHeadings(body []byte) []heading {
+	var headings []heading
+	var prev markdownLine
+	prevIsText := false
+	for line := range markdownLines(body) {
+		if line.InCode {
+			prevIsText = false
+			continue
+		}
+		if m := atxHeadingPattern.FindStringSubmatch(line.Text); m != nil {
+			headings = append(headings, heading{Level: len(m[1]), Text: strings.TrimSpace(m[2]), Line: line.Number})
+			prevIsText = false
+			continue
+		}
+		if m := setextHeadingPattern.FindStringSubmatch(line.Text); m != nil && prevIsText {
+			level := 1
+			if m[1][0] == '-' {
+				level = 2
+			}
+			headings = append(headings, heading{Level: level, Text: strings.TrimSpace(prev.Text), Line: prev.Number})
+			prevIsText = false
+			continue
+		}
+		prev = line
+		prevIsText = strings.TrimSpace(line.Text) != "" && !isBlockMarker(line.Text)
+	}
+	return headings
+}
+
+// isBlockMarker reports whether line starts a list item, block quote, or
+// table row, none of which can be underlined to form a setext heading.
+func isBlockMarker(line string) bool {
+	trimmed := strings.TrimLeft(line, " ")
+	return strings.HasPrefix(trimmed, "- ") ||
+		strings.HasPrefix(trimmed, "* ") ||
+		strings.HasPrefix(trimmed, "+ ") ||
+		strings.HasPrefix(trimmed, ">") ||
+		strings.HasPrefix(trimmed, "|")
+}
+
+// flattenHeadings returns body with headings deeper than maxDepth replaced by
+// bold paragraphs. Setext underlines of flattened headings are removed.
+func flattenHeadings(body []byte, maxDepth int) []byte {
+	lines := strings.Split(string(body), "\n")
+	drop := map[int]bool{}
+	for _, h := range scanHeadings(body) {
+		if h.Level <= maxDepth {
+			continue
+		}
+		i := h.Line - 1
+		text, cr := strings.CutSuffix(lines[i], "\r")
+		if !atxHeadingPattern.MatchString(text) {
+			drop[i+1] = true
+		}
+		lines[i] = ""
+		if h.Text != "" {
+			lines[i] = "**" + h.Text + "**"
+		}
+		if cr {
+			lines[i] += "\r"
+		}
+	}
+	kept := lines[:0]
+	for i, line := range lines {
+		if !drop[i] {
+			kept = append(kept, line)
+		}
+	}
+	return []byte(strings.Join(kept, "\n"))
+}
+
+// headingAnchor returns the GitHub-style anchor slug for a heading text:
+// lowercased, with spaces turned into hyphens and punctuation dropped.
+func headingAnchor(text string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(strings.TrimSpace(text)) {
+		switch {
+		case unicode.IsLetter(r), unicode.IsDigit(r), r == '-', r == '_':
+			b.WriteRune(r)
+		case r == ' ':
+			b.WriteByte('-')
+		}
+	}
+	return b.String()
+}
+
+// withAnchors sets unique anchors on headings and returns them. Like GitHub,
+// repeated slugs are suffixed with "-1", "-2", and so on, skipping suffixes
+// that would collide with an anchor already taken.
+func withAnchors(headings []heading) []heading {
+	seen := map[string]int{}
+	for i, h := range headings {
+		slug := headingAnchor(h.Text)
+		anchor := slug
+		for {
+			if _, ok := seen[anchor]; !ok {
+				break
+			}
+			seen[slug]++
+			anchor = fmt.Sprintf("%s-%d", slug, seen[slug])
+		}
+		seen[anchor] = 0
+		headings[i].Anchor = anchor
+	}
+	return headings
+}
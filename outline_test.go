@@ -0,0 +1,108 @@
+package mcpmds
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"testing/fstest"
+)
+
+func Test_scanHeadings(t *testing.T) {
+	body := []byte("# Title\n\nSetext\n======\n\n## Section ##\n\n```\n# not a heading\n```\n\n- item\n---\n\nSub\n---\n#hashtag\n")
+	want := []heading{
+		{Level: 1, Text: "Title", Line: 1},
+		{Level: 1, Text: "Setext", Line: 3},
+		{Level: 2, Text: "Section", Line: 6},
+		{Level: 2, Text: "Sub", Line: 15},
+	}
+	if got := scanHeadings(body); !reflect.DeepEqual(got, want) {
+		t.Errorf("scanHeadings() = %#v, want %#v", got, want)
+	}
+}
+
+func Test_server_readMarkdownFile_maxHeadingDepth(t *testing.T) {
+	testFS := fstest.MapFS{
+		"a.md": {Data: []byte("---\ntitle: A\n---\n# Title\n\n## Section\n\n#### Deep\n\n##### Deeper #####\n\nSetext\n------\n\n```\n#### code\n```\n")},
+	}
+	s := &Server{fs: testFS}
+
+	tests := []struct {
+		name  string
+		depth int
+		want  string
+	}{
+		{
+			name:  "Deep headings flattened",
+			depth: 3,
+			want:  "---\ntitle: A\n---\n# Title\n\n## Section\n\n**Deep**\n\n**Deeper**\n\nSetext\n------\n\n```\n#### code\n```\n",
+		},
+		{
+			name:  "Setext headings flattened",
+			depth: 1,
+			want:  "---\ntitle: A\n---\n# Title\n\n**Section**\n\n**Deep**\n\n**Deeper**\n\n**Setext**\n\n```\n#### code\n```\n",
+		},
+		{
+			name:  "Unchanged by default",
+			depth: 0,
+			want:  string(testFS["a.md"].Data),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := s.readMarkdownFile(context.Background(), &readMarkdownFileRequest{Path: "a.md", MaxHeadingDepth: tt.depth})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got.Content != tt.want {
+				t.Errorf("Content = %q, want %q", got.Content, tt.want)
+			}
+		})
+	}
+	if string(testFS["a.md"].Data) != "---\ntitle: A\n---\n# Title\n\n## Section\n\n#### Deep\n\n##### Deeper #####\n\nSetext\n------\n\n```\n#### code\n```\n" {
+		t.Error("the file was modified")
+	}
+}
+
+func Test_withAnchors(t *testing.T) {
+	headings := []heading{{Text: "a"}, {Text: "a-1"}, {Text: "a"}, {Text: "a"}}
+	var got []string
+	for _, h := range withAnchors(headings) {
+		got = append(got, h.Anchor)
+	}
+	if want := []string{"a", "a-1", "a-2", "a-3"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("withAnchors() anchors = %v, want %v", got, want)
+	}
+}
+
+func Test_server_readMarkdownFile_includeOutline(t *testing.T) {
+	testFS := fstest.MapFS{
+		"a.md": {Data: []byte("---\ntitle: A\n---\n# Guide\n\n## Install\n\n### On Linux\n\n## Install\n\nUsage Notes\n-----------\n\n## Install\n\n```\n## Not a heading\n```\n\n# What's New?\n")},
+	}
+	s := &Server{fs: testFS}
+
+	got, err := s.readMarkdownFile(context.Background(), &readMarkdownFileRequest{Path: "a.md", IncludeOutline: true})
+	if err != nil {
+		t.Fatalf("readMarkdownFile() error = %v", err)
+	}
+	want := []heading{
+		{Level: 1, Text: "Guide", Line: 1, Anchor: "guide"},
+		{Level: 2, Text: "Install", Line: 3, Anchor: "install"},
+		{Level: 3, Text: "On Linux", Line: 5, Anchor: "on-linux"},
+		{Level: 2, Text: "Install", Line: 7, Anchor: "install-1"},
+		{Level: 2, Text: "Usage Notes", Line: 9, Anchor: "usage-notes"},
+		{Level: 2, Text: "Install", Line: 12, Anchor: "install-2"},
+		{Level: 1, Text: "What's New?", Line: 18, Anchor: "whats-new"},
+	}
+	if !reflect.DeepEqual(got.Outline, want) {
+		t.Errorf("Outline = %+v, want %+v", got.Outline, want)
+	}
+
+	got, err = s.readMarkdownFile(context.Background(), &readMarkdownFileRequest{Path: "a.md"})
+	if err != nil {
+		t.Fatalf("readMarkdownFile() error = %v", err)
+	}
+	if got.Outline != nil {
+		t.Errorf("Outline = %+v without include_outline, want nil", got.Outline)
+	}
+}
@@ -0,0 +1,84 @@
+package mcpmds
+
+import (
+	"io/fs"
+	"slices"
+	"time"
+)
+
+// WithOutlineCache caches the headings parsed from each markdown file, keyed
+// by its path and modification time, so that tools listing headings or titles
+// do not re-read and re-parse unchanged files. A file is parsed again when
+// its modification time or size changes.
+func WithOutlineCache(enabled bool) ServerOption {
+	return func(s *Server) {
+		s.outlineCache = enabled
+	}
+}
+
+// cachedOutline is the parsed headings of a markdown file.
+type cachedOutline struct {
+	modTime  time.Time
+	size     int64
+	headings []heading
+}
+
+// fileHeadings returns the headings in the body of the markdown file at path,
+// from the outline cache when enabled and the file is unchanged.
+func (s *Server) fileHeadings(path string) ([]heading, error) {
+	if !s.outlineCache {
+		content, err := s.readMarkdown(path)
+		if err != nil {
+			return nil, err
+		}
+		_, _, body := s.splitFrontmatter(content)
+		return scanHeadings(body), nil
+	}
+	if err := s.checkServed(path); err != nil {
+		return nil, err
+	}
+	info, err := fs.Stat(s.fs, path)
+	if err != nil {
+		return nil, err
+	}
+	if headings, ok := s.cachedHeadings(path, info); ok {
+		return headings, nil
+	}
+	content, err := s.readMarkdown(path)
+	if err != nil {
+		return nil, err
+	}
+	_, _, body := s.splitFrontmatter(content)
+	return s.cacheHeadings(path, info, body), nil
+}
+
+// cachedHeadings returns a copy of the cached headings of the file at path if
+// the cache entry matches info.
+func (s *Server) cachedHeadings(path string, info fs.FileInfo) ([]heading, bool) {
+	s.outlineMu.Lock()
+	defer s.outlineMu.Unlock()
+	entry, ok := s.outlines[path]
+	if !ok || !entry.modTime.Equal(info.ModTime()) || entry.size != info.Size() {
+		return nil, false
+	}
+	return slices.Clone(entry.headings), true
+}
+
+// cacheHeadings parses the headings in body, the body of the file at path
+// described by info, and caches them when the outline cache is enabled.
+func (s *Server) cacheHeadings(path string, info fs.FileInfo, body []byte) []heading {
+	if !s.outlineCache {
+		return scanHeadings(body)
+	}
+	if headings, ok := s.cachedHeadings(path, info); ok {
+		return headings
+	}
+	headings := scanHeadings(body)
+	s.outlineMu.Lock()
+	defer s.outlineMu.Unlock()
+	if s.outlines == nil {
+		s.outlines = map[string]cachedOutline{}
+	}
+	s.outlines[path] = cachedOutline{modTime: info.ModTime(), size: info.Size(), headings: slices.Clone(headings)}
+	return headings
+}
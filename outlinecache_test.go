@@ -0,0 +1,93 @@
+package mcpmds
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"testing/fstest"
+	"time"
+)
+
+func TestWithOutlineCache(t *testing.T) {
+	modTime := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	testFS := fstest.MapFS{
+		"a.md": {Data: []byte("# Old\n"), ModTime: modTime},
+	}
+	s := &Server{fs: testFS}
+	WithOutlineCache(true)(s)
+
+	title := func() string {
+		t.Helper()
+		got, err := s.documentTitle(markdownFileInfo{Path: "a.md"})
+		if err != nil {
+			t.Fatalf("documentTitle() error = %v", err)
+		}
+		return got
+	}
+	if got := title(); got != "Old" {
+		t.Fatalf("title = %q, want Old", got)
+	}
+
+	// Same size and modification time: the cached outline is served.
+	testFS["a.md"].Data = []byte("# New\n")
+	if got := title(); got != "Old" {
+		t.Errorf("title after unstamped edit = %q, want cached Old", got)
+	}
+
+	testFS["a.md"].ModTime = modTime.Add(time.Second)
+	if got := title(); got != "New" {
+		t.Errorf("title after modtime change = %q, want New", got)
+	}
+
+	resp, err := s.readMarkdownFile(context.Background(), &readMarkdownFileRequest{Path: "a.md", IncludeOutline: true})
+	if err != nil {
+		t.Fatalf("readMarkdownFile() error = %v", err)
+	}
+	if len(resp.Outline) != 1 || resp.Outline[0].Anchor != "new" {
+		t.Errorf("Outline = %+v, want New with anchor", resp.Outline)
+	}
+	// Anchors set on a returned outline must not leak into the cache.
+	if cached := s.outlines["a.md"].headings; cached[0].Anchor != "" {
+		t.Errorf("cached heading anchor = %q, want empty", cached[0].Anchor)
+	}
+
+	t.Run("Disabled", func(t *testing.T) {
+		s := &Server{fs: testFS}
+		if _, err := s.documentTitle(markdownFileInfo{Path: "a.md"}); err != nil {
+			t.Fatalf("documentTitle() error = %v", err)
+		}
+		if s.outlines != nil {
+			t.Errorf("outlines = %v without WithOutlineCache, want nil", s.outlines)
+		}
+	})
+}
+
+func BenchmarkFileHeadings(b *testing.B) {
+	testFS := fstest.MapFS{}
+	body := "# Title\n\n" + strings.Repeat("Some body text.\n\n## Section\n\nMore text.\n\n```\n# code\n```\n\n", 20)
+	var paths []string
+	for i := range 200 {
+		p := fmt.Sprintf("dir%d/file%d.md", i%10, i)
+		testFS[p] = &fstest.MapFile{Data: []byte(body)}
+		paths = append(paths, p)
+	}
+	for _, bm := range []struct {
+		name string
+		opts []ServerOption
+	}{
+		{name: "uncached"},
+		{name: "cached", opts: []ServerOption{WithOutlineCache(true)}},
+	} {
+		b.Run(bm.name, func(b *testing.B) {
+			s := newServer("bench", "bench", testFS, bm.opts...)
+			for b.Loop() {
+				for _, p := range paths {
+					if _, err := s.fileHeadings(p); err != nil {
+						b.Fatal(err)
+					}
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,88 @@
+package mcpmds
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/Warashi/go-modelcontextprotocol/mcp"
+)
+
+// permalinkIndexURI is the URI of the synthetic permalink index resource.
+const permalinkIndexURI = "file://_permalinks.json"
+
+// WithPermalinkIndex registers a synthetic resource, file://_permalinks.json,
+// holding a JSON index from each file's frontmatter "permalink", or else its
+// "slug", to the file's path, so clients can resolve public URLs to source
+// documents with one read. Values claimed by several files are listed as
+// collisions and map to the first file in path order. The index is generated
+// when the resource is read.
+func WithPermalinkIndex(enabled bool) ServerOption {
+	return func(s *Server) {
+		s.permalinkIndex = enabled
+	}
+}
+
+// permalinkIndexResource is the resource entry of the permalink index.
+func permalinkIndexResource() mcp.Resource {
+	return mcp.Resource{
+		URI:         permalinkIndexURI,
+		Name:        "_permalinks.json",
+		Description: "A JSON index from frontmatter permalinks and slugs to the paths of the markdown files",
+		MimeType:    "application/json",
+	}
+}
+
+type permalinkIndex struct {
+	// Permalinks maps each permalink or slug to the path of its file.
+	Permalinks map[string]string `json:"permalinks"`
+	// Collisions are the permalinks or slugs claimed by more than one file.
+	Collisions []permalinkCollision `json:"collisions"`
+}
+
+type permalinkCollision struct {
+	Permalink string   `json:"permalink"`
+	Paths     []string `json:"paths"`
+}
+
+// filePermalink returns the frontmatter permalink of a file, or else its slug.
+func filePermalink(frontmatter map[string]any) string {
+	for _, key := range []string{"permalink", "slug"} {
+		if v, ok := frontmatter[key].(string); ok && strings.TrimSpace(v) != "" {
+			return strings.TrimSpace(v)
+		}
+	}
+	return ""
+}
+
+// renderPermalinkIndex returns the permalink index of the served markdown files.
+func (s *Server) renderPermalinkIndex(ctx context.Context) ([]byte, error) {
+	files, err := s.collectMarkdownFiles(ctx)
+	if err != nil {
+		return nil, err
+	}
+	index := permalinkIndex{Permalinks: map[string]string{}, Collisions: []permalinkCollision{}}
+	paths := map[string][]string{}
+	var order []string
+	for _, f := range files {
+		permalink := filePermalink(f.Frontmatter)
+		if permalink == "" {
+			continue
+		}
+		if _, ok := paths[permalink]; !ok {
+			index.Permalinks[permalink] = f.Path
+			order = append(order, permalink)
+		}
+		paths[permalink] = append(paths[permalink], f.Path)
+	}
+	for _, permalink := range order {
+		if len(paths[permalink]) > 1 {
+			index.Collisions = append(index.Collisions, permalinkCollision{Permalink: permalink, Paths: paths[permalink]})
+		}
+	}
+	b, err := json.MarshalIndent(index, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(b, '\n'), nil
+}
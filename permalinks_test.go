@@ -0,0 +1,60 @@
+package mcpmds
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+	"testing/fstest"
+
+	"github.com/Warashi/go-modelcontextprotocol/mcp"
+)
+
+func Test_server_permalinkIndex(t *testing.T) {
+	testFS := fstest.MapFS{
+		"about.md":       {Data: []byte("---\npermalink: /about/\nslug: ignored\n---\n")},
+		"blog/hello.md":  {Data: []byte("---\nslug: hello\n---\n")},
+		"blog/hello2.md": {Data: []byte("---\nslug: hello\n---\n")},
+		"draft.md":       {Data: []byte("no frontmatter\n")},
+	}
+
+	s := &Server{fs: testFS}
+	WithPermalinkIndex(true)(s)
+
+	resources, err := s.resourceList()
+	if err != nil {
+		t.Fatalf("resourceList() error = %v", err)
+	}
+	if last := resources[len(resources)-1]; last.URI != permalinkIndexURI {
+		t.Errorf("last resource = %s, want %s", last.URI, permalinkIndexURI)
+	}
+
+	req := &mcp.Request[mcp.ReadResourceRequestParams]{Params: mcp.ReadResourceRequestParams{URI: permalinkIndexURI}}
+	got, err := s.ReadResource(context.Background(), req)
+	if err != nil {
+		t.Fatalf("ReadResource() error = %v", err)
+	}
+	contents := got.Data.Contents[0].(mcp.TextResourceContents)
+	if contents.MimeType != "application/json" {
+		t.Errorf("MimeType = %q, want application/json", contents.MimeType)
+	}
+	var index permalinkIndex
+	if err := json.Unmarshal([]byte(contents.Text), &index); err != nil {
+		t.Fatalf("unmarshal permalink index: %v", err)
+	}
+	want := permalinkIndex{
+		Permalinks: map[string]string{"/about/": "about.md", "hello": "blog/hello.md"},
+		Collisions: []permalinkCollision{{Permalink: "hello", Paths: []string{"blog/hello.md", "blog/hello2.md"}}},
+	}
+	if !reflect.DeepEqual(index, want) {
+		t.Errorf("permalink index = %+v, want %+v", index, want)
+	}
+}
+
+func Test_server_permalinkIndex_disabled(t *testing.T) {
+	s := &Server{fs: fstest.MapFS{"a.md": {Data: []byte("a")}}}
+	req := &mcp.Request[mcp.ReadResourceRequestParams]{Params: mcp.ReadResourceRequestParams{URI: permalinkIndexURI}}
+	if _, err := s.ReadResource(context.Background(), req); err == nil {
+		t.Error("ReadResource() error = nil, want error")
+	}
+}
@@ -0,0 +1,71 @@
+package mcpmds
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Warashi/go-modelcontextprotocol/jsonschema"
+	"github.com/Warashi/go-modelcontextprotocol/mcp"
+)
+
+// promptExcerptLength is the maximum length in characters of the body excerpt
+// in a prompt context block.
+const promptExcerptLength = 300
+
+func (s *Server) promptContextTool() mcp.Tool[*promptContextRequest, string] {
+	return mcp.NewToolFunc(
+		fmt.Sprintf("prompt_context_%s", s.name),
+		fmt.Sprintf("Summarize a markdown file managed by %s as a compact text block of its title, tags, description, and a body excerpt, ready to insert into a prompt", s.name),
+		jsonschema.Object{
+			Properties: map[string]jsonschema.Schema{
+				"path": jsonschema.String{
+					Description: "The path to the markdown file",
+				},
+			},
+			Required: []string{"path"},
+		},
+		s.promptContext,
+	)
+}
+
+type promptContextRequest struct {
+	Path string `json:"path" jsonschema:"required"`
+}
+
+func (s *Server) promptContext(ctx context.Context, request *promptContextRequest) (string, error) {
+	path, content, err := s.readMarkdownOrAlias(ctx, request.Path)
+	if err != nil {
+		return "", err
+	}
+	frontmatter, err := s.readFrontmatter(content)
+	if err != nil {
+		return "", err
+	}
+	title, err := s.documentTitle(markdownFileInfo{Path: path, Frontmatter: frontmatter})
+	if err != nil {
+		return "", err
+	}
+	_, _, body := s.splitFrontmatter(content)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "<document path=%q>\n", path)
+	if title != "" {
+		fmt.Fprintf(&b, "Title: %s\n", title)
+	}
+	if tags := s.frontmatterTags(frontmatter); len(tags) > 0 {
+		fmt.Fprintf(&b, "Tags: %s\n", strings.Join(tags, ", "))
+	}
+	for _, key := range excerptKeys {
+		if text, ok := frontmatter[key].(string); ok && strings.TrimSpace(text) != "" {
+			fmt.Fprintf(&b, "Description: %s\n", strings.Join(strings.Fields(text), " "))
+			break
+		}
+	}
+	if text := truncateText(firstParagraph(body), promptExcerptLength); text != "" {
+		fmt.Fprintf(&b, "Excerpt: %s\n", text)
+	}
+	b.WriteString("</document>\n")
+	s.recordRead(path)
+	return b.String(), nil
+}
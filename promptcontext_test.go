@@ -0,0 +1,70 @@
+package mcpmds
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func Test_server_promptContext(t *testing.T) {
+	testFS := fstest.MapFS{
+		"full.md":  {Data: []byte("---\ntitle: Deploying\ntags: [ops, release]\ndescription: How to ship\n  a release.\ntoken: abc\n---\n# Deploying\n\nRun the pipeline\nand wait.\n\nMore text.\n")},
+		"plain.md": {Data: []byte("# Plain Page\n\nJust a body.\n")},
+		"long.md":  {Data: []byte(strings.Repeat("word ", 100))},
+	}
+
+	tests := []struct {
+		name string
+		path string
+		want string
+	}{
+		{
+			name: "All fields",
+			path: "full.md",
+			want: "<document path=\"full.md\">\nTitle: Deploying\nTags: ops, release\nDescription: How to ship a release.\nExcerpt: Run the pipeline and wait.\n</document>\n",
+		},
+		{
+			name: "Title from heading and no frontmatter",
+			path: "plain.md",
+			want: "<document path=\"plain.md\">\nTitle: Plain Page\nExcerpt: Just a body.\n</document>\n",
+		},
+		{
+			name: "Long excerpt is truncated",
+			path: "long.md",
+			want: "<document path=\"long.md\">\nExcerpt: " + strings.TrimSpace(strings.Repeat("word ", 59)) + "…\n</document>\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Server{fs: testFS}
+			got, err := s.promptContext(context.Background(), &promptContextRequest{Path: tt.path})
+			if err != nil {
+				t.Fatalf("promptContext() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("promptContext()\n got = %q,\nwant = %q", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("Excluded keys are omitted", func(t *testing.T) {
+		s := &Server{fs: testFS}
+		WithExcludeFrontmatter("tags")(s)
+		got, err := s.promptContext(context.Background(), &promptContextRequest{Path: "full.md"})
+		if err != nil {
+			t.Fatalf("promptContext() error = %v", err)
+		}
+		if strings.Contains(got, "Tags:") {
+			t.Errorf("promptContext() = %q, want no tags", got)
+		}
+	})
+
+	t.Run("Missing file", func(t *testing.T) {
+		s := &Server{fs: testFS}
+		if _, err := s.promptContext(context.Background(), &promptContextRequest{Path: "nope.md"}); err == nil {
+			t.Error("promptContext() error = nil, want an error")
+		}
+	})
+}
@@ -0,0 +1,107 @@
+package mcpmds
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"text/template"
+
+	"github.com/Warashi/go-modelcontextprotocol/mcp"
+)
+
+// summarizeTemplate renders the message of the summarize prompt.
+var summarizeTemplate = template.Must(template.New("summarize").Parse(`Please write a concise summary of the markdown file {{.Path}}, covering its main points in a few sentences or bullet points.
+
+<file path="{{.Path}}">
+{{.Content}}
+</file>
+`))
+
+// prompt is a prompt template offered to clients by prompts/list.
+type prompt struct {
+	Name        string           `json:"name"`
+	Description string           `json:"description,omitempty"`
+	Arguments   []promptArgument `json:"arguments,omitempty"`
+}
+
+// promptArgument is an argument accepted by a prompt.
+type promptArgument struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Required    bool   `json:"required,omitempty"`
+}
+
+type listPromptsRequestParams struct {
+	Cursor string `json:"cursor"`
+}
+
+type listPromptsResultData struct {
+	Prompts []prompt `json:"prompts"`
+}
+
+type getPromptRequestParams struct {
+	Name      string            `json:"name"`
+	Arguments map[string]string `json:"arguments"`
+}
+
+type getPromptResultData struct {
+	Description string          `json:"description,omitempty"`
+	Messages    []promptMessage `json:"messages"`
+}
+
+// promptMessage is a message of a rendered prompt.
+type promptMessage struct {
+	Role    string          `json:"role"`
+	Content mcp.TextContent `json:"content"`
+}
+
+// summarizePrompt returns the prompt asking for a summary of a markdown file.
+func (s *Server) summarizePrompt() prompt {
+	return prompt{
+		Name:        fmt.Sprintf("summarize_%s_markdown", s.name),
+		Description: fmt.Sprintf("Summarize a markdown file managed by %s", s.name),
+		Arguments: []promptArgument{
+			{Name: "path", Description: "The path to the markdown file", Required: true},
+		},
+	}
+}
+
+// promptsOption returns the option serving the server's prompts with the
+// prompts/list and prompts/get methods.
+func (s *Server) promptsOption() mcp.ServerOption {
+	return func(srv *mcp.Server) {
+		mcp.WithCustomHandlerFunc("prompts/list", func(ctx context.Context, _ *mcp.Request[listPromptsRequestParams]) (*mcp.Result[listPromptsResultData], error) {
+			return &mcp.Result[listPromptsResultData]{Data: listPromptsResultData{Prompts: []prompt{s.summarizePrompt()}}}, nil
+		})(srv)
+		mcp.WithCustomHandlerFunc("prompts/get", func(ctx context.Context, request *mcp.Request[getPromptRequestParams]) (*mcp.Result[getPromptResultData], error) {
+			data, err := s.getPrompt(request.Params)
+			if err != nil {
+				return nil, err
+			}
+			return &mcp.Result[getPromptResultData]{Data: *data}, nil
+		})(srv)
+	}
+}
+
+// getPrompt renders the prompt named in params with its arguments.
+func (s *Server) getPrompt(params getPromptRequestParams) (*getPromptResultData, error) {
+	p := s.summarizePrompt()
+	if params.Name != p.Name {
+		return nil, fmt.Errorf("unknown prompt %q", params.Name)
+	}
+	if params.Arguments["path"] == "" {
+		return nil, fmt.Errorf("prompt %s requires the path argument", p.Name)
+	}
+	path, content, err := s.readRequestedMarkdown(params.Arguments["path"])
+	if err != nil {
+		return nil, err
+	}
+	var b strings.Builder
+	if err := summarizeTemplate.Execute(&b, struct{ Path, Content string }{path, strings.TrimRight(string(content), "\n")}); err != nil {
+		return nil, err
+	}
+	return &getPromptResultData{
+		Description: fmt.Sprintf("Summarize %s", path),
+		Messages:    []promptMessage{{Role: "user", Content: mcp.TextContent{Text: b.String()}}},
+	}, nil
+}
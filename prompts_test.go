@@ -0,0 +1,78 @@
+package mcpmds
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func Test_server_summarizePrompt(t *testing.T) {
+	testFS := fstest.MapFS{
+		"docs/intro.md": {Data: []byte("---\ntitle: Intro\n---\n# Intro\n\nThe project in brief.\n")},
+	}
+	s := newServer("test", "test", testFS)
+	srv, err := s.server()
+	if err != nil {
+		t.Fatalf("server() error = %v", err)
+	}
+
+	var list listPromptsResultData
+	if err := json.Unmarshal(callMCP(t, srv, "prompts/list", map[string]any{}), &list); err != nil {
+		t.Fatal(err)
+	}
+	if len(list.Prompts) != 1 || list.Prompts[0].Name != "summarize_test_markdown" {
+		t.Fatalf("prompts/list = %+v, want summarize_test_markdown", list.Prompts)
+	}
+
+	var init struct {
+		Capabilities struct {
+			Prompts *struct{} `json:"prompts"`
+		} `json:"capabilities"`
+	}
+	if err := json.Unmarshal(callMCP(t, srv, "initialize", map[string]any{"protocolVersion": "2024-11-05"}), &init); err != nil {
+		t.Fatal(err)
+	}
+	if init.Capabilities.Prompts == nil {
+		t.Error("initialize capabilities.prompts is missing")
+	}
+
+	raw := callMCP(t, srv, "prompts/get", map[string]any{
+		"name":      "summarize_test_markdown",
+		"arguments": map[string]string{"path": "docs/intro.md"},
+	})
+	var got struct {
+		Messages []struct {
+			Role    string `json:"role"`
+			Content struct {
+				Type string `json:"type"`
+				Text string `json:"text"`
+			} `json:"content"`
+		} `json:"messages"`
+	}
+	if err := json.Unmarshal(raw, &got); err != nil {
+		t.Fatal(err)
+	}
+	if len(got.Messages) != 1 || got.Messages[0].Role != "user" || got.Messages[0].Content.Type != "text" {
+		t.Fatalf("prompts/get = %s, want one user text message", raw)
+	}
+	text := got.Messages[0].Content.Text
+	for _, want := range []string{"concise summary", "docs/intro.md", "# Intro\n\nThe project in brief.\n</file>"} {
+		if !strings.Contains(text, want) {
+			t.Errorf("prompt text = %q, want it to contain %q", text, want)
+		}
+	}
+}
+
+func Test_server_getPrompt_errors(t *testing.T) {
+	s := &Server{name: "test", fs: fstest.MapFS{"a.md": {Data: []byte("a")}}}
+	for _, params := range []getPromptRequestParams{
+		{Name: "unknown"},
+		{Name: "summarize_test_markdown"},
+		{Name: "summarize_test_markdown", Arguments: map[string]string{"path": "missing.md"}},
+	} {
+		if _, err := s.getPrompt(params); err == nil {
+			t.Errorf("getPrompt(%+v) error = nil, want error", params)
+		}
+	}
+}
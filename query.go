@@ -0,0 +1,59 @@
+package mcpmds
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Warashi/go-modelcontextprotocol/jsonschema"
+	"github.com/Warashi/go-modelcontextprotocol/mcp"
+)
+
+func (s *Server) queryMarkdownFilesTool() mcp.Tool[*queryMarkdownFilesRequest, *queryMarkdownFilesResponse] {
+	return mcp.NewToolFunc(
+		fmt.Sprintf("query_%s_markdown_files", s.name),
+		fmt.Sprintf("List markdown files managed by %s whose frontmatter has all the given key-value pairs, such as status: published", s.name),
+		jsonschema.Object{
+			Properties: map[string]jsonschema.Schema{
+				"frontmatter": jsonschema.Map{
+					Description:          "Maps frontmatter keys to expected values. A list-valued key such as tags matches if any element equals the value. Values are compared as the frontmatter value's type, so \"123\" matches the number 123 and \"true\" the boolean true",
+					AdditionalProperties: jsonschema.String{},
+				},
+			},
+			Required: []string{"frontmatter"},
+		},
+		s.queryMarkdownFiles,
+	)
+}
+
+type queryMarkdownFilesRequest struct {
+	Frontmatter map[string]string `json:"frontmatter" jsonschema:"required"`
+}
+
+type queryMarkdownFilesResponse struct {
+	Files []markdownFileInfo `json:"files"`
+}
+
+func (s *Server) queryMarkdownFiles(ctx context.Context, request *queryMarkdownFilesRequest) (*queryMarkdownFilesResponse, error) {
+	if len(request.Frontmatter) == 0 {
+		return nil, errors.New("frontmatter must have at least one key")
+	}
+	files := []markdownFileInfo{}
+	for f := range s.markdownFiles(ctx) {
+		if frontmatterMatches(f.Frontmatter, request.Frontmatter) {
+			files = append(files, f)
+		}
+	}
+	return &queryMarkdownFilesResponse{Files: files}, nil
+}
+
+// frontmatterMatches reports whether frontmatter has every key in want with
+// a value equal to, or a list containing, the expected value.
+func frontmatterMatches(frontmatter map[string]any, want map[string]string) bool {
+	for key, value := range want {
+		if !valueMatches(frontmatter[key], value) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,56 @@
+package mcpmds
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"testing/fstest"
+)
+
+func Test_server_queryMarkdownFiles(t *testing.T) {
+	testFS := fstest.MapFS{
+		"published.md": {Data: []byte("---\nstatus: published\nauthor: alice\ntags: [go, mcp]\nvalue: 123\n---\n")},
+		"draft.md":     {Data: []byte("---\nstatus: draft\nauthor: bob\ntags: go\nvalue: \"123\"\nfeatured: true\n---\n")},
+		"toml.md":      {Data: []byte("+++\nstatus = \"published\"\nvalue = 123.0\ntags = [\"rust\"]\ndate = 2024-03-21\n+++\n")},
+		"plain.md":     {Data: []byte("no frontmatter")},
+	}
+	s := &Server{fs: testFS}
+
+	tests := []struct {
+		name    string
+		query   map[string]string
+		want    []string
+		wantErr bool
+	}{
+		{name: "Scalar string", query: map[string]string{"status": "published"}, want: []string{"published.md", "toml.md"}},
+		{name: "Multiple keys", query: map[string]string{"status": "published", "author": "alice"}, want: []string{"published.md"}},
+		{name: "List membership", query: map[string]string{"tags": "go"}, want: []string{"draft.md", "published.md"}},
+		{name: "Number matches numbers only", query: map[string]string{"value": "123"}, want: []string{"draft.md", "published.md", "toml.md"}},
+		{name: "Number compared numerically", query: map[string]string{"value": "123.0"}, want: []string{"published.md", "toml.md"}},
+		{name: "Boolean", query: map[string]string{"featured": "true"}, want: []string{"draft.md"}},
+		{name: "Date", query: map[string]string{"date": "2024-03-21"}, want: []string{"toml.md"}},
+		{name: "Missing key", query: map[string]string{"owner": "alice"}, want: []string{}},
+		{name: "Empty query", query: map[string]string{}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := s.queryMarkdownFiles(context.Background(), &queryMarkdownFilesRequest{Frontmatter: tt.query})
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("queryMarkdownFiles() succeeded, want error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("queryMarkdownFiles() error = %v", err)
+			}
+			got := []string{}
+			for _, f := range resp.Files {
+				got = append(got, f.Path)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("queryMarkdownFiles() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,132 @@
+package mcpmds
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Warashi/go-modelcontextprotocol/mcp"
+	"golang.org/x/time/rate"
+)
+
+// WithRateLimit limits each client to perClient tool calls per second, with
+// bursts of up to burst calls. Calls over the limit fail with an error without
+// running the tool. Sessions of NewHandler share the limiter of their client,
+// identified by its bearer token if WithAuthToken or WithAuthorizer accepted
+// it and by its remote address otherwise, so reconnecting does not reset the
+// limit. The single session of ServeStdio has a limiter of its own; tools
+// called outside a session share one limiter.
+func WithRateLimit(perClient rate.Limit, burst int) ServerOption {
+	return func(s *Server) {
+		s.rateLimit = perClient
+		s.rateBurst = burst
+		s.rateLimiter = rate.NewLimiter(perClient, burst)
+	}
+}
+
+// rateLimiterKey is the context key of the rate limiter of a session.
+type rateLimiterKey struct{}
+
+// rateLimitClientKey is the context key of the client an HTTP request comes
+// from, as identified by rateLimitClient.
+type rateLimitClientKey struct{}
+
+// rateLimitClient identifies the client making r by its bearer token if
+// authorized reports that the token was checked, or by its remote address
+// otherwise. Unchecked tokens are ignored, as a client could present a new one
+// on every connection.
+func rateLimitClient(r *http.Request, authorized bool) string {
+	if token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer "); authorized && ok && token != "" {
+		return "token " + token
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	return "address " + host
+}
+
+// withRateLimitClient returns r with its client recorded in its context for
+// withSessionRateLimiter, or r itself if WithRateLimit is not set. It must be
+// called only after the authorizer, if any, has accepted r.
+func (s *Server) withRateLimitClient(r *http.Request) *http.Request {
+	if s.rateLimiter == nil {
+		return r
+	}
+	return r.WithContext(context.WithValue(r.Context(), rateLimitClientKey{}, rateLimitClient(r, s.authorizer != nil)))
+}
+
+// withSessionRateLimiter returns ctx carrying the rate limiter for the tool
+// calls of one session, or ctx itself if WithRateLimit is not set. Sessions
+// whose context records a client use that client's limiter, looked up on each
+// call by allowClient; others get a new one.
+func (s *Server) withSessionRateLimiter(ctx context.Context) context.Context {
+	if s.rateLimiter == nil {
+		return ctx
+	}
+	if _, ok := ctx.Value(rateLimitClientKey{}).(string); ok {
+		return ctx
+	}
+	return context.WithValue(ctx, rateLimiterKey{}, rate.NewLimiter(s.rateLimit, s.rateBurst))
+}
+
+// allowClient reports whether client may make another tool call, creating
+// its rate limiter if needed. Creating a limiter evicts those whose bucket has
+// refilled, as they behave like new ones; this keeps the limiters of idle
+// clients from piling up.
+func (s *Server) allowClient(client string) bool {
+	s.rateMu.Lock()
+	defer s.rateMu.Unlock()
+	limiter, ok := s.clientRateLimiters[client]
+	if !ok {
+		now := time.Now()
+		for c, l := range s.clientRateLimiters {
+			if l.TokensAt(now) >= float64(s.rateBurst) {
+				delete(s.clientRateLimiters, c)
+			}
+		}
+		if s.clientRateLimiters == nil {
+			s.clientRateLimiters = map[string]*rate.Limiter{}
+		}
+		limiter = rate.NewLimiter(s.rateLimit, s.rateBurst)
+		s.clientRateLimiters[client] = limiter
+	}
+	return limiter.Allow()
+}
+
+// allowToolCall reports an error if the tool call made with ctx exceeds the
+// rate limit of its session.
+func (s *Server) allowToolCall(ctx context.Context) error {
+	if s.rateLimiter == nil {
+		return nil
+	}
+	var allowed bool
+	if limiter, ok := ctx.Value(rateLimiterKey{}).(*rate.Limiter); ok {
+		allowed = limiter.Allow()
+	} else if client, ok := ctx.Value(rateLimitClientKey{}).(string); ok {
+		allowed = s.allowClient(client)
+	} else {
+		allowed = s.rateLimiter.Allow()
+	}
+	if !allowed {
+		return fmt.Errorf("rate limit exceeded: at most %g tool calls per second are allowed, with bursts of %d", float64(s.rateLimit), s.rateBurst)
+	}
+	return nil
+}
+
+// withTool returns the option registering tool, with its handler subject to
+// the server's rate limit.
+func withTool[Input, Output any](s *Server, tool mcp.Tool[Input, Output]) mcp.ServerOption {
+	handler := tool.Handler
+	tool.Handler = mcp.ToolHandlerFunc[Input, Output](func(ctx context.Context, input Input) (Output, error) {
+		if err := s.allowToolCall(ctx); err != nil {
+			var zero Output
+			return zero, err
+		}
+		return handler.Handle(ctx, input)
+	})
+	return mcp.WithTool(tool)
+}
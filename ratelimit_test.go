@@ -0,0 +1,162 @@
+package mcpmds
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/Warashi/go-modelcontextprotocol/transport"
+	"golang.org/x/time/rate"
+)
+
+// callToolsInSession calls the tool n times in one session and returns
+// whether each call failed, by request order.
+func callToolsInSession(t *testing.T, ctx context.Context, s *Server, tool string, n int) []bool {
+	t.Helper()
+	srv, err := s.server()
+	if err != nil {
+		t.Fatalf("server() error = %v", err)
+	}
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	server, client := transport.NewPipe()
+	go s.sessionHandler(srv).HandleSession(ctx, 1, server)
+	defer client.Close()
+
+	go func() {
+		for i := range n {
+			req := fmt.Sprintf(`{"jsonrpc":"2.0","id":%d,"method":"tools/call","params":{"name":%q,"arguments":{}}}`, i, tool)
+			client.Send(json.RawMessage(req))
+		}
+	}()
+	failed := make([]bool, n)
+	received := 0
+	for msg := range client.Receive() {
+		var resp struct {
+			ID     int `json:"id"`
+			Result struct {
+				IsError bool `json:"isError"`
+				Content []struct {
+					Text string `json:"text"`
+				} `json:"content"`
+			} `json:"result"`
+		}
+		if err := json.Unmarshal(msg, &resp); err != nil {
+			t.Fatalf("unmarshal response: %v", err)
+		}
+		failed[resp.ID] = resp.Result.IsError
+		if resp.Result.IsError && !strings.Contains(resp.Result.Content[0].Text, "rate limit exceeded") {
+			t.Errorf("call %d error = %q, want rate limit error", resp.ID, resp.Result.Content[0].Text)
+		}
+		if received++; received == n {
+			break
+		}
+	}
+	return failed
+}
+
+func TestWithRateLimit(t *testing.T) {
+	testFS := fstest.MapFS{"a.md": {Data: []byte("# A\n")}}
+	s := newServer("test", "test", testFS, WithRateLimit(rate.Every(time.Hour), 2))
+
+	// Each session may make a burst of two calls before being rejected.
+	for session := range 2 {
+		rejected := 0
+		for _, failed := range callToolsInSession(t, context.Background(), s, "list_test_markdown_files", 3) {
+			if failed {
+				rejected++
+			}
+		}
+		if rejected != 1 {
+			t.Errorf("session %d: %d calls rejected, want 1", session, rejected)
+		}
+	}
+}
+
+func TestWithRateLimit_sameClient(t *testing.T) {
+	testFS := fstest.MapFS{"a.md": {Data: []byte("# A\n")}}
+	s := newServer("test", "test", testFS, WithRateLimit(rate.Every(time.Hour), 2), WithAuthToken("secret"))
+
+	// Reconnecting does not reset the limit of a client.
+	client := func(remoteAddr, token string) context.Context {
+		r := httptest.NewRequest(http.MethodGet, "/sse", nil)
+		r.RemoteAddr = remoteAddr
+		if token != "" {
+			r.Header.Set("Authorization", "Bearer "+token)
+		}
+		return s.withRateLimitClient(r).Context()
+	}
+	for _, tt := range []struct {
+		name string
+		ctx  context.Context
+		want int
+	}{
+		{"first session", client("192.0.2.1:1000", ""), 1},
+		{"reconnected from another port", client("192.0.2.1:2000", ""), 3},
+		{"another address", client("192.0.2.2:1000", ""), 1},
+		{"token", client("192.0.2.1:1000", "secret"), 1},
+		{"same token from another address", client("192.0.2.3:1000", "secret"), 3},
+	} {
+		rejected := 0
+		for _, failed := range callToolsInSession(t, tt.ctx, s, "list_test_markdown_files", 3) {
+			if failed {
+				rejected++
+			}
+		}
+		if rejected != tt.want {
+			t.Errorf("%s: %d calls rejected, want %d", tt.name, rejected, tt.want)
+		}
+	}
+}
+
+func TestWithRateLimit_rotatingTokens(t *testing.T) {
+	testFS := fstest.MapFS{"a.md": {Data: []byte("# A\n")}}
+	s := newServer("test", "test", testFS, WithRateLimit(rate.Every(time.Hour), 2))
+
+	// Without an authorizer, tokens are unchecked and do not identify clients.
+	for i, token := range []string{"a", "b", "c"} {
+		r := httptest.NewRequest(http.MethodGet, "/sse", nil)
+		r.RemoteAddr = "192.0.2.1:1000"
+		r.Header.Set("Authorization", "Bearer "+token)
+		rejected := 0
+		for _, failed := range callToolsInSession(t, s.withRateLimitClient(r).Context(), s, "list_test_markdown_files", 3) {
+			if failed {
+				rejected++
+			}
+		}
+		if want := min(1+2*i, 3); rejected != want {
+			t.Errorf("token %q: %d calls rejected, want %d", token, rejected, want)
+		}
+	}
+}
+
+func TestWithRateLimit_evictsIdleClients(t *testing.T) {
+	s := newServer("test", "test", fstest.MapFS{}, WithRateLimit(rate.Every(10*time.Millisecond), 1))
+	if !s.allowClient("address 192.0.2.1") {
+		t.Fatal("first client: call rejected")
+	}
+	time.Sleep(20 * time.Millisecond)
+	if !s.allowClient("address 192.0.2.2") {
+		t.Fatal("second client: call rejected")
+	}
+	if got := len(s.clientRateLimiters); got != 1 {
+		t.Errorf("%d client rate limiters kept, want 1", got)
+	}
+}
+
+func TestWithRateLimit_outsideSession(t *testing.T) {
+	s := newServer("test", "test", fstest.MapFS{}, WithRateLimit(rate.Every(time.Hour), 1))
+	ctx := context.Background()
+	if err := s.allowToolCall(ctx); err != nil {
+		t.Fatalf("first call: error = %v", err)
+	}
+	if err := s.allowToolCall(ctx); err == nil {
+		t.Error("second call: error = nil, want rate limit error")
+	}
+}
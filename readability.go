@@ -0,0 +1,147 @@
+package mcpmds
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/Warashi/go-modelcontextprotocol/jsonschema"
+	"github.com/Warashi/go-modelcontextprotocol/mcp"
+)
+
+func (s *Server) readabilityMarkdownFileTool() mcp.Tool[*readabilityMarkdownFileRequest, *readabilityMarkdownFileResponse] {
+	return mcp.NewToolFunc(
+		fmt.Sprintf("readability_%s_markdown_file", s.name),
+		fmt.Sprintf("Compute reading difficulty metrics for the body of a markdown file managed by %s", s.name),
+		jsonschema.Object{
+			Properties: map[string]jsonschema.Schema{
+				"path": jsonschema.String{
+					Description: "The path to the markdown file",
+				},
+			},
+			Required: []string{"path"},
+		},
+		s.readabilityMarkdownFile,
+	)
+}
+
+type readabilityMarkdownFileRequest struct {
+	Path string `json:"path" jsonschema:"required"`
+}
+
+type readabilityMarkdownFileResponse struct {
+	// Path is the relative path to the markdown file.
+	Path string `json:"path"`
+	// Sentences is the number of sentences in the prose.
+	Sentences int `json:"sentences"`
+	// Words is the number of words in the prose.
+	Words int `json:"words"`
+	// Syllables is the estimated number of syllables in the prose.
+	Syllables int `json:"syllables"`
+	// FleschReadingEase is the Flesch reading-ease score; higher is easier.
+	FleschReadingEase float64 `json:"flesch_reading_ease"`
+	// FleschKincaidGrade is the Flesch-Kincaid grade level.
+	FleschKincaidGrade float64 `json:"flesch_kincaid_grade"`
+	// AutomatedReadabilityIndex is the automated readability index, a grade level based on characters per word.
+	AutomatedReadabilityIndex float64 `json:"automated_readability_index"`
+}
+
+var (
+	proseLinkPattern     = regexp.MustCompile(`!?\[([^\]]*)\]\([^)]*\)`)
+	proseMarkerPattern   = regexp.MustCompile(`^\s*(?:#{1,6}\s+|>\s*|[-*+]\s+(?:\[[ xX]\]\s+)?|\d+[.)]\s+)`)
+	sentenceEndPattern   = regexp.MustCompile(`[.!?]+(?:["')\]]*)(?:\s|$)`)
+	proseWordPattern     = regexp.MustCompile(`[\p{L}\p{N}]+(?:['’][\p{L}]+)*`)
+	syllableGroupPattern = regexp.MustCompile(`[aeiouy]+`)
+)
+
+func (s *Server) readabilityMarkdownFile(ctx context.Context, request *readabilityMarkdownFileRequest) (*readabilityMarkdownFileResponse, error) {
+	path, content, err := s.readRequestedMarkdown(request.Path)
+	if err != nil {
+		return nil, err
+	}
+	_, _, body := s.splitFrontmatter(content)
+
+	resp := &readabilityMarkdownFileResponse{Path: path}
+	letters := 0
+	for _, block := range proseBlocks(body) {
+		words := proseWordPattern.FindAllString(block, -1)
+		if len(words) == 0 {
+			continue
+		}
+		resp.Words += len(words)
+		for _, w := range words {
+			resp.Syllables += syllables(w)
+			for _, r := range w {
+				if unicode.IsLetter(r) || unicode.IsNumber(r) {
+					letters++
+				}
+			}
+		}
+		// A block that does not end with punctuation, such as a heading, is still a sentence.
+		ends := sentenceEndPattern.FindAllStringIndex(block, -1)
+		resp.Sentences += len(ends)
+		if len(ends) == 0 || ends[len(ends)-1][1] != len(block) {
+			resp.Sentences++
+		}
+	}
+	if resp.Words == 0 {
+		return resp, nil
+	}
+
+	wordsPerSentence := float64(resp.Words) / float64(resp.Sentences)
+	syllablesPerWord := float64(resp.Syllables) / float64(resp.Words)
+	lettersPerWord := float64(letters) / float64(resp.Words)
+	resp.FleschReadingEase = round2(206.835 - 1.015*wordsPerSentence - 84.6*syllablesPerWord)
+	resp.FleschKincaidGrade = round2(0.39*wordsPerSentence + 11.8*syllablesPerWord - 15.59)
+	resp.AutomatedReadabilityIndex = round2(4.71*lettersPerWord + 0.5*wordsPerSentence - 21.43)
+	return resp, nil
+}
+
+// proseBlocks returns the paragraphs, headings, list items, and quotes of body
+// as plain text, with fenced code blocks, inline code spans, and markup removed.
+func proseBlocks(body []byte) []string {
+	var blocks []string
+	var current []string
+	flush := func() {
+		if len(current) > 0 {
+			blocks = append(blocks, strings.Join(current, " "))
+			current = nil
+		}
+	}
+	for line := range markdownLines(body) {
+		if line.InCode || strings.TrimSpace(line.Text) == "" {
+			flush()
+			continue
+		}
+		text := codeSpanPattern.ReplaceAllString(line.Text, "")
+		text = proseLinkPattern.ReplaceAllString(text, "$1")
+		if proseMarkerPattern.MatchString(text) {
+			flush()
+			text = proseMarkerPattern.ReplaceAllString(text, "")
+		}
+		if text = strings.TrimSpace(text); text != "" {
+			current = append(current, text)
+		}
+	}
+	flush()
+	return blocks
+}
+
+// syllables estimates the number of syllables in an English word by counting
+// vowel groups, discounting a silent trailing "e".
+func syllables(word string) int {
+	w := strings.ToLower(word)
+	n := len(syllableGroupPattern.FindAllString(w, -1))
+	if n > 1 && strings.HasSuffix(w, "e") && !strings.HasSuffix(w, "le") && !strings.HasSuffix(w, "ee") {
+		n--
+	}
+	return max(n, 1)
+}
+
+// round2 rounds f to two decimal places.
+func round2(f float64) float64 {
+	return math.Round(f*100) / 100
+}
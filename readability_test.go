@@ -0,0 +1,79 @@
+package mcpmds
+
+import (
+	"context"
+	"testing"
+	"testing/fstest"
+)
+
+func Test_server_readabilityMarkdownFile(t *testing.T) {
+	testFS := fstest.MapFS{
+		"simple.md": {Data: []byte("---\ntitle: Simple\n---\n# The Cat\n\nThe cat sat on the mat. It was a big cat. The dog ran.\n\n```\nconsiderably_complicated_identifier(parameterization)\n```\n")},
+		"complex.md": {Data: []byte("Notwithstanding considerable organizational complexity, interdepartmental " +
+			"communication necessitates comprehensive documentation, particularly regarding " +
+			"institutional responsibilities and administrative accountability.\n")},
+		"empty.md": {Data: []byte("---\ntitle: Empty\n---\n")},
+	}
+
+	s := &Server{fs: testFS}
+
+	t.Run("Simple text", func(t *testing.T) {
+		got, err := s.readabilityMarkdownFile(context.Background(), &readabilityMarkdownFileRequest{Path: "simple.md"})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got.Sentences != 4 || got.Words != 16 {
+			t.Errorf("sentences = %d, words = %d, want 4, 16", got.Sentences, got.Words)
+		}
+		if got.FleschReadingEase < 90 {
+			t.Errorf("FleschReadingEase = %v, want >= 90", got.FleschReadingEase)
+		}
+		if got.FleschKincaidGrade > 3 {
+			t.Errorf("FleschKincaidGrade = %v, want <= 3", got.FleschKincaidGrade)
+		}
+	})
+
+	t.Run("Complex text", func(t *testing.T) {
+		got, err := s.readabilityMarkdownFile(context.Background(), &readabilityMarkdownFileRequest{Path: "complex.md"})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got.Sentences != 1 {
+			t.Errorf("sentences = %d, want 1", got.Sentences)
+		}
+		if got.FleschReadingEase > 10 {
+			t.Errorf("FleschReadingEase = %v, want <= 10", got.FleschReadingEase)
+		}
+		if got.FleschKincaidGrade < 16 {
+			t.Errorf("FleschKincaidGrade = %v, want >= 16", got.FleschKincaidGrade)
+		}
+		if got.AutomatedReadabilityIndex < 16 {
+			t.Errorf("AutomatedReadabilityIndex = %v, want >= 16", got.AutomatedReadabilityIndex)
+		}
+	})
+
+	t.Run("Empty body", func(t *testing.T) {
+		got, err := s.readabilityMarkdownFile(context.Background(), &readabilityMarkdownFileRequest{Path: "empty.md"})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got.Words != 0 || got.FleschReadingEase != 0 {
+			t.Errorf("got = %+v, want zero metrics", got)
+		}
+	})
+}
+
+func Test_syllables(t *testing.T) {
+	tests := map[string]int{
+		"cat":           1,
+		"table":         2,
+		"make":          1,
+		"communication": 5,
+		"the":           1,
+	}
+	for word, want := range tests {
+		if got := syllables(word); got != want {
+			t.Errorf("syllables(%q) = %d, want %d", word, got, want)
+		}
+	}
+}
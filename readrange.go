@@ -0,0 +1,85 @@
+package mcpmds
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+
+	"github.com/Warashi/go-modelcontextprotocol/jsonschema"
+	"github.com/Warashi/go-modelcontextprotocol/mcp"
+)
+
+func (s *Server) readRangeTool() mcp.Tool[*readRangeRequest, *readRangeResponse] {
+	return mcp.NewToolFunc(
+		fmt.Sprintf("read_%s_range", s.name),
+		fmt.Sprintf("Read a byte range of a markdown file managed by %s as base64, without markdown interpretation", s.name),
+		jsonschema.Object{
+			Properties: map[string]jsonschema.Schema{
+				"path": jsonschema.String{
+					Description: "The path to the markdown file",
+				},
+				"offset": jsonschema.Integer{
+					Description: "The byte offset to start reading at",
+				},
+				"length": jsonschema.Integer{
+					Description: "The number of bytes to read; 0 reads to the end of the file",
+				},
+			},
+			Required: []string{"path", "offset"},
+		},
+		s.readRange,
+	)
+}
+
+type readRangeRequest struct {
+	Path   string `json:"path" jsonschema:"required"`
+	Offset int64  `json:"offset" jsonschema:"required"`
+	Length int64  `json:"length"`
+}
+
+type readRangeResponse struct {
+	// Path is the relative path to the markdown file.
+	Path string `json:"path"`
+	// Offset is the byte offset of the range.
+	Offset int64 `json:"offset"`
+	// Length is the number of bytes returned, which is less than requested at the end of the file.
+	Length int64 `json:"length"`
+	// Size is the size of the whole file in bytes.
+	Size int64 `json:"size"`
+	// EOF reports whether the range reaches the end of the file.
+	EOF bool `json:"eof"`
+	// Data is the content of the range, base64-encoded.
+	Data []byte `json:"data"`
+}
+
+func (s *Server) readRange(ctx context.Context, request *readRangeRequest) (*readRangeResponse, error) {
+	if request.Offset < 0 || request.Length < 0 {
+		return nil, errors.New("offset and length must not be negative")
+	}
+	path, content, err := s.readRequestedMarkdown(request.Path)
+	if err != nil {
+		return nil, err
+	}
+	if s.sourceEncoding != nil {
+		// Offsets are of the file as stored, not of its transcoded content.
+		content, err = fs.ReadFile(s.fs, path)
+		if err != nil {
+			return nil, err
+		}
+	}
+	size := int64(len(content))
+	start := min(request.Offset, size)
+	end := size
+	if request.Length > 0 {
+		end = min(start+request.Length, size)
+	}
+	return &readRangeResponse{
+		Path:   path,
+		Offset: request.Offset,
+		Length: end - start,
+		Size:   size,
+		EOF:    end == size,
+		Data:   content[start:end],
+	}, nil
+}
@@ -0,0 +1,83 @@
+package mcpmds
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"testing/fstest"
+
+	"golang.org/x/text/encoding/charmap"
+)
+
+func Test_server_readRange(t *testing.T) {
+	testFS := fstest.MapFS{
+		"a.md": {Data: []byte("---\ntitle: A\n---\n# Héllo\n")},
+	}
+	s := &Server{fs: testFS}
+
+	tests := []struct {
+		name     string
+		request  *readRangeRequest
+		wantData string
+		wantEOF  bool
+		wantErr  bool
+	}{
+		{name: "Mid-file range", request: &readRangeRequest{Path: "a.md", Offset: 4, Length: 8}, wantData: "title: A"},
+		{name: "Range splitting a rune", request: &readRangeRequest{Path: "a.md", Offset: 20, Length: 1}, wantData: "\xc3"},
+		{name: "To the end", request: &readRangeRequest{Path: "a.md", Offset: 17}, wantData: "# Héllo\n", wantEOF: true},
+		{name: "Length past the end", request: &readRangeRequest{Path: "a.md", Offset: 17, Length: 100}, wantData: "# Héllo\n", wantEOF: true},
+		{name: "Offset past the end", request: &readRangeRequest{Path: "a.md", Offset: 1000, Length: 10}, wantData: "", wantEOF: true},
+		{name: "Negative offset", request: &readRangeRequest{Path: "a.md", Offset: -1}, wantErr: true},
+		{name: "Negative length", request: &readRangeRequest{Path: "a.md", Length: -1}, wantErr: true},
+		{name: "Missing file", request: &readRangeRequest{Path: "missing.md"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := s.readRange(context.Background(), tt.request)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("readRange() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if string(got.Data) != tt.wantData || got.Length != int64(len(tt.wantData)) || got.EOF != tt.wantEOF {
+				t.Errorf("readRange() = %q (length %d, eof %v), want %q (eof %v)", got.Data, got.Length, got.EOF, tt.wantData, tt.wantEOF)
+			}
+		})
+	}
+
+	t.Run("Base64 encoding", func(t *testing.T) {
+		got, err := s.readRange(context.Background(), &readRangeRequest{Path: "a.md", Offset: 0, Length: 3})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		b, err := json.Marshal(got)
+		if err != nil {
+			t.Fatalf("marshal: %v", err)
+		}
+		var raw struct {
+			Data string `json:"data"`
+		}
+		if err := json.Unmarshal(b, &raw); err != nil {
+			t.Fatalf("unmarshal: %v", err)
+		}
+		if raw.Data != "LS0t" {
+			t.Errorf("data = %q, want %q", raw.Data, "LS0t")
+		}
+	})
+}
+
+func Test_server_readRange_sourceEncoding(t *testing.T) {
+	// "# Café\n" encoded in ISO 8859-1.
+	latin1 := []byte("# Caf\xe9\n")
+	s := newServer("test", "test", fstest.MapFS{"a.md": {Data: latin1}}, WithSourceEncoding(charmap.ISO8859_1))
+
+	got, err := s.readRange(context.Background(), &readRangeRequest{Path: "a.md", Offset: 5})
+	if err != nil {
+		t.Fatalf("readRange() error = %v", err)
+	}
+	if string(got.Data) != "\xe9\n" || got.Size != int64(len(latin1)) {
+		t.Errorf("readRange() = %q of %d bytes, want %q of %d bytes", got.Data, got.Size, "\xe9\n", len(latin1))
+	}
+}
@@ -0,0 +1,55 @@
+package mcpmds
+
+import "sync"
+
+// reindexer runs a re-index pass in the background when triggered. At most
+// one pass runs at a time: triggers arriving while a pass is in progress are
+// coalesced into a single follow-up pass, so bursts of file changes never
+// queue overlapping work while still indexing the latest state.
+type reindexer struct {
+	run func()
+
+	mu      sync.Mutex
+	running bool
+	pending bool
+	wg      sync.WaitGroup
+}
+
+// newReindexer returns a reindexer that calls run for each pass.
+func newReindexer(run func()) *reindexer {
+	return &reindexer{run: run}
+}
+
+// trigger requests a re-index pass. It starts one immediately when idle and
+// otherwise schedules a single follow-up to the pass in progress.
+func (r *reindexer) trigger() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.running {
+		r.pending = true
+		return
+	}
+	r.running = true
+	r.wg.Add(1)
+	go r.loop()
+}
+
+func (r *reindexer) loop() {
+	defer r.wg.Done()
+	for {
+		r.run()
+		r.mu.Lock()
+		if !r.pending {
+			r.running = false
+			r.mu.Unlock()
+			return
+		}
+		r.pending = false
+		r.mu.Unlock()
+	}
+}
+
+// wait blocks until no pass is running or pending.
+func (r *reindexer) wait() {
+	r.wg.Wait()
+}
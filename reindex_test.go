@@ -0,0 +1,46 @@
+package mcpmds
+
+import (
+	"sync/atomic"
+	"testing"
+)
+
+func Test_reindexer_coalescesTriggers(t *testing.T) {
+	started := make(chan struct{})
+	release := make(chan struct{})
+	var runs atomic.Int32
+	r := newReindexer(func() {
+		if runs.Add(1) == 1 {
+			close(started)
+			<-release
+		}
+	})
+
+	r.trigger()
+	<-started
+	for range 10 {
+		r.trigger()
+	}
+	close(release)
+	r.wait()
+
+	if got := runs.Load(); got != 2 {
+		t.Errorf("runs = %d, want 2 (the in-progress pass and one follow-up)", got)
+	}
+
+	r.trigger()
+	r.wait()
+	if got := runs.Load(); got != 3 {
+		t.Errorf("runs after idle trigger = %d, want 3", got)
+	}
+}
+
+func Test_reindexer_idleTriggerRunsOnce(t *testing.T) {
+	var runs atomic.Int32
+	r := newReindexer(func() { runs.Add(1) })
+	r.trigger()
+	r.wait()
+	if got := runs.Load(); got != 1 {
+		t.Errorf("runs = %d, want 1", got)
+	}
+}
@@ -0,0 +1,71 @@
+package mcpmds
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/Warashi/go-modelcontextprotocol/jsonschema"
+	"github.com/Warashi/go-modelcontextprotocol/mcp"
+)
+
+func (s *Server) relatedTool() mcp.Tool[*relatedRequest, *relatedResponse] {
+	return mcp.NewToolFunc(
+		fmt.Sprintf("related_%s", s.name),
+		fmt.Sprintf("List the documents declared in the related frontmatter key of a markdown file managed by %s, flagging targets that do not exist", s.name),
+		jsonschema.Object{
+			Properties: map[string]jsonschema.Schema{
+				"path": jsonschema.String{
+					Description: "The path to the markdown file",
+				},
+			},
+			Required: []string{"path"},
+		},
+		s.related,
+	)
+}
+
+type relatedRequest struct {
+	Path string `json:"path" jsonschema:"required"`
+}
+
+type relatedResponse struct {
+	// Related are the related documents that exist, in declaration order.
+	Related []markdownFileInfo `json:"related"`
+	// Missing are the declared targets, as written, that do not resolve to a
+	// served markdown file.
+	Missing []string `json:"missing"`
+}
+
+func (s *Server) related(ctx context.Context, request *relatedRequest) (*relatedResponse, error) {
+	source, _, err := s.readMarkdownOrAlias(ctx, request.Path)
+	if err != nil {
+		return nil, err
+	}
+	files := map[string]markdownFileInfo{}
+	for f := range s.markdownFiles(ctx) {
+		files[f.Path] = f
+	}
+
+	resp := &relatedResponse{Related: []markdownFileInfo{}, Missing: []string{}}
+	var seen []string
+	for _, target := range stringList(files[source].Frontmatter["related"]) {
+		target = strings.TrimSpace(target)
+		if target == "" {
+			continue
+		}
+		p, ok := resolveLink(source, target)
+		f, exists := files[p]
+		if !ok || !exists {
+			resp.Missing = append(resp.Missing, target)
+			continue
+		}
+		if slices.Contains(seen, p) {
+			continue
+		}
+		seen = append(seen, p)
+		resp.Related = append(resp.Related, f)
+	}
+	return resp, nil
+}
@@ -0,0 +1,77 @@
+package mcpmds
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"reflect"
+	"testing"
+	"testing/fstest"
+)
+
+func Test_server_related(t *testing.T) {
+	testFS := fstest.MapFS{
+		"guide/install.md":   {Data: []byte("---\nrelated: [configure.md, /faq.md, ../missing.md, configure.md]\n---\nbody")},
+		"guide/configure.md": {Data: []byte("---\ntitle: Configure\nrelated: ../guide/install.md\n---\nbody")},
+		"faq.md":             {Data: []byte("---\ntitle: FAQ\n---\nbody")},
+		"plain.md":           {Data: []byte("body")},
+	}
+	s := &Server{fs: testFS}
+
+	tests := []struct {
+		name        string
+		path        string
+		wantRelated []string
+		wantMissing []string
+		wantErr     error
+	}{
+		{
+			name:        "Relative and root paths with a missing target",
+			path:        "guide/install.md",
+			wantRelated: []string{"guide/configure.md", "faq.md"},
+			wantMissing: []string{"../missing.md"},
+		},
+		{
+			name:        "Single related target",
+			path:        "guide/configure.md",
+			wantRelated: []string{"guide/install.md"},
+			wantMissing: []string{},
+		},
+		{
+			name:        "No related key",
+			path:        "plain.md",
+			wantRelated: []string{},
+			wantMissing: []string{},
+		},
+		{
+			name:    "Non-existent file",
+			path:    "nope.md",
+			wantErr: fs.ErrNotExist,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := s.related(context.Background(), &relatedRequest{Path: tt.path})
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("related() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("related() error = %v", err)
+			}
+			paths := []string{}
+			for _, f := range got.Related {
+				paths = append(paths, f.Path)
+			}
+			if !reflect.DeepEqual(paths, tt.wantRelated) {
+				t.Errorf("related() related = %v, want %v", paths, tt.wantRelated)
+			}
+			if !reflect.DeepEqual(got.Missing, tt.wantMissing) {
+				t.Errorf("related() missing = %v, want %v", got.Missing, tt.wantMissing)
+			}
+		})
+	}
+}
@@ -0,0 +1,38 @@
+package mcpmds
+
+import (
+	"net/url"
+	"slices"
+)
+
+// WithRewriteLinksToResourceURIs rewrites links between markdown files in
+// returned content to their resource URIs (file://path/to/file.md), so that
+// MCP clients can follow them directly.
+// External links, anchors, and links to non-markdown files are left unchanged.
+func WithRewriteLinksToResourceURIs(enabled bool) ServerOption {
+	return func(s *Server) {
+		s.rewriteLinksToResourceURIs = enabled
+	}
+}
+
+// rewriteInternalLinks rewrites the links to markdown files in the body of
+// the file at source to resource URIs.
+func (s *Server) rewriteInternalLinks(source string, content []byte) []byte {
+	_, _, body := s.splitFrontmatter(content)
+	head := content[:len(content)-len(body)]
+	body = rewriteLinks(body, func(link markdownLink) (string, bool) {
+		if link.Image {
+			return "", false
+		}
+		target, ok := resolveLink(source, link.URL)
+		if !ok || !s.isMarkdown(target) {
+			return "", false
+		}
+		uri := resourceURI(target)
+		if u, err := url.Parse(link.URL); err == nil && u.Fragment != "" {
+			uri += "#" + u.Fragment
+		}
+		return uri, true
+	})
+	return slices.Concat(head, body)
+}
@@ -0,0 +1,79 @@
+package mcpmds
+
+import (
+	"context"
+	"testing"
+	"testing/fstest"
+
+	"github.com/Warashi/go-modelcontextprotocol/mcp"
+)
+
+func Test_server_rewriteLinksToResourceURIs(t *testing.T) {
+	content := `---
+title: Guide
+link: ./kept.md
+---
+Relative: [other](./other.md) and [parent](../index.md#intro).
+Absolute: [api](/docs/api.md).
+External: [site](https://example.com/page.md) and [mail](mailto:a@example.com).
+Anchor: [top](#top). Image: ![img](./diagram.png). Code: ` + "`[x](./x.md)`" + `.
+
+` + "```" + `
+[in code](./code.md)
+` + "```" + `
+`
+	want := `---
+title: Guide
+link: ./kept.md
+---
+Relative: [other](file://docs/other.md) and [parent](file://index.md#intro).
+Absolute: [api](file://docs/api.md).
+External: [site](https://example.com/page.md) and [mail](mailto:a@example.com).
+Anchor: [top](#top). Image: ![img](./diagram.png). Code: ` + "`[x](./x.md)`" + `.
+
+` + "```" + `
+[in code](./code.md)
+` + "```" + `
+`
+	testFS := fstest.MapFS{
+		"docs/guide.md": {Data: []byte(content)},
+	}
+
+	t.Run("Disabled by default", func(t *testing.T) {
+		s := &Server{fs: testFS}
+		got, err := s.readMarkdownFile(context.Background(), &readMarkdownFileRequest{Path: "docs/guide.md"})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got.Content != content {
+			t.Errorf("readMarkdownFile() content =\n%s\nwant =\n%s", got.Content, content)
+		}
+	})
+
+	t.Run("Rewritten in read tool", func(t *testing.T) {
+		s := &Server{fs: testFS}
+		WithRewriteLinksToResourceURIs(true)(s)
+		got, err := s.readMarkdownFile(context.Background(), &readMarkdownFileRequest{Path: "docs/guide.md"})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got.Content != want {
+			t.Errorf("readMarkdownFile() content =\n%s\nwant =\n%s", got.Content, want)
+		}
+	})
+
+	t.Run("Rewritten in resource read", func(t *testing.T) {
+		s := &Server{fs: testFS}
+		WithRewriteLinksToResourceURIs(true)(s)
+		req := &mcp.Request[mcp.ReadResourceRequestParams]{
+			Params: mcp.ReadResourceRequestParams{URI: "file://docs/guide.md"},
+		}
+		got, err := s.ReadResource(context.Background(), req)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if text := got.Data.Contents[0].(mcp.TextResourceContents).Text; text != want {
+			t.Errorf("ReadResource() text =\n%s\nwant =\n%s", text, want)
+		}
+	})
+}
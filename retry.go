@@ -0,0 +1,54 @@
+package mcpmds
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"time"
+)
+
+// errRetriesExhausted is reported when a walked file still cannot be read after all retries.
+var errRetriesExhausted = errors.New("retries exhausted")
+
+// WithWalkRetry retries failed reads of individual files while walking the
+// filesystem, which helps with eventually-consistent filesystems such as
+// object storage where a file may be listed before it is fully written.
+// Each file is read up to attempts times, waiting backoff before the first
+// retry and doubling the wait after each failure.
+// Files that still fail are skipped instead of aborting the walk.
+func WithWalkRetry(attempts int, backoff time.Duration) ServerOption {
+	return func(s *Server) {
+		s.walkRetryAttempts = attempts
+		s.walkRetryBackoff = backoff
+	}
+}
+
+// readWalkedMarkdownInfo reads the info of a file found during the walk,
+// retrying according to WithWalkRetry. Waiting between attempts stops with
+// ctx's error when ctx is done.
+func (s *Server) readWalkedMarkdownInfo(ctx context.Context, path string, d fs.DirEntry) (markdownFileInfo, error) {
+	if s.walkRetryAttempts <= 0 {
+		return s.readMarkdownInfo(path, d)
+	}
+	wait := s.walkRetryBackoff
+	var err error
+	for attempt := range s.walkRetryAttempts {
+		if attempt > 0 {
+			timer := time.NewTimer(wait)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return markdownFileInfo{}, ctx.Err()
+			case <-timer.C:
+			}
+			wait *= 2
+		}
+		var info markdownFileInfo
+		info, err = s.readMarkdownInfo(path, d)
+		if err == nil || errors.Is(err, errNotServed) || errors.Is(err, errFileTooLarge) || isFrontmatterParseError(err) {
+			return info, err
+		}
+	}
+	return markdownFileInfo{}, fmt.Errorf("%w: %w", errRetriesExhausted, err)
+}
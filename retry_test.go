@@ -0,0 +1,129 @@
+package mcpmds
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"slices"
+	"sync"
+	"testing"
+	"testing/fstest"
+	"time"
+)
+
+// flakyFS fails to open configured files a number of times before succeeding.
+type flakyFS struct {
+	fstest.MapFS
+
+	mu       sync.Mutex
+	failures map[string]int
+	opens    map[string]int
+}
+
+func (f *flakyFS) Open(name string) (fs.File, error) {
+	if err := f.fail(name); err != nil {
+		return nil, err
+	}
+	return f.MapFS.Open(name)
+}
+
+func (f *flakyFS) ReadFile(name string) ([]byte, error) {
+	if err := f.fail(name); err != nil {
+		return nil, err
+	}
+	return f.MapFS.ReadFile(name)
+}
+
+func (f *flakyFS) fail(name string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.opens[name]++
+	if f.failures[name] > 0 {
+		f.failures[name]--
+		return &fs.PathError{Op: "open", Path: name, Err: errors.New("object not yet consistent")}
+	}
+	return nil
+}
+
+func Test_server_walkRetry(t *testing.T) {
+	newFS := func() *flakyFS {
+		return &flakyFS{
+			MapFS: fstest.MapFS{
+				"stable.md":     {Data: []byte("stable")},
+				"eventually.md": {Data: []byte("eventually readable")},
+				"broken.md":     {Data: []byte("never readable")},
+			},
+			failures: map[string]int{
+				"eventually.md": 2,
+				"broken.md":     100,
+			},
+			opens: map[string]int{},
+		}
+	}
+
+	t.Run("Without retry a failing read stops the walk", func(t *testing.T) {
+		s := &Server{fs: newFS()}
+		resp, err := s.listMarkdownFiles(context.Background(), nil)
+		if err != nil {
+			t.Fatalf("listMarkdownFiles() error = %v", err)
+		}
+		if len(resp.Files) != 0 {
+			t.Errorf("listMarkdownFiles() got = %v, want no files", resp.Files)
+		}
+	})
+
+	t.Run("Retry recovers flaky files and skips persistent failures", func(t *testing.T) {
+		fsys := newFS()
+		s := &Server{fs: fsys}
+		WithWalkRetry(3, time.Millisecond)(s)
+
+		resp, err := s.listMarkdownFiles(context.Background(), nil)
+		if err != nil {
+			t.Fatalf("listMarkdownFiles() error = %v", err)
+		}
+		var got []string
+		for _, f := range resp.Files {
+			got = append(got, f.Path)
+		}
+		if want := []string{"eventually.md", "stable.md"}; !slices.Equal(got, want) {
+			t.Errorf("listMarkdownFiles() got = %v, want %v", got, want)
+		}
+		if got := fsys.opens["broken.md"]; got != 3 {
+			t.Errorf("broken.md opened %d times, want 3", got)
+		}
+		if got := fsys.opens["eventually.md"]; got != 3 {
+			t.Errorf("eventually.md opened %d times, want 3", got)
+		}
+	})
+
+	t.Run("Hidden files are not retried", func(t *testing.T) {
+		fsys := newFS()
+		fsys.MapFS["draft.md"] = &fstest.MapFile{Data: []byte("---\ndraft: true\n---\n")}
+		s := &Server{fs: fsys}
+		WithWalkRetry(3, time.Millisecond)(s)
+		WithHideDrafts("")(s)
+
+		if _, err := s.listMarkdownFiles(context.Background(), nil); err != nil {
+			t.Fatalf("listMarkdownFiles() error = %v", err)
+		}
+		if got := fsys.opens["draft.md"]; got != 1 {
+			t.Errorf("draft.md opened %d times, want 1", got)
+		}
+	})
+
+	t.Run("Canceling stops waiting for a retry", func(t *testing.T) {
+		s := &Server{fs: newFS()}
+		WithWalkRetry(3, time.Hour)(s)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		start := time.Now()
+		_, err := s.listMarkdownFiles(ctx, nil)
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("listMarkdownFiles() error = %v, want %v", err, context.DeadlineExceeded)
+		}
+		if elapsed := time.Since(start); elapsed > time.Minute {
+			t.Errorf("listMarkdownFiles() took %v after cancellation", elapsed)
+		}
+	})
+}
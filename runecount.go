@@ -0,0 +1,11 @@
+package mcpmds
+
+// WithRuneCount adds a rune_count field to the metadata of each markdown
+// file: the number of characters in the body, excluding frontmatter. Unlike
+// the byte size, it matches the visible length of text in multibyte
+// languages such as Japanese or Chinese.
+func WithRuneCount(enabled bool) ServerOption {
+	return func(s *Server) {
+		s.runeCount = enabled
+	}
+}
@@ -0,0 +1,61 @@
+package mcpmds
+
+import (
+	"context"
+	"testing"
+	"testing/fstest"
+)
+
+func TestWithRuneCount(t *testing.T) {
+	testFS := fstest.MapFS{
+		"ascii.md": {Data: []byte("---\ntitle: Hello\n---\nHello, world")},
+		"cjk.md":   {Data: []byte("---\ntitle: 挨拶\n---\nこんにちは世界")},
+		"empty.md": {Data: []byte("---\ntitle: Empty\n---\n")},
+	}
+
+	tests := []struct {
+		path          string
+		wantSize      int64
+		wantRuneCount int
+	}{
+		{path: "ascii.md", wantSize: 33, wantRuneCount: 12},
+		{path: "cjk.md", wantSize: 43, wantRuneCount: 7},
+		{path: "empty.md", wantSize: 21, wantRuneCount: 0},
+	}
+
+	s := &Server{fs: testFS}
+	WithRuneCount(true)(s)
+	resp, err := s.listMarkdownFiles(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("listMarkdownFiles() error = %v", err)
+	}
+	files := map[string]markdownFileInfo{}
+	for _, f := range resp.Files {
+		files[f.Path] = f
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			f := files[tt.path]
+			if f.Size != tt.wantSize {
+				t.Errorf("Size = %d, want %d", f.Size, tt.wantSize)
+			}
+			if f.RuneCount == nil {
+				t.Fatal("RuneCount is nil")
+			}
+			if *f.RuneCount != tt.wantRuneCount {
+				t.Errorf("RuneCount = %d, want %d", *f.RuneCount, tt.wantRuneCount)
+			}
+		})
+	}
+
+	s = &Server{fs: testFS}
+	resp, err = s.listMarkdownFiles(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("listMarkdownFiles() error = %v", err)
+	}
+	for _, f := range resp.Files {
+		if f.RuneCount != nil {
+			t.Errorf("%s: RuneCount = %d without WithRuneCount, want nil", f.Path, *f.RuneCount)
+		}
+	}
+}
@@ -0,0 +1,101 @@
+package mcpmds
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+)
+
+// FrontmatterSchema describes the expected frontmatter keys.
+type FrontmatterSchema map[string]FieldSchema
+
+// FieldSchema describes the expected value of a frontmatter key.
+type FieldSchema struct {
+	// Type is the expected type: "string", "number", "integer", "boolean",
+	// "array", "object", or "datetime". An empty type accepts any value.
+	Type string
+	// Required reports whether the key must be present.
+	Required bool
+	// Enum lists the allowed values, compared by their string representation.
+	// An empty list allows any value.
+	Enum []any
+}
+
+// WithFrontmatterSchema fills missing frontmatter keys from defaults and
+// validates the result against schema whenever a file is read with the read
+// tool. Violations do not fail the read; they are returned as warnings next
+// to the enriched frontmatter.
+func WithFrontmatterSchema(defaults map[string]any, schema FrontmatterSchema) ServerOption {
+	return func(s *Server) {
+		s.frontmatterDefaults = defaults
+		s.frontmatterSchema = schema
+	}
+}
+
+// applyFrontmatterDefaults returns frontmatter with the keys it lacks taken
+// from the configured defaults.
+func (s *Server) applyFrontmatterDefaults(frontmatter map[string]any) map[string]any {
+	if len(s.frontmatterDefaults) == 0 {
+		return frontmatter
+	}
+	merged := cloneValue(s.frontmatterDefaults).(map[string]any)
+	maps.Copy(merged, frontmatter)
+	return merged
+}
+
+// validate returns a warning for each violation of the schema by frontmatter,
+// ordered by key.
+func (schema FrontmatterSchema) validate(frontmatter map[string]any) []string {
+	var warnings []string
+	for _, key := range slices.Sorted(maps.Keys(schema)) {
+		field := schema[key]
+		v, ok := frontmatter[key]
+		if !ok {
+			if field.Required {
+				warnings = append(warnings, fmt.Sprintf("missing required key %q", key))
+			}
+			continue
+		}
+		if field.Type != "" && !hasSchemaType(v, field.Type) {
+			warnings = append(warnings, fmt.Sprintf("key %q: expected %s, got %s", key, field.Type, schemaTypeOf(v)))
+			continue
+		}
+		if len(field.Enum) > 0 && !slices.ContainsFunc(field.Enum, func(e any) bool { return fmt.Sprint(e) == fmt.Sprint(v) }) {
+			warnings = append(warnings, fmt.Sprintf("key %q: value %v is not one of %v", key, v, field.Enum))
+		}
+	}
+	return warnings
+}
+
+// hasSchemaType reports whether v is of the schema type typ.
+func hasSchemaType(v any, typ string) bool {
+	actual := schemaTypeOf(v)
+	return actual == typ || (typ == "number" && actual == "integer")
+}
+
+// schemaTypeOf returns the schema type name of a parsed frontmatter value.
+func schemaTypeOf(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return "null"
+	case string:
+		return "string"
+	case bool:
+		return "boolean"
+	case int, int64, uint64:
+		return "integer"
+	case float64:
+		if v == float64(int64(v)) {
+			return "integer"
+		}
+		return "number"
+	case []any:
+		return "array"
+	case map[string]any:
+		return "object"
+	case time.Time:
+		return "datetime"
+	}
+	return fmt.Sprintf("%T", v)
+}
@@ -0,0 +1,99 @@
+package mcpmds
+
+import (
+	"context"
+	"reflect"
+	"slices"
+	"testing"
+	"testing/fstest"
+)
+
+func Test_server_frontmatterSchema(t *testing.T) {
+	testFS := fstest.MapFS{
+		"complete.md": {Data: []byte("---\ntitle: Complete\nstatus: draft\n---\n")},
+		"bare.md":     {Data: []byte("# Bare\n")},
+		"invalid.md":  {Data: []byte("---\ntitle: 42\nstatus: archived\n---\n")},
+	}
+
+	s := &Server{fs: testFS}
+	WithFrontmatterSchema(
+		map[string]any{"status": "published", "license": "MIT"},
+		FrontmatterSchema{
+			"title":   {Type: "string", Required: true},
+			"status":  {Type: "string", Enum: []any{"draft", "published"}},
+			"license": {Type: "string"},
+		},
+	)(s)
+
+	tests := []struct {
+		name            string
+		path            string
+		wantFrontmatter map[string]any
+		wantWarnings    []string
+	}{
+		{
+			name:            "Defaulted keys",
+			path:            "complete.md",
+			wantFrontmatter: map[string]any{"title": "Complete", "status": "draft", "license": "MIT"},
+		},
+		{
+			name:            "Missing required key",
+			path:            "bare.md",
+			wantFrontmatter: map[string]any{"status": "published", "license": "MIT"},
+			wantWarnings:    []string{`missing required key "title"`},
+		},
+		{
+			name:            "Invalid values",
+			path:            "invalid.md",
+			wantFrontmatter: map[string]any{"title": uint64(42), "status": "archived", "license": "MIT"},
+			wantWarnings: []string{
+				`key "status": value archived is not one of [draft published]`,
+				`key "title": expected string, got integer`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := s.readMarkdownFile(context.Background(), &readMarkdownFileRequest{Path: tt.path})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got.Frontmatter, tt.wantFrontmatter) {
+				t.Errorf("Frontmatter = %#v, want %#v", got.Frontmatter, tt.wantFrontmatter)
+			}
+			if !slices.Equal(got.Warnings, tt.wantWarnings) {
+				t.Errorf("Warnings = %q, want %q", got.Warnings, tt.wantWarnings)
+			}
+		})
+	}
+}
+
+func Test_server_frontmatterSchema_hiddenDefaults(t *testing.T) {
+	testFS := fstest.MapFS{"a.md": {Data: []byte("---\ntitle: A\n---\n")}}
+	defaults := map[string]any{
+		"owner":  "team",
+		"secret": "token",
+		"meta":   map[string]any{"internal": "x", "public": "y"},
+	}
+	s := newServer("test", "test", testFS,
+		WithFrontmatterSchema(defaults, nil),
+		WithExcludeFrontmatter("owner", "meta.internal"),
+		WithRedactFrontmatter("secret"),
+	)
+
+	for range 2 {
+		got, err := s.readMarkdownFile(context.Background(), &readMarkdownFileRequest{Path: "a.md"})
+		if err != nil {
+			t.Fatalf("readMarkdownFile() error = %v", err)
+		}
+		want := map[string]any{"title": "A", "secret": redactedValue, "meta": map[string]any{"public": "y"}}
+		if !reflect.DeepEqual(got.Frontmatter, want) {
+			t.Errorf("readMarkdownFile() frontmatter = %v, want %v", got.Frontmatter, want)
+		}
+	}
+	// Filtering the defaulted frontmatter leaves the defaults themselves intact.
+	if _, ok := defaults["meta"].(map[string]any)["internal"]; !ok {
+		t.Error("defaults lost meta.internal")
+	}
+}
@@ -0,0 +1,121 @@
+package mcpmds
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/Warashi/go-modelcontextprotocol/jsonschema"
+	"github.com/Warashi/go-modelcontextprotocol/mcp"
+)
+
+// snippetRadius is the number of bytes of context kept on each side of the
+// first match in a search snippet.
+const snippetRadius = 40
+
+func (s *Server) searchMarkdownFilesTool() mcp.Tool[*searchMarkdownFilesRequest, *searchMarkdownFilesResponse] {
+	return mcp.NewToolFunc(
+		fmt.Sprintf("search_%s_markdown_files", s.name),
+		fmt.Sprintf("Search the body text of markdown files managed by %s, returning each matching file with its match count and a snippet around the first match", s.name),
+		jsonschema.Object{
+			Properties: map[string]jsonschema.Schema{
+				"query": jsonschema.String{
+					Description: "The text to search for",
+				},
+				"case_sensitive": jsonschema.Boolean{
+					Description: "Match letter case exactly; by default the search ignores case",
+				},
+				"max_results": jsonschema.Integer{
+					Description: "The maximum number of results to return; defaults to the server's limit",
+				},
+			},
+			Required: []string{"query"},
+		},
+		s.searchMarkdownFiles,
+	)
+}
+
+type searchMarkdownFilesRequest struct {
+	Query         string `json:"query" jsonschema:"required"`
+	CaseSensitive bool   `json:"case_sensitive"`
+	MaxResults    int    `json:"max_results"`
+}
+
+type searchMarkdownFilesResponse struct {
+	Results []searchResult `json:"results"`
+	// Truncated reports whether more files matched than were returned.
+	Truncated bool `json:"truncated"`
+}
+
+// searchResult is a markdown file whose body matches a search query.
+type searchResult struct {
+	// Path is the relative path to the markdown file.
+	Path string `json:"path"`
+	// Matches is the number of non-overlapping matches in the body.
+	Matches int `json:"matches"`
+	// Snippet is the text surrounding the first match, on a single line.
+	Snippet string `json:"snippet"`
+}
+
+func (s *Server) searchMarkdownFiles(ctx context.Context, request *searchMarkdownFilesRequest) (*searchMarkdownFilesResponse, error) {
+	if request.Query == "" {
+		return nil, errors.New("query is required")
+	}
+	limit, err := s.searchLimit(request.MaxResults)
+	if err != nil {
+		return nil, err
+	}
+	expr := regexp.QuoteMeta(request.Query)
+	if !request.CaseSensitive {
+		expr = "(?i)" + expr
+	}
+	pattern := regexp.MustCompile(expr)
+
+	resp := &searchMarkdownFilesResponse{Results: []searchResult{}}
+	for f := range s.markdownFiles(ctx) {
+		content, err := s.readMarkdown(f.Path)
+		if err != nil {
+			return nil, err
+		}
+		_, _, body := s.splitFrontmatter(content)
+		matches := pattern.FindAllIndex(body, -1)
+		if len(matches) == 0 {
+			continue
+		}
+		if len(resp.Results) == limit {
+			resp.Truncated = true
+			break
+		}
+		resp.Results = append(resp.Results, searchResult{
+			Path:    f.Path,
+			Matches: len(matches),
+			Snippet: snippet(body, matches[0][0], matches[0][1]),
+		})
+	}
+	return resp, nil
+}
+
+// snippet returns the text of body within snippetRadius bytes of the match
+// at [start, end), widened to rune boundaries and with whitespace runs
+// collapsed to single spaces. An ellipsis marks each side that was cut.
+func snippet(body []byte, start, end int) string {
+	from := max(0, start-snippetRadius)
+	for from > 0 && !utf8.RuneStart(body[from]) {
+		from--
+	}
+	to := min(len(body), end+snippetRadius)
+	for to < len(body) && !utf8.RuneStart(body[to]) {
+		to++
+	}
+	text := strings.Join(strings.Fields(string(body[from:to])), " ")
+	if from > 0 {
+		text = "…" + text
+	}
+	if to < len(body) {
+		text += "…"
+	}
+	return text
+}
@@ -0,0 +1,72 @@
+package mcpmds
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func Test_server_searchMarkdownFiles(t *testing.T) {
+	long := strings.Repeat("a", 50) + " Deprecation notice " + strings.Repeat("b", 50)
+	testFS := fstest.MapFS{
+		"api.md":     {Data: []byte("---\ntitle: API\n---\n" + long + "\n")},
+		"meta.md":    {Data: []byte("---\ndeprecation: soon\n---\nNothing to see here.\n")},
+		"short.md":   {Data: []byte("The deprecation of v1.\nAnother\ndeprecation.\n")},
+		"unicode.md": {Data: []byte(strings.Repeat("é", 30) + "deprecation\n")},
+	}
+	s := &Server{fs: testFS}
+
+	tests := []struct {
+		name    string
+		request *searchMarkdownFilesRequest
+		want    []searchResult
+		wantErr bool
+	}{
+		{
+			name:    "Case-insensitive search skips frontmatter",
+			request: &searchMarkdownFilesRequest{Query: "deprecation"},
+			want: []searchResult{
+				{Path: "api.md", Matches: 1, Snippet: "…" + strings.Repeat("a", 39) + " Deprecation notice " + strings.Repeat("b", 32) + "…"},
+				{Path: "short.md", Matches: 2, Snippet: "The deprecation of v1. Another deprecation."},
+				{Path: "unicode.md", Matches: 1, Snippet: "…" + strings.Repeat("é", 20) + "deprecation"},
+			},
+		},
+		{
+			name:    "Case-sensitive search",
+			request: &searchMarkdownFilesRequest{Query: "Deprecation", CaseSensitive: true},
+			want: []searchResult{
+				{Path: "api.md", Matches: 1, Snippet: "…" + strings.Repeat("a", 39) + " Deprecation notice " + strings.Repeat("b", 32) + "…"},
+			},
+		},
+		{
+			name:    "No matches",
+			request: &searchMarkdownFilesRequest{Query: "title"},
+			want:    []searchResult{},
+		},
+		{
+			name:    "Empty query",
+			request: &searchMarkdownFilesRequest{},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := s.searchMarkdownFiles(context.Background(), tt.request)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected an error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("searchMarkdownFiles() error = %v", err)
+			}
+			if !reflect.DeepEqual(got.Results, tt.want) {
+				t.Errorf("searchMarkdownFiles()\n got = %q,\nwant = %q", got.Results, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,137 @@
+package mcpmds
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"iter"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/Warashi/go-modelcontextprotocol/jsonschema"
+	"github.com/Warashi/go-modelcontextprotocol/mcp"
+)
+
+func (s *Server) searchAllTool() mcp.Tool[*searchAllRequest, *searchAllResponse] {
+	return mcp.NewToolFunc(
+		fmt.Sprintf("search_all_%s", s.name),
+		fmt.Sprintf("Search the frontmatter values and body text of markdown files managed by %s for a query, case-insensitively", s.name),
+		jsonschema.Object{
+			Properties: map[string]jsonschema.Schema{
+				"query": jsonschema.String{
+					Description: "The text to search for",
+				},
+				"max_results": jsonschema.Integer{
+					Description: "The maximum number of results to return; defaults to the server's limit",
+				},
+			},
+			Required: []string{"query"},
+		},
+		s.searchAll,
+	)
+}
+
+type searchAllRequest struct {
+	Query      string `json:"query" jsonschema:"required"`
+	MaxResults int    `json:"max_results"`
+}
+
+type searchAllResponse struct {
+	Matches []searchMatch `json:"matches"`
+	// Truncated reports whether more matches were found than were returned.
+	Truncated bool `json:"truncated"`
+}
+
+// searchMatch is a place in a markdown file where a query was found.
+type searchMatch struct {
+	// Path is the relative path to the markdown file.
+	Path string `json:"path"`
+	// Location is "frontmatter" or "body".
+	Location string `json:"location"`
+	// Key is the dotted frontmatter key holding the match, for frontmatter matches.
+	Key string `json:"key,omitempty"`
+	// Line is the 1-based line number within the body, for body matches.
+	Line int `json:"line,omitempty"`
+	// Text is the matching frontmatter value or body line.
+	Text string `json:"text"`
+}
+
+func (s *Server) searchAll(ctx context.Context, request *searchAllRequest) (*searchAllResponse, error) {
+	if request.Query == "" {
+		return nil, errors.New("query is required")
+	}
+	limit, err := s.searchLimit(request.MaxResults)
+	if err != nil {
+		return nil, err
+	}
+	query := strings.ToLower(request.Query)
+	resp := &searchAllResponse{Matches: []searchMatch{}}
+	add := func(m searchMatch) bool {
+		if len(resp.Matches) == limit {
+			resp.Truncated = true
+			return false
+		}
+		resp.Matches = append(resp.Matches, m)
+		return true
+	}
+	for f := range s.markdownFiles(ctx) {
+		for key, value := range flattenFrontmatter("", f.Frontmatter) {
+			if strings.Contains(strings.ToLower(value), query) && !add(searchMatch{Path: f.Path, Location: "frontmatter", Key: key, Text: value}) {
+				return resp, nil
+			}
+		}
+
+		content, err := s.readMarkdown(f.Path)
+		if err != nil {
+			return nil, err
+		}
+		_, _, body := s.splitFrontmatter(content)
+		for line := range markdownLines(body) {
+			if strings.Contains(strings.ToLower(line.Text), query) && !add(searchMatch{Path: f.Path, Location: "body", Line: line.Number, Text: strings.TrimSpace(line.Text)}) {
+				return resp, nil
+			}
+		}
+	}
+	return resp, nil
+}
+
+// flattenFrontmatter returns the scalar values of frontmatter as strings keyed
+// by their dotted key path, in key order. List elements share the key of the list.
+func flattenFrontmatter(prefix string, frontmatter map[string]any) iter.Seq2[string, string] {
+	return func(yield func(string, string) bool) {
+		for _, key := range slices.Sorted(maps.Keys(frontmatter)) {
+			path := key
+			if prefix != "" {
+				path = prefix + "." + key
+			}
+			if !flattenValue(path, frontmatter[key], yield) {
+				return
+			}
+		}
+	}
+}
+
+// flattenValue yields the scalar values within v under key, reporting false if
+// yield asked to stop.
+func flattenValue(key string, v any, yield func(string, string) bool) bool {
+	switch v := v.(type) {
+	case nil:
+		return true
+	case map[string]any:
+		for k, e := range flattenFrontmatter(key, v) {
+			if !yield(k, e) {
+				return false
+			}
+		}
+		return true
+	case []any:
+		for _, e := range v {
+			if !flattenValue(key, e, yield) {
+				return false
+			}
+		}
+		return true
+	}
+	return yield(key, fmt.Sprint(v))
+}
@@ -0,0 +1,61 @@
+package mcpmds
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"testing/fstest"
+)
+
+func Test_server_searchAll(t *testing.T) {
+	testFS := fstest.MapFS{
+		"meta.md": {Data: []byte("---\ntitle: Kubernetes basics\nseries:\n  name: Ops\ntags: [containers, kubernetes]\n---\n# Basics\n")},
+		"body.md": {Data: []byte("---\ntitle: Deploying\n---\n# Deploying\n\nUse Kubernetes to deploy.\n")},
+		"both.md": {Data: []byte("---\nsummary: About kubernetes\n---\nKUBERNETES everywhere\n")},
+		"none.md": {Data: []byte("# Nothing\n")},
+	}
+
+	s := &Server{fs: testFS}
+
+	tests := []struct {
+		name  string
+		query string
+		want  []searchMatch
+	}{
+		{
+			name:  "Frontmatter, body, and both",
+			query: "kubernetes",
+			want: []searchMatch{
+				{Path: "body.md", Location: "body", Line: 3, Text: "Use Kubernetes to deploy."},
+				{Path: "both.md", Location: "frontmatter", Key: "summary", Text: "About kubernetes"},
+				{Path: "both.md", Location: "body", Line: 1, Text: "KUBERNETES everywhere"},
+				{Path: "meta.md", Location: "frontmatter", Key: "tags", Text: "kubernetes"},
+				{Path: "meta.md", Location: "frontmatter", Key: "title", Text: "Kubernetes basics"},
+			},
+		},
+		{
+			name:  "Nested frontmatter key",
+			query: "ops",
+			want: []searchMatch{
+				{Path: "meta.md", Location: "frontmatter", Key: "series.name", Text: "Ops"},
+			},
+		},
+		{
+			name:  "No match",
+			query: "absent",
+			want:  []searchMatch{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := s.searchAll(context.Background(), &searchAllRequest{Query: tt.query})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got.Matches, tt.want) {
+				t.Errorf("searchAll() = %#v, want %#v", got.Matches, tt.want)
+			}
+		})
+	}
+}
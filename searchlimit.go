@@ -0,0 +1,31 @@
+package mcpmds
+
+import "errors"
+
+// defaultMaxSearchResults is the number of search results returned when
+// neither the request nor WithMaxSearchResults sets a cap.
+const defaultMaxSearchResults = 100
+
+// WithMaxSearchResults sets the number of results the search tools return
+// when a request does not give max_results. Results beyond the cap are
+// dropped and the response is flagged as truncated. Defaults to 100.
+func WithMaxSearchResults(n int) ServerOption {
+	return func(s *Server) {
+		s.maxSearchResults = n
+	}
+}
+
+// searchLimit returns the result cap for a search requesting maxResults,
+// where 0 selects the server's default.
+func (s *Server) searchLimit(maxResults int) (int, error) {
+	if maxResults < 0 {
+		return 0, errors.New("max_results must not be negative")
+	}
+	if maxResults > 0 {
+		return maxResults, nil
+	}
+	if s.maxSearchResults > 0 {
+		return s.maxSearchResults, nil
+	}
+	return defaultMaxSearchResults, nil
+}
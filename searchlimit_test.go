@@ -0,0 +1,67 @@
+package mcpmds
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"testing/fstest"
+)
+
+func Test_server_searchMaxResults(t *testing.T) {
+	testFS := fstest.MapFS{}
+	for i := range 5 {
+		testFS[fmt.Sprintf("doc%d.md", i)] = &fstest.MapFile{Data: []byte("---\ntitle: needle\n---\nneedle\n")}
+	}
+
+	tests := []struct {
+		name       string
+		opts       []ServerOption
+		maxResults int
+		// Each file matches once in the body search and twice in search_all.
+		wantFiles            int
+		wantFilesTruncated   bool
+		wantMatches          int
+		wantMatchesTruncated bool
+		wantErr              bool
+	}{
+		{name: "Under the cap", maxResults: 10, wantFiles: 5, wantMatches: 10},
+		{name: "Exactly the cap", maxResults: 5, wantFiles: 5, wantMatches: 5, wantMatchesTruncated: true},
+		{name: "Over the cap", maxResults: 2, wantFiles: 2, wantFilesTruncated: true, wantMatches: 2, wantMatchesTruncated: true},
+		{name: "Server default", opts: []ServerOption{WithMaxSearchResults(3)}, wantFiles: 3, wantFilesTruncated: true, wantMatches: 3, wantMatchesTruncated: true},
+		{name: "Request overrides server default", opts: []ServerOption{WithMaxSearchResults(3)}, maxResults: 20, wantFiles: 5, wantMatches: 10},
+		{name: "Built-in default", wantFiles: 5, wantMatches: 10},
+		{name: "Negative", maxResults: -1, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newServer("test", "test", testFS, tt.opts...)
+
+			files, err := s.searchMarkdownFiles(context.Background(), &searchMarkdownFilesRequest{Query: "needle", MaxResults: tt.maxResults})
+			if tt.wantErr {
+				if err == nil {
+					t.Error("searchMarkdownFiles() succeeded, want error")
+				}
+			} else if err != nil {
+				t.Fatalf("searchMarkdownFiles() error = %v", err)
+			} else {
+				if len(files.Results) != tt.wantFiles || files.Truncated != tt.wantFilesTruncated {
+					t.Errorf("searchMarkdownFiles() = %d results, truncated %v, want %d, %v", len(files.Results), files.Truncated, tt.wantFiles, tt.wantFilesTruncated)
+				}
+			}
+
+			all, err := s.searchAll(context.Background(), &searchAllRequest{Query: "needle", MaxResults: tt.maxResults})
+			if tt.wantErr {
+				if err == nil {
+					t.Error("searchAll() succeeded, want error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("searchAll() error = %v", err)
+			}
+			if len(all.Matches) != tt.wantMatches || all.Truncated != tt.wantMatchesTruncated {
+				t.Errorf("searchAll() = %d matches, truncated %v, want %d, %v", len(all.Matches), all.Truncated, tt.wantMatches, tt.wantMatchesTruncated)
+			}
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package mcpmds
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Warashi/go-modelcontextprotocol/jsonschema"
+	"github.com/Warashi/go-modelcontextprotocol/mcp"
+)
+
+// defaultRootSection is the section holding files at the root when no bucket is given.
+const defaultRootSection = "."
+
+func (s *Server) sectionsTool() mcp.Tool[*sectionsRequest, *sectionsResponse] {
+	return mcp.NewToolFunc(
+		fmt.Sprintf("sections_%s", s.name),
+		fmt.Sprintf("List markdown files managed by %s grouped by their top-level directory", s.name),
+		jsonschema.Object{
+			Properties: map[string]jsonschema.Schema{
+				"root_bucket": jsonschema.String{
+					Description: "The section name for files at the root; defaults to \".\"",
+				},
+			},
+		},
+		s.sections,
+	)
+}
+
+type sectionsRequest struct {
+	RootBucket string `json:"root_bucket"`
+}
+
+type sectionsResponse struct {
+	// Sections maps each top-level directory name to the files beneath it.
+	Sections map[string][]markdownFileInfo `json:"sections"`
+}
+
+func (s *Server) sections(ctx context.Context, request *sectionsRequest) (*sectionsResponse, error) {
+	bucket := request.RootBucket
+	if bucket == "" {
+		bucket = defaultRootSection
+	}
+	sections := map[string][]markdownFileInfo{}
+	for f := range s.markdownFiles(ctx) {
+		section, _, ok := strings.Cut(f.Path, "/")
+		if !ok {
+			section = bucket
+		}
+		sections[section] = append(sections[section], f)
+	}
+	return &sectionsResponse{Sections: sections}, nil
+}
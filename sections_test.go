@@ -0,0 +1,69 @@
+package mcpmds
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"testing"
+	"testing/fstest"
+)
+
+func Test_server_sections(t *testing.T) {
+	testFS := fstest.MapFS{
+		"README.md":            {Data: []byte("# Readme\n")},
+		"index.md":             {Data: []byte("# Index\n")},
+		"guides/setup.md":      {Data: []byte("# Setup\n")},
+		"guides/advanced/x.md": {Data: []byte("# X\n")},
+		"reference/api.md":     {Data: []byte("# API\n")},
+		"reference/notes.txt":  {Data: []byte("not markdown\n")},
+		"empty/nothing.txt":    {Data: []byte("not markdown\n")},
+	}
+
+	s := &Server{fs: testFS}
+
+	tests := []struct {
+		name    string
+		request *sectionsRequest
+		want    map[string][]string
+	}{
+		{
+			name:    "Default root bucket",
+			request: &sectionsRequest{},
+			want: map[string][]string{
+				".":         {"README.md", "index.md"},
+				"guides":    {"guides/advanced/x.md", "guides/setup.md"},
+				"reference": {"reference/api.md"},
+			},
+		},
+		{
+			name:    "Custom root bucket",
+			request: &sectionsRequest{RootBucket: "(root)"},
+			want: map[string][]string{
+				"(root)":    {"README.md", "index.md"},
+				"guides":    {"guides/advanced/x.md", "guides/setup.md"},
+				"reference": {"reference/api.md"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := s.sections(context.Background(), tt.request)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !slices.Equal(slices.Sorted(maps.Keys(got.Sections)), slices.Sorted(maps.Keys(tt.want))) {
+				t.Fatalf("sections() keys = %v, want %v", slices.Sorted(maps.Keys(got.Sections)), slices.Sorted(maps.Keys(tt.want)))
+			}
+			for section, want := range tt.want {
+				var paths []string
+				for _, f := range got.Sections[section] {
+					paths = append(paths, f.Path)
+				}
+				if !slices.Equal(paths, want) {
+					t.Errorf("sections()[%q] = %v, want %v", section, paths, want)
+				}
+			}
+		})
+	}
+}
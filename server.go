@@ -4,20 +4,29 @@ package mcpmds
 
 import (
 	"bytes"
+	"cmp"
 	"context"
-	"encoding/json"
 	"errors"
 	"fmt"
 	"io/fs"
 	"iter"
+	"net/http"
+	"net/url"
 	"path/filepath"
 	"slices"
+	"strconv"
 	"strings"
+	"sync"
+	"text/template"
+	"time"
+	"unicode"
+	"unicode/utf8"
 
-	"github.com/BurntSushi/toml"
 	"github.com/Warashi/go-modelcontextprotocol/jsonschema"
 	"github.com/Warashi/go-modelcontextprotocol/mcp"
-	"github.com/goccy/go-yaml"
+	"github.com/Warashi/go-modelcontextprotocol/transport"
+	"golang.org/x/text/encoding"
+	"golang.org/x/time/rate"
 )
 
 // Server implements the core logic for serving markdown files via MCP.
@@ -27,7 +36,102 @@ type Server struct {
 	description        string
 	fs                 fs.FS
 	opts               []mcp.ServerOption
+	mcpServer          *mcp.Server
+	extensions         map[string]bool
+	mimeTypes          map[string]string
+	includeGlobs       []string
+	excludeGlobs       []string
+	gitignore          bool
+	sourceEncoding     encoding.Encoding
+	maxSearchResults   int
 	excludeFrontmatter []string
+	redactFrontmatter  []string
+	hideDraftsKey      string
+	walkRetryAttempts  int
+	walkRetryBackoff   time.Duration
+
+	rewriteLinksToResourceURIs bool
+	createdTime                bool
+	excerptLength              int
+	runeCount                  bool
+	rootRelativeLinks          bool
+	lowercaseTags              bool
+	tagsKey                    string
+	resourceAnnotations        bool
+	frontmatterMeta            bool
+	stripFrontmatterContent    bool
+	canonicalPaths             bool
+	wikilinks                  bool
+	deduplicateByHash          bool
+	sitemap                    bool
+	sitemapBaseURL             string
+	manifest                   bool
+	permalinkIndex             bool
+	normalizeFrontmatter       bool
+	readOnlyEnumerated         bool
+	lazyResources              bool
+	strictFrontmatter          bool
+	outlineCache               bool
+
+	descriptionKeys     []string
+	descriptionTemplate *template.Template
+
+	allowlistFile string
+	allowlistMu   sync.Mutex
+	allowlist     *allowlist
+
+	globalDefaultsFile string
+	globalDefaultsMu   sync.Mutex
+	globalDefaults     *globalDefaults
+
+	aliasMu         sync.Mutex
+	aliasIndexCache map[string]string
+
+	navigationFile      string
+	navigationOrderFile string
+
+	frontmatterDelimiters []frontmatterDelimiter
+
+	mounts []mount
+
+	maxFileSize int64
+
+	trackAccess bool
+	accessMu    sync.Mutex
+	lastRead    map[string]time.Time
+
+	outlineMu sync.Mutex
+	outlines  map[string]cachedOutline
+
+	cache       bool
+	cacheMu     sync.Mutex
+	cachedFiles map[string]cachedFile
+
+	savedFilters map[string]Filter
+
+	frontmatterDefaults map[string]any
+	frontmatterSchema   FrontmatterSchema
+	validator           func(path string, frontmatter map[string]any) error
+
+	authorizer func(*http.Request) bool
+	etag       bool
+
+	rateLimit          rate.Limit
+	rateBurst          int
+	rateLimiter        *rate.Limiter
+	rateMu             sync.Mutex
+	clientRateLimiters map[string]*rate.Limiter
+
+	watch       bool
+	watchMu     sync.Mutex
+	sessions    map[uint64]transport.Session
+	stopWatch   func()
+	resourcesMu sync.Mutex
+	resources   []annotatedResource
+
+	frontmatterMu        sync.Mutex
+	frontmatterSnapshots map[string]frontmatterSnapshot
+	frontmatterChanges   []frontmatterChange
 }
 
 // ServerOption is a function that configures a Server.
@@ -41,17 +145,46 @@ func WithMCPOptions(opts ...mcp.ServerOption) ServerOption {
 }
 
 // WithExcludeFrontmatter sets the frontmatter keys to exclude from the resource description.
+// A dotted key such as "meta.internal.reviewer" removes a nested key, unless
+// the frontmatter has a top-level key with the dotted name itself. Parent
+// maps left empty are removed too.
 func WithExcludeFrontmatter(keys ...string) ServerOption {
 	return func(s *Server) {
 		s.excludeFrontmatter = append(s.excludeFrontmatter, keys...)
 	}
 }
 
+// redactedValue replaces the values of redacted frontmatter keys.
+const redactedValue = "***"
+
+// WithRedactFrontmatter sets the frontmatter keys whose values are masked as "***".
+// Unlike WithExcludeFrontmatter, redacted keys remain visible in listings and reads.
+func WithRedactFrontmatter(keys ...string) ServerOption {
+	return func(s *Server) {
+		s.redactFrontmatter = append(s.redactFrontmatter, keys...)
+	}
+}
+
 // New creates a new MCP server instance configured to serve markdown files from
 // the provided filesystem.
 // It initializes the server with a name, description, the filesystem, and optional
 // mcp.ServerOption configurations.
 func New(name, description string, fs fs.FS, opts ...ServerOption) (*mcp.Server, error) {
+	return newServer(name, description, fs, opts...).server()
+}
+
+// ServeStdio serves markdown files from the provided filesystem over stdin and
+// stdout until ctx is canceled or the input ends.
+func ServeStdio(ctx context.Context, name, description string, fs fs.FS, opts ...ServerOption) error {
+	s := newServer(name, description, fs, opts...)
+	srv, err := s.server()
+	if err != nil {
+		return err
+	}
+	return s.sessionHandler(srv).HandleSession(ctx, 0, transport.NewStdio())
+}
+
+func newServer(name, description string, fs fs.FS, opts ...ServerOption) *Server {
 	s := &Server{
 		name:        name,
 		description: description,
@@ -60,36 +193,144 @@ func New(name, description string, fs fs.FS, opts ...ServerOption) (*mcp.Server,
 	for _, opt := range opts {
 		opt(s)
 	}
-	return s.server()
+	if len(s.mounts) > 0 {
+		s.fs = newMountFS(s.fs, s.mounts)
+	}
+	return s
 }
 
 func (s *Server) server() (*mcp.Server, error) {
+	if err := s.validateMounts(); err != nil {
+		return nil, err
+	}
+	if !s.lazyResources {
+		if _, err := s.aliasIndex(context.Background()); err != nil {
+			return nil, err
+		}
+	}
+	if err := s.validateSavedFilters(); err != nil {
+		return nil, err
+	}
+	if err := s.validateGlobs(); err != nil {
+		return nil, err
+	}
+	if err := s.validateFrontmatterDelimiters(); err != nil {
+		return nil, err
+	}
 	opts, err := s.listResourcesOption()
 	if err != nil {
 		return nil, err
 	}
 	opts = append(opts,
 		mcp.WithResourceReader(s.resourceReader()),
-		mcp.WithTool(s.listMarkdownFilesTool()),
-		mcp.WithTool(s.readMarkdownFileTool()),
+		withTool(s, s.listMarkdownFilesTool()),
+		withTool(s, s.readMarkdownFileTool()),
+		withTool(s, s.staleMarkdownFilesTool()),
+		withTool(s, s.linkGraphTool()),
+		withTool(s, s.tasksMarkdownFileTool()),
+		withTool(s, s.folderListingTool()),
+		withTool(s, s.titleUniquenessTool()),
+		withTool(s, s.jsonlExportTool()),
+		withTool(s, s.aliasesTool()),
+		withTool(s, s.changelogTool()),
+		withTool(s, s.corpusOutlineTool()),
+		withTool(s, s.sectionsTool()),
+		withTool(s, s.readabilityMarkdownFileTool()),
+		withTool(s, s.externalDomainsTool()),
+		withTool(s, s.savedFilterTool()),
+		withTool(s, s.byAuthorTool()),
+		withTool(s, s.searchAllTool()),
+		withTool(s, s.listMarkdownLinksTool()),
+		withTool(s, s.bundleByTagTool()),
+		withTool(s, s.siblingsTool()),
+		withTool(s, s.schemaCoverageTool()),
+		withTool(s, s.readRangeTool()),
+		withTool(s, s.validateCustomTool()),
+		withTool(s, s.extremesTool()),
+		withTool(s, s.searchMarkdownFilesTool()),
+		withTool(s, s.relatedTool()),
+		withTool(s, s.promptContextTool()),
+		withTool(s, s.learningPathTool()),
+		withTool(s, s.filterNumericTool()),
+		withTool(s, s.frontmatterChangesTool()),
+		withTool(s, s.queryMarkdownFilesTool()),
+		withTool(s, s.unseenTool()),
+		withTool(s, s.chunkTool()),
+		withTool(s, s.filesWithCodeTool()),
+		withTool(s, s.tagCooccurrenceTool()),
+		withTool(s, s.statsTool()),
+		withTool(s, s.navigationTool()),
+		withTool(s, s.inheritedFrontmatterTool()),
+		withTool(s, s.missingTranslationsTool()),
+		withTool(s, s.coerceFrontmatterTool()),
+		withTool(s, s.capabilitiesTool()),
+		s.promptsOption(),
+		s.initializeOption(),
 	)
 	opts = append(opts, s.opts...)
-	return mcp.NewServer(s.name, s.description, opts...)
+	srv, err := mcp.NewServer(s.name, s.description, opts...)
+	if err != nil {
+		return nil, err
+	}
+	s.mcpServer = srv
+	return srv, nil
+}
+
+// initializeOption returns the option answering initialize with the
+// capabilities of the mcp package extended with the server's prompts and, in
+// watch mode, resource list_changed notifications.
+func (s *Server) initializeOption() mcp.ServerOption {
+	return func(srv *mcp.Server) {
+		mcp.WithCustomHandlerFunc("initialize", func(ctx context.Context, request *mcp.Request[mcp.InitializationRequestParams]) (*mcp.Result[mcp.InitializationResponseData], error) {
+			result, err := srv.Initialize(ctx, request)
+			if err != nil {
+				return nil, err
+			}
+			result.Data.Capabilities.Prompts = &mcp.PromptsCapabilities{}
+			if s.watch {
+				result.Data.Capabilities.Resources = &mcp.ResourcesCapabilities{ListChanged: true}
+			}
+			return result, nil
+		})(srv)
+	}
 }
 
 func (s *Server) listMarkdownFilesTool() mcp.Tool[*listMarkdownFilesRequest, *listMarkdownFilesResponse] {
 	return mcp.NewToolFunc(
 		fmt.Sprintf("list_%s_markdown_files", s.name),
 		fmt.Sprintf("List all markdown files managed by %s", s.name),
-		jsonschema.Object{},
+		jsonschema.Object{
+			Properties: map[string]jsonschema.Schema{
+				"limit": jsonschema.Integer{
+					Description: "The maximum number of files to return; 0 returns all remaining files",
+				},
+				"cursor": jsonschema.String{
+					Description: "The next_cursor of a previous call, to continue listing from where it stopped",
+				},
+				"sort_by": jsonschema.String{
+					Description: "The order of the files: \"path\", \"size\", or a frontmatter key such as \"date\", placing files without the key last; defaults to the navigation order if one is configured, or else \"path\"",
+				},
+				"descending": jsonschema.Boolean{
+					Description: "Whether to sort in descending order",
+				},
+			},
+		},
 		s.listMarkdownFiles,
 	)
 }
 
-type listMarkdownFilesRequest struct{}
+type listMarkdownFilesRequest struct {
+	Limit      int    `json:"limit"`
+	Cursor     string `json:"cursor"`
+	SortBy     string `json:"sort_by"`
+	Descending bool   `json:"descending"`
+}
 
 type listMarkdownFilesResponse struct {
 	Files []markdownFileInfo `json:"files"`
+	// NextCursor continues the listing after this page.
+	// It is empty when there are no more files.
+	NextCursor string `json:"next_cursor,omitempty"`
 }
 
 // markdownFileInfo holds metadata about a single markdown file.
@@ -98,94 +339,480 @@ type markdownFileInfo struct {
 	Path string `json:"path"`
 	// Size is the size of the markdown file in bytes.
 	Size int64 `json:"size"`
+	// ModTime is the modification time of the markdown file.
+	ModTime time.Time `json:"mod_time"`
+	// CreatedTime is the creation time of the markdown file.
+	// It is set only when enabled by WithCreatedTime and provided by the filesystem.
+	CreatedTime *time.Time `json:"created_time,omitempty"`
 	// Frontmatter is a map containing the parsed frontmatter of the markdown file.
 	// It can be nil if no frontmatter is found or parsable.
 	Frontmatter map[string]any `json:"frontmatter"`
+	// WordCount is the number of words in the body, excluding frontmatter,
+	// code, and markup.
+	WordCount int `json:"word_count"`
+	// ReadingTimeMinutes is the estimated reading time of the body at 200
+	// words per minute, rounded up.
+	ReadingTimeMinutes int `json:"reading_time_minutes"`
+	// RuneCount is the number of characters in the body, excluding frontmatter,
+	// as opposed to Size, which counts the bytes of the whole file.
+	// It is set only when enabled by WithRuneCount.
+	RuneCount *int `json:"rune_count,omitempty"`
+	// FrontmatterError is the error parsing the frontmatter of the markdown
+	// file, which leaves Frontmatter nil. It is never set with WithStrictFrontmatter,
+	// which fails instead.
+	FrontmatterError string `json:"frontmatter_error,omitempty"`
+	// Excerpt is a short preview of the markdown file.
+	// It is set only when enabled by WithExcerpt.
+	Excerpt string `json:"excerpt,omitempty"`
+	// LastRead is when the markdown file was last served.
+	// It is set only when enabled by WithTrackAccess and the file has been read.
+	LastRead *time.Time `json:"last_read,omitempty"`
+	// Duplicates are the paths of other files with identical content.
+	// It is set only when enabled by WithDeduplicateByHash.
+	Duplicates []string `json:"duplicates,omitempty"`
+	// Wikilinks are the wiki-links in the body with the files they resolve to.
+	// It is set only when enabled by WithWikilinks.
+	Wikilinks []fileWikiLink `json:"wikilinks,omitempty"`
+
+	// frontmatterKeys are the top-level frontmatter keys in source order,
+	// used to encode resource descriptions deterministically.
+	frontmatterKeys []string
 }
 
-func (s *Server) markdownFiles() iter.Seq[markdownFileInfo] {
+// markdownFiles returns the served markdown files in lexical order. The
+// sequence ends early once ctx is done.
+func (s *Server) markdownFiles(ctx context.Context) iter.Seq[markdownFileInfo] {
 	return func(yield func(markdownFileInfo) bool) {
-		fs.WalkDir(s.fs, ".", func(path string, d fs.DirEntry, err error) error {
-			if err != nil {
-				return err
-			}
-			if d.IsDir() {
-				return nil
-			}
-			if filepath.Ext(path) != ".md" {
-				return nil
-			}
-			info, err := s.readMarkdownInfo(path, d)
-			if err != nil {
-				return err
-			}
-			if !yield(info) {
-				return fs.SkipAll
-			}
+		s.walk(ctx, yield)
+	}
+}
+
+// collectMarkdownFiles returns the served markdown files for listings, with
+// files of identical content merged when enabled by WithDeduplicateByHash.
+// With WithStrictFrontmatter, it also reports the error that stopped the
+// enumeration, such as invalid frontmatter.
+func (s *Server) collectMarkdownFiles(ctx context.Context) ([]markdownFileInfo, error) {
+	files := []markdownFileInfo{}
+	err := s.walk(ctx, func(f markdownFileInfo) bool {
+		files = append(files, f)
+		return true
+	})
+	if err != nil && (s.strictFrontmatter || ctx.Err() != nil) {
+		return nil, err
+	}
+	if s.deduplicateByHash {
+		files = slices.Collect(s.deduplicate(slices.Values(files)))
+	}
+	return files, nil
+}
+
+// walk calls yield for each served markdown file in lexical order until yield
+// returns false, and returns the error that stopped the walk, if any.
+func (s *Server) walk(ctx context.Context, yield func(markdownFileInfo) bool) error {
+	ignore := s.newGitignore()
+	wikiLinks := s.newWikiLinkResolver()
+	return fs.WalkDir(s.fs, ".", func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		served, err := s.walkEntry(ignore, path, d)
+		if !served || err != nil {
+			return err
+		}
+		info, err := s.readCachedMarkdownInfo(ctx, path, d)
+		if !s.strictFrontmatter && isFrontmatterParseError(err) {
+			info, err = s.brokenMarkdownInfo(path, d, err)
+		}
+		if errors.Is(err, fs.ErrNotExist) || errors.Is(err, errRetriesExhausted) || errors.Is(err, errFileTooLarge) {
 			return nil
-		})
+		}
+		if err == nil {
+			err = wikiLinks.resolve(info.Wikilinks)
+		}
+		if err != nil {
+			return err
+		}
+		if !yield(info) {
+			return fs.SkipAll
+		}
+		return nil
+	})
+}
+
+func (s *Server) listMarkdownFiles(ctx context.Context, request *listMarkdownFilesRequest) (*listMarkdownFilesResponse, error) {
+	if request == nil {
+		request = &listMarkdownFilesRequest{}
+	}
+	if request.Limit < 0 {
+		return nil, errors.New("limit must not be negative")
+	}
+	offset := 0
+	if request.Cursor != "" {
+		n, err := strconv.Atoi(request.Cursor)
+		if err != nil || n < 0 {
+			return nil, fmt.Errorf("invalid cursor %q", request.Cursor)
+		}
+		offset = n
+	}
+	files, err := s.collectMarkdownFiles(ctx)
+	if err != nil {
+		return nil, err
 	}
+	slices.SortFunc(files, func(a, b markdownFileInfo) int {
+		return strings.Compare(a.Path, b.Path)
+	})
+	if request.SortBy == "" {
+		if err := s.sortByNavigationOrder(files); err != nil {
+			return nil, err
+		}
+	}
+	sortFiles(files, request.SortBy, request.Descending)
+	files = files[min(offset, len(files)):]
+	resp := &listMarkdownFilesResponse{Files: files}
+	if request.Limit > 0 && request.Limit < len(files) {
+		resp.Files = files[:request.Limit]
+		resp.NextCursor = strconv.Itoa(offset + request.Limit)
+	}
+	return resp, nil
 }
 
-func (s *Server) listMarkdownFiles(ctx context.Context, _ *listMarkdownFilesRequest) (*listMarkdownFilesResponse, error) {
-	return &listMarkdownFilesResponse{Files: slices.Collect(s.markdownFiles())}, nil
+// errNotServed is reported for files that exist but are hidden by the server's policy.
+// It matches fs.ErrNotExist so that hidden files are indistinguishable from missing ones.
+var errNotServed error = notServedError{}
+
+type notServedError struct{}
+
+func (notServedError) Error() string { return fs.ErrNotExist.Error() }
+
+func (notServedError) Is(target error) bool { return target == fs.ErrNotExist }
+
+// checkServed reports fs.ErrNotExist if the server's policy hides the file at
+// path regardless of its content.
+func (s *Server) checkServed(path string) error {
+	if s.readOnlyEnumerated && !s.isMarkdown(path) {
+		return &fs.PathError{Op: "read", Path: path, Err: errNotServed}
+	}
+	served, err := s.servedFile(s.newGitignore(), path)
+	if err != nil {
+		return err
+	}
+	if !served {
+		return &fs.PathError{Op: "read", Path: path, Err: errNotServed}
+	}
+	return nil
+}
+
+// servedFile reports whether the server's policy serves the file at path,
+// matching .gitignore files with ignore.
+func (s *Server) servedFile(ignore *gitignore, path string) (bool, error) {
+	if s.isGlobalDefaultsFile(path) || !s.globIncluded(path) || ignore.ignored(path, false) {
+		return false, nil
+	}
+	return s.allowed(path)
 }
 
+// walkEntry decides how walks of the served tree treat the entry d at path,
+// matching .gitignore files with ignore. It returns fs.SkipDir for
+// directories not to descend into, and reports whether a file is a served
+// markdown file.
+func (s *Server) walkEntry(ignore *gitignore, path string, d fs.DirEntry) (bool, error) {
+	if d.IsDir() {
+		if ignore.ignored(path, true) {
+			return false, fs.SkipDir
+		}
+		return false, s.walkGlobDir(path)
+	}
+	if !s.isMarkdown(path) {
+		return false, nil
+	}
+	return s.servedFile(ignore, path)
+}
+
+// markdownNotFoundError reports that the markdown file at Path is missing or
+// hidden by the server's policy, naming the file in the same words either way.
+type markdownNotFoundError struct {
+	Path string
+	// Err is the underlying error, matching fs.ErrNotExist.
+	Err error
+}
+
+func (e *markdownNotFoundError) Error() string {
+	return fmt.Sprintf("markdown file %q: %v", e.Path, fs.ErrNotExist)
+}
+
+func (e *markdownNotFoundError) Unwrap() error { return e.Err }
+
+// readMarkdown reads the markdown file at path, reporting fs.ErrNotExist for
+// files that exist but are hidden by the server's policy. Not-found errors
+// name the requested path; other I/O errors are returned as they are.
+func (s *Server) readMarkdown(path string) ([]byte, error) {
+	content, err := s.readServedMarkdown(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, &markdownNotFoundError{Path: path, Err: err}
+	}
+	return content, err
+}
+
+// readServedMarkdown reads the markdown file at path for readMarkdown.
+func (s *Server) readServedMarkdown(path string) ([]byte, error) {
+	if err := s.checkServed(path); err != nil {
+		return nil, err
+	}
+	return s.readMarkdownContent(path)
+}
+
+// readMarkdownContent reads the markdown file at path, whose path the caller
+// has checked against the server's policy, reporting fs.ErrNotExist if its
+// content hides it.
+func (s *Server) readMarkdownContent(path string) ([]byte, error) {
+	if err := s.checkFileSize(path); err != nil {
+		return nil, err
+	}
+	content, err := fs.ReadFile(s.fs, path)
+	if err != nil {
+		return nil, err
+	}
+	content, err = s.decodeSource(path, content)
+	if err != nil {
+		return nil, err
+	}
+	hidden, err := s.hidden(content)
+	if err != nil {
+		return nil, err
+	}
+	if hidden {
+		return nil, &fs.PathError{Op: "read", Path: path, Err: errNotServed}
+	}
+	return content, nil
+}
+
+// readMarkdownInfo reads the info of the file d at path, found by a walk that
+// has checked it with walkEntry.
 func (s *Server) readMarkdownInfo(path string, d fs.DirEntry) (markdownFileInfo, error) {
 	info, err := d.Info()
 	if err != nil {
 		return markdownFileInfo{}, err
 	}
-	content, err := fs.ReadFile(s.fs, path)
+	content, err := s.readMarkdownContent(path)
 	if err != nil {
 		return markdownFileInfo{}, err
 	}
-	frontmatter, err := s.readFrontmatter(content)
+	frontmatter, keys, err := s.readOrderedFrontmatter(content)
 	if err != nil {
 		return markdownFileInfo{}, err
 	}
-	return markdownFileInfo{
-		Path:        path,
-		Size:        info.Size(),
-		Frontmatter: frontmatter,
-	}, nil
+	_, _, body := s.splitFrontmatter(content)
+	words := wordCount(body)
+	fileInfo := markdownFileInfo{
+		Path:               path,
+		Size:               info.Size(),
+		ModTime:            info.ModTime(),
+		Frontmatter:        frontmatter,
+		WordCount:          words,
+		ReadingTimeMinutes: readingTimeMinutes(words),
+		frontmatterKeys:    keys,
+	}
+	if s.createdTime {
+		if created, ok := createdTime(info); ok {
+			fileInfo.CreatedTime = &created
+		}
+	}
+	if s.runeCount {
+		n := utf8.RuneCount(body)
+		fileInfo.RuneCount = &n
+	}
+	if s.excerptLength > 0 {
+		fileInfo.Excerpt = excerpt(frontmatter, body, s.excerptLength)
+	}
+	if s.wikilinks {
+		fileInfo.Wikilinks = bodyWikiLinks(body)
+	}
+	if s.trackAccess {
+		if lastRead, ok := s.lastReadTime(path); ok {
+			fileInfo.LastRead = &lastRead
+		}
+	}
+	return fileInfo, nil
 }
 
-func (s *Server) readFrontmatter(content []byte) (map[string]any, error) {
-	type unmarshaler struct {
-		Unmarshaler func([]byte, interface{}) error
-		Delimiter   string
-	}
-	unmarshalers := []unmarshaler{
-		{yaml.Unmarshal, "---\n"},
-		{toml.Unmarshal, "+++\n"},
+// frontmatterFormat describes a frontmatter syntax recognized at the top of a markdown file.
+type frontmatterFormat struct {
+	Name string
+	// Unmarshal parses a block, returning its top-level keys in source order.
+	Unmarshal func([]byte) (map[string]any, []string, error)
+	// Delimiter is the line opening and closing the frontmatter block. It may
+	// be followed by trailing spaces or tabs and any line terminator.
+	Delimiter string
+	// Close is the line closing the frontmatter block, if it differs from
+	// Delimiter.
+	Close string
+}
+
+// builtinFrontmatterFormats are the frontmatter formats recognized by default.
+var builtinFrontmatterFormats = []frontmatterFormat{
+	{Name: "yaml", Unmarshal: unmarshalYAML, Delimiter: "---"},
+	{Name: "toml", Unmarshal: unmarshalTOML, Delimiter: "+++"},
+	{Name: "json", Unmarshal: unmarshalJSON, Delimiter: ";;;"},
+}
+
+// frontmatterFormats returns the built-in frontmatter formats followed by the
+// custom delimiters added by WithFrontmatterDelimiter.
+func (s *Server) frontmatterFormats() []frontmatterFormat {
+	formats := slices.Clone(builtinFrontmatterFormats)
+	for _, d := range s.frontmatterDelimiters {
+		if f, ok := d.frontmatterFormat(); ok {
+			formats = append(formats, f)
+		}
 	}
+	return formats
+}
 
-	content = bytes.TrimSpace(content)
-	for _, u := range unmarshalers {
-		if bytes.HasPrefix(content, []byte(u.Delimiter)) {
-			start := bytes.Index(content, []byte(u.Delimiter))
-			if start == -1 {
-				continue
-			}
-			end := bytes.Index(content[start+len(u.Delimiter):], []byte("\n"+u.Delimiter))
-			if end == -1 {
-				continue
-			}
-			var frontmatter map[string]any
-			if err := u.Unmarshaler(content[start+len(u.Delimiter):start+len(u.Delimiter)+end], &frontmatter); err != nil {
-				return nil, err
-			}
-			for _, key := range s.excludeFrontmatter {
-				delete(frontmatter, key)
+// splitFrontmatter splits content into the raw frontmatter block between the
+// delimiters and the body following the closing delimiter.
+// If content has no frontmatter, format is nil and body is content itself.
+func (s *Server) splitFrontmatter(content []byte) (block []byte, format *frontmatterFormat, body []byte) {
+	trimmed := bytes.TrimLeftFunc(content, unicode.IsSpace)
+	first, rest, _ := bytes.Cut(trimmed, []byte("\n"))
+	for _, f := range s.frontmatterFormats() {
+		if !isDelimiterLine(first, f.Delimiter) {
+			continue
+		}
+		closing := cmp.Or(f.Close, f.Delimiter)
+		for offset := 0; offset < len(rest); {
+			line, _, found := bytes.Cut(rest[offset:], []byte("\n"))
+			if isDelimiterLine(line, closing) {
+				end := offset + len(line)
+				if found {
+					end++
+				}
+				block := bytes.TrimSuffix(bytes.TrimSuffix(rest[:offset], []byte("\n")), []byte("\r"))
+				return block, &f, rest[end:]
 			}
-			if len(frontmatter) == 0 {
-				return nil, nil
+			if !found {
+				break
 			}
-			return frontmatter, nil
+			offset += len(line) + 1
 		}
 	}
-	return nil, nil
+	return nil, nil, content
+}
+
+// isDelimiterLine reports whether line, without its trailing newline, is the
+// delimiter followed only by spaces, tabs, or a carriage return.
+func isDelimiterLine(line []byte, delimiter string) bool {
+	rest, ok := bytes.CutPrefix(line, []byte(delimiter))
+	return ok && len(bytes.Trim(rest, " \t\r")) == 0
+}
+
+// bodySize returns the size in bytes of the content following the frontmatter.
+func (s *Server) bodySize(content []byte) int64 {
+	_, _, body := s.splitFrontmatter(content)
+	return int64(len(body))
+}
+
+// parseFrontmatter parses the frontmatter of content merged over the global
+// defaults, without applying the server's exclusions.
+func (s *Server) parseFrontmatter(content []byte) (map[string]any, error) {
+	frontmatter, _, err := s.parseOrderedFrontmatter(content)
+	return frontmatter, err
+}
+
+// parseOrderedFrontmatter is parseFrontmatter, also returning the top-level
+// keys written in content in source order.
+func (s *Server) parseOrderedFrontmatter(content []byte) (map[string]any, []string, error) {
+	frontmatter, keys, err := s.unmarshalFrontmatter(content)
+	if err != nil {
+		return nil, nil, err
+	}
+	frontmatter, err = s.mergeGlobalDefaults(frontmatter)
+	if err != nil {
+		return nil, nil, err
+	}
+	if s.lowercaseTags {
+		lowercaseTags(frontmatter, s.tagsFrontmatterKey())
+	}
+	return frontmatter, keys, nil
+}
+
+// unmarshalFrontmatter returns the frontmatter written in content, without any
+// defaults, and its top-level keys in source order.
+func (s *Server) unmarshalFrontmatter(content []byte) (map[string]any, []string, error) {
+	block, format, _ := s.splitFrontmatter(content)
+	if format == nil || len(bytes.TrimSpace(block)) == 0 {
+		return nil, nil, nil
+	}
+	frontmatter, keys, err := format.Unmarshal(block)
+	if err != nil {
+		return nil, nil, &frontmatterParseError{Format: format.Name, Err: err}
+	}
+	return frontmatter, keys, nil
+}
+
+func (s *Server) readFrontmatter(content []byte) (map[string]any, error) {
+	frontmatter, _, err := s.readOrderedFrontmatter(content)
+	return frontmatter, err
+}
+
+// readOrderedFrontmatter is readFrontmatter, also returning the top-level keys
+// written in content in source order.
+func (s *Server) readOrderedFrontmatter(content []byte) (map[string]any, []string, error) {
+	frontmatter, keys, err := s.parseOrderedFrontmatter(content)
+	if err != nil {
+		return nil, nil, err
+	}
+	frontmatter = s.filterFrontmatter(frontmatter)
+	if frontmatter == nil {
+		return nil, nil, nil
+	}
+	return frontmatter, keys, nil
+}
+
+// filterFrontmatter removes excluded keys from frontmatter, normalizes and
+// redacts its values, and returns it, or nil if no keys are left.
+func (s *Server) filterFrontmatter(frontmatter map[string]any) map[string]any {
+	for _, key := range s.excludeFrontmatter {
+		deleteFrontmatterKey(frontmatter, key)
+	}
+	if s.normalizeFrontmatter {
+		for key, value := range frontmatter {
+			frontmatter[key] = normalizeValue(value)
+		}
+	}
+	for _, key := range s.redactFrontmatter {
+		if _, ok := frontmatter[key]; ok {
+			frontmatter[key] = redactedValue
+		}
+	}
+	if len(frontmatter) == 0 {
+		return nil
+	}
+	return frontmatter
+}
+
+// deleteFrontmatterKey deletes key from frontmatter and reports whether it was
+// present. If frontmatter has no such top-level key, a dotted key is followed
+// into nested maps, and the maps that the deletion leaves empty are deleted as
+// well. Maps that were already empty are kept.
+func deleteFrontmatterKey(frontmatter map[string]any, key string) bool {
+	if _, ok := frontmatter[key]; ok {
+		delete(frontmatter, key)
+		return true
+	}
+	parent, rest, ok := strings.Cut(key, ".")
+	if !ok {
+		return false
+	}
+	nested, ok := frontmatter[parent].(map[string]any)
+	if !ok || !deleteFrontmatterKey(nested, rest) {
+		return false
+	}
+	if len(nested) == 0 {
+		delete(frontmatter, parent)
+	}
+	return true
 }
 
 func (s *Server) readMarkdownFileTool() mcp.Tool[*readMarkdownFileRequest, *readMarkdownFileResponse] {
@@ -197,6 +824,21 @@ func (s *Server) readMarkdownFileTool() mcp.Tool[*readMarkdownFileRequest, *read
 				"path": jsonschema.String{
 					Description: "The path to the markdown file",
 				},
+				"frontmatter_as_code_block": jsonschema.Boolean{
+					Description: "Replace the frontmatter in the content with a yaml fenced code block holding the same metadata",
+				},
+				"max_heading_depth": jsonschema.Integer{
+					Description: "Flatten headings deeper than this level into bold text; 0 keeps all headings",
+				},
+				"expand_wiki_links": jsonschema.Boolean{
+					Description: "Rewrite [[Page]] wiki-links into markdown links titled with the target's title and pointing at its resource URI",
+				},
+				"include_outline": jsonschema.Boolean{
+					Description: "Include the document's headings with their levels and anchor slugs",
+				},
+				"strip_frontmatter": jsonschema.Boolean{
+					Description: "Return the content without its frontmatter block; the parsed frontmatter is still returned separately",
+				},
 			},
 			Required: []string{"path"},
 		},
@@ -205,7 +847,12 @@ func (s *Server) readMarkdownFileTool() mcp.Tool[*readMarkdownFileRequest, *read
 }
 
 type readMarkdownFileRequest struct {
-	Path string `json:"path" jsonschema:"required"`
+	Path                   string `json:"path" jsonschema:"required"`
+	FrontmatterAsCodeBlock bool   `json:"frontmatter_as_code_block"`
+	MaxHeadingDepth        int    `json:"max_heading_depth"`
+	ExpandWikiLinks        bool   `json:"expand_wiki_links"`
+	IncludeOutline         bool   `json:"include_outline"`
+	StripFrontmatter       bool   `json:"strip_frontmatter"`
 }
 
 // readMarkdownFileResponse defines the response structure for the readMarkdownFile tool.
@@ -215,49 +862,164 @@ type readMarkdownFileResponse struct {
 	Path string `json:"path"`
 	// Size is the size of the markdown file in bytes.
 	Size int64 `json:"size"`
+	// FrontmatterSize is the size in bytes of the frontmatter block, including
+	// its delimiters, or 0 if the file has no frontmatter.
+	FrontmatterSize int64 `json:"frontmatter_size"`
+	// BodySize is the size in bytes of the content following the frontmatter.
+	BodySize int64 `json:"body_size"`
 	// Frontmatter contains the parsed frontmatter data.
 	Frontmatter map[string]any `json:"frontmatter"`
-	// Content is the full text content of the markdown file.
+	// WordCount is the number of words in the body, excluding frontmatter,
+	// code, and markup.
+	WordCount int `json:"word_count"`
+	// ReadingTimeMinutes is the estimated reading time of the body at 200
+	// words per minute, rounded up.
+	ReadingTimeMinutes int `json:"reading_time_minutes"`
+	// Content is the full text content of the markdown file, or its body
+	// alone when the frontmatter is stripped.
 	Content string `json:"content"`
+	// Outline is the heading structure of the body, outside fenced code blocks.
+	// It is set only when requested with include_outline.
+	Outline []heading `json:"outline,omitempty"`
+	// Warnings are the violations of the frontmatter schema set by WithFrontmatterSchema.
+	Warnings []string `json:"warnings,omitempty"`
 }
 
 func (s *Server) readMarkdownFile(ctx context.Context, request *readMarkdownFileRequest) (*readMarkdownFileResponse, error) {
-	content, err := fs.ReadFile(s.fs, request.Path)
+	path, content, err := s.readMarkdownOrAlias(ctx, request.Path)
 	if err != nil {
 		return nil, err
 	}
-	info, err := fs.Stat(s.fs, request.Path)
+	info, err := fs.Stat(s.fs, path)
 	if err != nil {
 		return nil, err
 	}
-	frontmatter, err := s.readFrontmatter(content)
+	frontmatter, err := s.parseFrontmatter(content)
 	if err != nil {
 		return nil, err
 	}
+	_, _, body := s.splitFrontmatter(content)
+	bodySize := int64(len(body))
+	words := wordCount(body)
+	// Defaults are applied first so that excluded and redacted keys stay hidden.
+	frontmatter = s.filterFrontmatter(s.applyFrontmatterDefaults(frontmatter))
+	warnings := s.frontmatterSchema.validate(frontmatter)
+	rendered := s.renderContent(path, content)
+	if request.ExpandWikiLinks {
+		rendered, err = s.expandWikiLinks(ctx, rendered)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if request.MaxHeadingDepth > 0 {
+		_, _, renderedBody := s.splitFrontmatter(rendered)
+		head := rendered[:len(rendered)-len(renderedBody)]
+		rendered = append(slices.Clip(head), flattenHeadings(renderedBody, request.MaxHeadingDepth)...)
+	}
+	if request.StripFrontmatter || s.stripFrontmatterContent {
+		rendered = s.stripFrontmatter(rendered)
+	}
+	if request.FrontmatterAsCodeBlock {
+		rendered, err = s.frontmatterAsCodeBlock(rendered, frontmatter)
+		if err != nil {
+			return nil, err
+		}
+	}
+	var outline []heading
+	if request.IncludeOutline {
+		outline = withAnchors(s.cacheHeadings(path, info, body))
+	}
+	s.recordRead(path)
 	return &readMarkdownFileResponse{
-		Path:        request.Path,
-		Size:        info.Size(),
-		Frontmatter: frontmatter,
-		Content:     string(content),
+		Path:               path,
+		Size:               info.Size(),
+		FrontmatterSize:    int64(len(content)) - bodySize,
+		BodySize:           bodySize,
+		Frontmatter:        frontmatter,
+		WordCount:          words,
+		ReadingTimeMinutes: readingTimeMinutes(words),
+		Content:            string(rendered),
+		Outline:            outline,
+		Warnings:           warnings,
 	}, nil
 }
 
+// renderContent applies the server's content transformations to a markdown
+// file before it is returned to a client. The file itself is left unchanged.
+func (s *Server) renderContent(path string, content []byte) []byte {
+	if s.rewriteLinksToResourceURIs {
+		content = s.rewriteInternalLinks(path, content)
+	}
+	return content
+}
+
 func (s *Server) listResourcesOption() ([]mcp.ServerOption, error) {
+	resources, err := s.resourceList()
+	if err != nil {
+		return nil, err
+	}
+	if s.watch {
+		s.setResources(resources)
+		if !s.lazyResources {
+			s.snapshotFrontmatter()
+		}
+		return []mcp.ServerOption{s.watchedResourcesOption()}, nil
+	}
 	opts := []mcp.ServerOption{}
-	for f := range s.markdownFiles() {
-		desc, err := json.Marshal(f.Frontmatter)
+	for _, r := range resources {
+		opts = append(opts, mcp.WithResource(r.Resource))
+	}
+	if s.resourceAnnotations || s.frontmatterMeta {
+		opts = append(opts, annotatedResourcesOption(resources))
+	}
+	return opts, nil
+}
+
+// resourceList returns the resources to register for the served files, with
+// their annotations when enabled by WithResourceAnnotations, or without
+// reading the files when enabled by WithLazyResources.
+func (s *Server) resourceList() ([]annotatedResource, error) {
+	if s.lazyResources {
+		return s.lazyResourceList()
+	}
+	files, err := s.collectMarkdownFiles(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	resources := []annotatedResource{}
+	for _, f := range files {
+		desc, err := s.resourceDescription(f)
 		if err != nil {
 			return nil, err
 		}
-		opts = append(opts, mcp.WithResource(mcp.Resource{
-			URI:         "file://" + f.Path,
+		resource := annotatedResource{Resource: mcp.Resource{
+			URI:         resourceURI(f.Path),
 			Name:        filepath.Base(f.Path),
-			Description: string(desc),
-			MimeType:    "text/markdown",
+			Description: desc,
+			MimeType:    s.mimeType(f.Path),
 			Size:        f.Size,
-		}))
+		}}
+		if s.resourceAnnotations {
+			resource.Annotations = frontmatterAnnotations(f.Frontmatter)
+		}
+		if s.frontmatterMeta {
+			resource.Meta = frontmatterMeta(f.Frontmatter)
+			if f.FrontmatterError != "" {
+				resource.Meta = frontmatterErrorMeta(f.FrontmatterError)
+			}
+		}
+		resources = append(resources, resource)
 	}
-	return opts, nil
+	if s.sitemap {
+		resources = append(resources, annotatedResource{Resource: sitemapResource()})
+	}
+	if s.manifest {
+		resources = append(resources, annotatedResource{Resource: manifestResource()})
+	}
+	if s.permalinkIndex {
+		resources = append(resources, annotatedResource{Resource: permalinkIndexResource()})
+	}
+	return resources, nil
 }
 
 func (s *Server) resourceReader() mcp.ResourceReader {
@@ -268,23 +1030,169 @@ func (s *Server) resourceReader() mcp.ResourceReader {
 // It reads the content of a resource specified by a file URI.
 func (s *Server) ReadResource(ctx context.Context, request *mcp.Request[mcp.ReadResourceRequestParams]) (*mcp.Result[mcp.ReadResourceResultData], error) {
 	if !strings.HasPrefix(request.Params.URI, "file://") {
-		return nil, errors.New("unsupported scheme: " + request.Params.URI)
+		return nil, invalidResourceRequest(errors.New("unsupported scheme: " + request.Params.URI))
 	}
 
-	content, err := fs.ReadFile(s.fs, request.Params.URI[7:])
+	switch {
+	case s.sitemap && request.Params.URI == sitemapURI:
+		return syntheticResource(ctx, request.Params.URI, "application/xml", s.renderSitemap)
+	case s.manifest && request.Params.URI == manifestURI:
+		return syntheticResource(ctx, request.Params.URI, "application/json", s.renderManifest)
+	case s.permalinkIndex && request.Params.URI == permalinkIndexURI:
+		return syntheticResource(ctx, request.Params.URI, "application/json", s.renderPermalinkIndex)
+	}
+
+	rawPath, rawQuery, _ := strings.Cut(request.Params.URI[7:], "?")
+	path, err := resourcePath(rawPath)
+	if err != nil {
+		return nil, invalidResourceRequest(err)
+	}
+	path, content, err := s.readMarkdownOrAlias(ctx, path)
 	if err != nil {
 		return nil, err
 	}
+	var meta map[string]any
+	if s.frontmatterMeta {
+		frontmatter, err := s.readFrontmatter(content)
+		switch {
+		case isFrontmatterParseError(err):
+			meta = frontmatterErrorMeta(err.Error())
+		case err != nil:
+			return nil, err
+		default:
+			meta = frontmatterMeta(frontmatter)
+		}
+	}
+	content = s.renderContent(path, content)
 
+	if rawQuery != "" {
+		query, err := url.ParseQuery(rawQuery)
+		if err != nil {
+			return nil, invalidResourceRequest(fmt.Errorf("invalid query: %w", err))
+		}
+		content, err = linesAround(content, query)
+		if err != nil {
+			return nil, invalidResourceRequest(err)
+		}
+	}
+
+	s.recordRead(path)
 	return &mcp.Result[mcp.ReadResourceResultData]{
+		Meta: meta,
 		Data: mcp.ReadResourceResultData{
 			Contents: []mcp.IsResourceContents{
 				mcp.TextResourceContents{
 					URI:      request.Params.URI,
 					Text:     string(content),
-					MimeType: "text/markdown",
+					MimeType: s.mimeType(path),
 				},
 			},
 		},
 	}, nil
 }
+
+// errInvalidResourceRequest matches the errors of ReadResource caused by a
+// malformed request, such as an invalid path or query, rather than by a
+// failure to read the resource.
+var errInvalidResourceRequest = errors.New("invalid resource request")
+
+// invalidResourceRequestError marks Err as caused by a malformed request,
+// keeping its message.
+type invalidResourceRequestError struct {
+	Err error
+}
+
+// invalidResourceRequest returns err marked as matching errInvalidResourceRequest.
+func invalidResourceRequest(err error) error {
+	return &invalidResourceRequestError{Err: err}
+}
+
+func (e *invalidResourceRequestError) Error() string { return e.Err.Error() }
+
+func (e *invalidResourceRequestError) Unwrap() []error {
+	return []error{e.Err, errInvalidResourceRequest}
+}
+
+// syntheticResource returns the result of reading a synthetic resource whose
+// content is generated by render.
+func syntheticResource(ctx context.Context, uri, mimeType string, render func(context.Context) ([]byte, error)) (*mcp.Result[mcp.ReadResourceResultData], error) {
+	content, err := render(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &mcp.Result[mcp.ReadResourceResultData]{
+		Data: mcp.ReadResourceResultData{
+			Contents: []mcp.IsResourceContents{
+				mcp.TextResourceContents{
+					URI:      uri,
+					Text:     string(content),
+					MimeType: mimeType,
+				},
+			},
+		},
+	}, nil
+}
+
+// resourceURI returns the file URI naming the file at p, with each path
+// segment escaped so that resourcePath maps it back to p.
+func resourceURI(p string) string {
+	segments := strings.Split(p, "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+	return "file://" + strings.Join(segments, "/")
+}
+
+// resourcePath returns the file path named by the path part of a file URI.
+// The path is unescaped and cleaned, and paths escaping the served tree,
+// whether through ".." or by being absolute, are rejected.
+func resourcePath(rawPath string) (string, error) {
+	p, err := url.PathUnescape(rawPath)
+	if err == nil {
+		p, err = cleanRelativePath(p)
+	}
+	if err != nil {
+		return "", fmt.Errorf("invalid resource path %q: %w", rawPath, err)
+	}
+	return p, nil
+}
+
+// defaultAroundContext is the number of lines returned on each side of the
+// requested line when the around query parameter is given without context.
+const defaultAroundContext = 10
+
+// linesAround returns the lines of content surrounding the line given by the
+// around query parameter, with context lines on each side.
+// Line numbers are 1-based and out-of-range values are clamped to the content.
+// If the query has no around parameter, content is returned unchanged.
+func linesAround(content []byte, query url.Values) ([]byte, error) {
+	if !query.Has("around") {
+		return content, nil
+	}
+	around, err := strconv.Atoi(query.Get("around"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid around parameter: %w", err)
+	}
+	contextLines := defaultAroundContext
+	if query.Has("context") {
+		contextLines, err = strconv.Atoi(query.Get("context"))
+		if err != nil {
+			return nil, fmt.Errorf("invalid context parameter: %w", err)
+		}
+		if contextLines < 0 {
+			return nil, errors.New("invalid context parameter: must not be negative")
+		}
+	}
+
+	lines := strings.SplitAfter(string(content), "\n")
+	if len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	if len(lines) == 0 {
+		return nil, nil
+	}
+	around = min(max(around, 1), len(lines))
+	start := max(around-contextLines, 1)
+	end := min(around+contextLines, len(lines))
+	return []byte(strings.Join(lines[start-1:end], "")), nil
+}
@@ -6,6 +6,7 @@ import (
 	"io/fs"
 	"reflect"
 	"slices"
+	"strconv"
 	"strings"
 	"testing"
 	"testing/fstest"
@@ -156,6 +157,40 @@ Regular content`),
 			},
 			wantErr: false,
 		},
+		{
+			name: "JSON frontmatter",
+			content: []byte(`;;;
+{"title": "Test JSON", "value": 789}
+;;;
+Regular content`),
+			want: map[string]any{
+				"title": "Test JSON",
+				"value": float64(789), // JSON decoder uses float64
+			},
+			wantErr: false,
+		},
+		{
+			name: "JSON frontmatter with key exclusion",
+			content: []byte(`;;;
+{"title": "Test JSON", "draft": true, "_internal": "secret"}
+;;;
+Regular content`),
+			excludeFrontmatter: []string{"draft", "_internal"},
+			want: map[string]any{
+				"title": "Test JSON",
+			},
+			wantErr: false,
+		},
+		{
+			name: "JSON frontmatter with all keys excluded",
+			content: []byte(`;;;
+{"draft": true}
+;;;
+Regular content`),
+			excludeFrontmatter: []string{"draft"},
+			want:               nil,
+			wantErr:            false,
+		},
 		{
 			name: "YAML frontmatter with extra whitespace",
 			content: []byte(`
@@ -199,6 +234,15 @@ Regular content`),
 title = "Test Invalid TOML"
 value = "unterminated string
 +++
+Regular content`),
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "Invalid JSON",
+			content: []byte(`;;;
+{"title": "Test Invalid JSON",
+;;;
 Regular content`),
 			want:    nil,
 			wantErr: true,
@@ -225,6 +269,17 @@ Content with +++ delimiter`),
 			},
 			wantErr: false,
 		},
+		{
+			name: "Delimiter inside content (JSON)",
+			content: []byte(`;;;
+{"title": "Test JSON"}
+;;;
+Content with ;;; delimiter`),
+			want: map[string]any{
+				"title": "Test JSON",
+			},
+			wantErr: false,
+		},
 		{
 			name: "Only delimiter (YAML)",
 			content: []byte(`---
@@ -239,6 +294,58 @@ Content with +++ delimiter`),
 			want:    nil,
 			wantErr: false,
 		},
+		{
+			name: "Only delimiter (JSON)",
+			content: []byte(`;;;
+;;;`),
+			want:    nil,
+			wantErr: false,
+		},
+		{
+			name:    "Opening delimiter followed by spaces",
+			content: []byte("--- \ntitle: Trailing Space\n---\nRegular content"),
+			want: map[string]any{
+				"title": "Trailing Space",
+			},
+			wantErr: false,
+		},
+		{
+			name:    "CRLF line terminators",
+			content: []byte("---\r\ntitle: CRLF\r\n---\r\nRegular content"),
+			want: map[string]any{
+				"title": "CRLF",
+			},
+			wantErr: false,
+		},
+		{
+			name:    "Closing delimiter at end of file",
+			content: []byte("+++\ntitle = \"EOF\"\n+++"),
+			want: map[string]any{
+				"title": "EOF",
+			},
+			wantErr: false,
+		},
+		{
+			name:    "Opening delimiter at end of file",
+			content: []byte("---"),
+			want:    nil,
+			wantErr: false,
+		},
+		{
+			name:    "Delimiter followed by text is not frontmatter",
+			content: []byte("--- not frontmatter\ntitle: Nope\n---\n"),
+			want:    nil,
+			wantErr: false,
+		},
+		{
+			name: "Empty block (JSON)",
+			content: []byte(`;;;
+
+;;;
+Regular content`),
+			want:    nil,
+			wantErr: false,
+		},
 	}
 
 	for _, tt := range tests {
@@ -262,6 +369,38 @@ Content with +++ delimiter`),
 	}
 }
 
+func Test_server_splitFrontmatter(t *testing.T) {
+	tests := []struct {
+		name      string
+		content   string
+		wantBlock string
+		wantBody  string
+		wantFound bool
+	}{
+		{name: "LF", content: "---\na: 1\n---\nbody\n", wantBlock: "a: 1", wantBody: "body\n", wantFound: true},
+		{name: "Trailing whitespace on delimiters", content: "--- \t\na: 1\n---  \nbody", wantBlock: "a: 1", wantBody: "body", wantFound: true},
+		{name: "CRLF", content: "---\r\na: 1\r\n---\r\nbody\r\n", wantBlock: "a: 1", wantBody: "body\r\n", wantFound: true},
+		{name: "Closing delimiter at EOF", content: "---\na: 1\n---", wantBlock: "a: 1", wantBody: "", wantFound: true},
+		{name: "Empty block", content: "---\n---\nbody", wantBlock: "", wantBody: "body", wantFound: true},
+		{name: "Opening delimiter at EOF", content: "---", wantBody: "---"},
+		{name: "Unclosed", content: "---\na: 1\n", wantBody: "---\na: 1\n"},
+		{name: "Thematic break is not a delimiter", content: "----\na: 1\n----\n", wantBody: "----\na: 1\n----\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Server{}
+			block, format, body := s.splitFrontmatter([]byte(tt.content))
+			if (format != nil) != tt.wantFound {
+				t.Fatalf("splitFrontmatter() format = %v, want found %v", format, tt.wantFound)
+			}
+			if string(block) != tt.wantBlock || string(body) != tt.wantBody {
+				t.Errorf("splitFrontmatter() = (%q, %q), want (%q, %q)", block, body, tt.wantBlock, tt.wantBody)
+			}
+		})
+	}
+}
+
 func TestNew(t *testing.T) {
 	testFS := fstest.MapFS{
 		"file1.md": {Data: []byte("content1")},
@@ -311,29 +450,45 @@ func Test_server_listMarkdownFiles(t *testing.T) {
 
 	wantFiles := []markdownFileInfo{
 		{
-			Path:        "another.md",
-			Size:        int64(len(testFS["another.md"].Data)),
-			Frontmatter: nil,
+			Path:               "another.md",
+			Size:               int64(len(testFS["another.md"].Data)),
+			ModTime:            now,
+			Frontmatter:        nil,
+			WordCount:          1,
+			ReadingTimeMinutes: 1,
 		},
 		{
-			Path:        "dir/file2.md",
-			Size:        int64(len(testFS["dir/file2.md"].Data)),
-			Frontmatter: map[string]any{"title": "File 2"},
+			Path:               "dir/file2.md",
+			Size:               int64(len(testFS["dir/file2.md"].Data)),
+			ModTime:            now,
+			Frontmatter:        map[string]any{"title": "File 2"},
+			WordCount:          1,
+			ReadingTimeMinutes: 1,
+			frontmatterKeys:    []string{"title"},
 		},
 		{
-			Path:        "dir/subdir/f3.md",
-			Size:        int64(len(testFS["dir/subdir/f3.md"].Data)),
-			Frontmatter: nil,
+			Path:               "dir/subdir/f3.md",
+			Size:               int64(len(testFS["dir/subdir/f3.md"].Data)),
+			ModTime:            now,
+			Frontmatter:        nil,
+			WordCount:          1,
+			ReadingTimeMinutes: 1,
 		},
 		{
-			Path:        "file1.md",
-			Size:        int64(len(testFS["file1.md"].Data)),
-			Frontmatter: nil,
+			Path:               "file1.md",
+			Size:               int64(len(testFS["file1.md"].Data)),
+			ModTime:            now,
+			Frontmatter:        nil,
+			WordCount:          1,
+			ReadingTimeMinutes: 1,
 		},
 		{
-			Path:        "noread.md", // Expect it to be listed even if content read might fail elsewhere
-			Size:        int64(len(testFS["noread.md"].Data)),
-			Frontmatter: nil,
+			Path:               "noread.md", // Expect it to be listed even if content read might fail elsewhere
+			Size:               int64(len(testFS["noread.md"].Data)),
+			ModTime:            now,
+			Frontmatter:        nil,
+			WordCount:          2,
+			ReadingTimeMinutes: 1,
 		},
 	}
 
@@ -357,6 +512,7 @@ func Test_server_readMarkdownFile(t *testing.T) {
 		"dir/file2.md":      {Data: []byte("---\ntitle: File 2\n---\ncontent2"), ModTime: now, Mode: 0644},
 		"empty.md":          {Data: []byte(""), ModTime: now, Mode: 0644},
 		"no_frontmatter.md": {Data: []byte("just content"), ModTime: now, Mode: 0644},
+		"toml.md":           {Data: []byte("+++\ntitle = \"TOML\"\n+++\nbody"), ModTime: now, Mode: 0644},
 	}
 
 	s := &Server{fs: testFS}
@@ -371,10 +527,29 @@ func Test_server_readMarkdownFile(t *testing.T) {
 			name: "Read file with frontmatter",
 			path: "dir/file2.md",
 			want: &readMarkdownFileResponse{
-				Path:        "dir/file2.md",
-				Size:        int64(len(testFS["dir/file2.md"].Data)),
-				Frontmatter: map[string]any{"title": "File 2"},
-				Content:     "---\ntitle: File 2\n---\ncontent2",
+				Path:               "dir/file2.md",
+				Size:               int64(len(testFS["dir/file2.md"].Data)),
+				FrontmatterSize:    22,
+				BodySize:           8,
+				Frontmatter:        map[string]any{"title": "File 2"},
+				WordCount:          1,
+				ReadingTimeMinutes: 1,
+				Content:            "---\ntitle: File 2\n---\ncontent2",
+			},
+			wantErr: false,
+		},
+		{
+			name: "Read file with TOML frontmatter",
+			path: "toml.md",
+			want: &readMarkdownFileResponse{
+				Path:               "toml.md",
+				Size:               int64(len(testFS["toml.md"].Data)),
+				FrontmatterSize:    23,
+				BodySize:           4,
+				Frontmatter:        map[string]any{"title": "TOML"},
+				WordCount:          1,
+				ReadingTimeMinutes: 1,
+				Content:            "+++\ntitle = \"TOML\"\n+++\nbody",
 			},
 			wantErr: false,
 		},
@@ -382,10 +557,13 @@ func Test_server_readMarkdownFile(t *testing.T) {
 			name: "Read file without frontmatter",
 			path: "no_frontmatter.md",
 			want: &readMarkdownFileResponse{
-				Path:        "no_frontmatter.md",
-				Size:        int64(len(testFS["no_frontmatter.md"].Data)),
-				Frontmatter: nil,
-				Content:     "just content",
+				Path:               "no_frontmatter.md",
+				Size:               int64(len(testFS["no_frontmatter.md"].Data)),
+				BodySize:           int64(len(testFS["no_frontmatter.md"].Data)),
+				Frontmatter:        nil,
+				WordCount:          2,
+				ReadingTimeMinutes: 1,
+				Content:            "just content",
 			},
 			wantErr: false,
 		},
@@ -498,6 +676,24 @@ func Test_server_ReadResource(t *testing.T) {
 			want:    nil,
 			wantErr: true,
 		},
+		{
+			name:    "Encoded path traversal",
+			uri:     "file://%2e%2e%2fetc/passwd",
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "Path traversal",
+			uri:     "file://dir/../../etc/passwd",
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "Absolute path",
+			uri:     "file:///etc/passwd",
+			want:    nil,
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -518,6 +714,9 @@ func Test_server_ReadResource(t *testing.T) {
 				if strings.Contains(tt.name, "Unsupported scheme") && !strings.Contains(err.Error(), "unsupported scheme") {
 					t.Errorf("expected 'unsupported scheme' error, got %v", err)
 				}
+				if (strings.Contains(tt.name, "traversal") || strings.Contains(tt.name, "Absolute")) && !strings.Contains(err.Error(), "invalid resource path") {
+					t.Errorf("expected 'invalid resource path' error, got %v", err)
+				}
 			} else {
 				if err != nil {
 					t.Fatalf("unexpected error: %v", err)
@@ -529,3 +728,383 @@ func Test_server_ReadResource(t *testing.T) {
 		})
 	}
 }
+
+func Test_server_ReadResource_around(t *testing.T) {
+	var lines []string
+	for i := 1; i <= 200; i++ {
+		lines = append(lines, "line "+strconv.Itoa(i))
+	}
+	testFS := fstest.MapFS{
+		"doc.md": {Data: []byte(strings.Join(lines, "\n") + "\n")},
+	}
+
+	s := &Server{fs: testFS}
+
+	tests := []struct {
+		name      string
+		uri       string
+		wantFirst string
+		wantLast  string
+		wantLines int
+		wantErr   bool
+	}{
+		{
+			name:      "Around with context",
+			uri:       "file://doc.md?around=120&context=40",
+			wantFirst: "line 80",
+			wantLast:  "line 160",
+			wantLines: 81,
+		},
+		{
+			name:      "Around with default context",
+			uri:       "file://doc.md?around=50",
+			wantFirst: "line 40",
+			wantLast:  "line 60",
+			wantLines: 21,
+		},
+		{
+			name:      "Zero context",
+			uri:       "file://doc.md?around=7&context=0",
+			wantFirst: "line 7",
+			wantLast:  "line 7",
+			wantLines: 1,
+		},
+		{
+			name:      "Window clamped at start",
+			uri:       "file://doc.md?around=3&context=10",
+			wantFirst: "line 1",
+			wantLast:  "line 13",
+			wantLines: 13,
+		},
+		{
+			name:      "Around beyond end is clamped",
+			uri:       "file://doc.md?around=500&context=5",
+			wantFirst: "line 195",
+			wantLast:  "line 200",
+			wantLines: 6,
+		},
+		{
+			name:      "Negative around is clamped",
+			uri:       "file://doc.md?around=-5&context=1",
+			wantFirst: "line 1",
+			wantLast:  "line 2",
+			wantLines: 2,
+		},
+		{
+			name:    "Non-numeric around",
+			uri:     "file://doc.md?around=abc",
+			wantErr: true,
+		},
+		{
+			name:    "Negative context",
+			uri:     "file://doc.md?around=10&context=-1",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := &mcp.Request[mcp.ReadResourceRequestParams]{
+				Params: mcp.ReadResourceRequestParams{URI: tt.uri},
+			}
+			got, err := s.ReadResource(context.Background(), req)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected an error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			contents := got.Data.Contents[0].(mcp.TextResourceContents)
+			if contents.URI != tt.uri {
+				t.Errorf("URI got = %q, want %q", contents.URI, tt.uri)
+			}
+			gotLines := strings.Split(strings.TrimSuffix(contents.Text, "\n"), "\n")
+			if len(gotLines) != tt.wantLines {
+				t.Errorf("got %d lines, want %d", len(gotLines), tt.wantLines)
+			}
+			if gotLines[0] != tt.wantFirst || gotLines[len(gotLines)-1] != tt.wantLast {
+				t.Errorf("got lines %q..%q, want %q..%q", gotLines[0], gotLines[len(gotLines)-1], tt.wantFirst, tt.wantLast)
+			}
+		})
+	}
+}
+
+func Test_server_redactFrontmatter(t *testing.T) {
+	testFS := fstest.MapFS{
+		"secret.md": {Data: []byte(`---
+title: Deploy
+api_key: abc123
+token: xyz
+internal: yes
+---
+body`)},
+		"plain.md": {Data: []byte(`---
+title: Plain
+---
+body`)},
+	}
+
+	s := &Server{fs: testFS}
+	WithRedactFrontmatter("api_key", "token", "missing")(s)
+	WithExcludeFrontmatter("internal")(s)
+
+	wantSecret := map[string]any{
+		"title":   "Deploy",
+		"api_key": "***",
+		"token":   "***",
+	}
+	wantPlain := map[string]any{
+		"title": "Plain",
+	}
+
+	resp, err := s.listMarkdownFiles(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("listMarkdownFiles() error = %v", err)
+	}
+	for _, f := range resp.Files {
+		want := wantPlain
+		if f.Path == "secret.md" {
+			want = wantSecret
+		}
+		if !reflect.DeepEqual(f.Frontmatter, want) {
+			t.Errorf("listMarkdownFiles() %s frontmatter = %#v, want %#v", f.Path, f.Frontmatter, want)
+		}
+	}
+
+	got, err := s.readMarkdownFile(context.Background(), &readMarkdownFileRequest{Path: "secret.md"})
+	if err != nil {
+		t.Fatalf("readMarkdownFile() error = %v", err)
+	}
+	if !reflect.DeepEqual(got.Frontmatter, wantSecret) {
+		t.Errorf("readMarkdownFile() frontmatter = %#v, want %#v", got.Frontmatter, wantSecret)
+	}
+}
+
+func Test_server_listMarkdownFiles_pagination(t *testing.T) {
+	testFS := fstest.MapFS{
+		"c.md":     {Data: []byte("c")},
+		"a.md":     {Data: []byte("a")},
+		"dir/b.md": {Data: []byte("b")},
+		"e.md":     {Data: []byte("e")},
+		"d.md":     {Data: []byte("d")},
+	}
+
+	tests := []struct {
+		name           string
+		fs             fstest.MapFS
+		request        *listMarkdownFilesRequest
+		wantPaths      []string
+		wantNextCursor string
+		wantErr        bool
+	}{
+		{
+			name:      "No limit returns everything sorted by path",
+			fs:        testFS,
+			request:   &listMarkdownFilesRequest{},
+			wantPaths: []string{"a.md", "c.md", "d.md", "dir/b.md", "e.md"},
+		},
+		{
+			name:           "First page",
+			fs:             testFS,
+			request:        &listMarkdownFilesRequest{Limit: 2},
+			wantPaths:      []string{"a.md", "c.md"},
+			wantNextCursor: "2",
+		},
+		{
+			name:           "Middle page",
+			fs:             testFS,
+			request:        &listMarkdownFilesRequest{Limit: 2, Cursor: "2"},
+			wantPaths:      []string{"d.md", "dir/b.md"},
+			wantNextCursor: "4",
+		},
+		{
+			name:      "Last page",
+			fs:        testFS,
+			request:   &listMarkdownFilesRequest{Limit: 2, Cursor: "4"},
+			wantPaths: []string{"e.md"},
+		},
+		{
+			name:      "Limit beyond the end",
+			fs:        testFS,
+			request:   &listMarkdownFilesRequest{Limit: 10},
+			wantPaths: []string{"a.md", "c.md", "d.md", "dir/b.md", "e.md"},
+		},
+		{
+			name:      "Cursor beyond the end",
+			fs:        testFS,
+			request:   &listMarkdownFilesRequest{Limit: 2, Cursor: "10"},
+			wantPaths: []string{},
+		},
+		{
+			name:      "Empty filesystem",
+			fs:        fstest.MapFS{},
+			request:   &listMarkdownFilesRequest{Limit: 2},
+			wantPaths: []string{},
+		},
+		{
+			name:    "Invalid cursor",
+			fs:      testFS,
+			request: &listMarkdownFilesRequest{Cursor: "abc"},
+			wantErr: true,
+		},
+		{
+			name:    "Negative limit",
+			fs:      testFS,
+			request: &listMarkdownFilesRequest{Limit: -1},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Server{fs: tt.fs}
+			got, err := s.listMarkdownFiles(context.Background(), tt.request)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected an error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("listMarkdownFiles() error = %v", err)
+			}
+			paths := []string{}
+			for _, f := range got.Files {
+				paths = append(paths, f.Path)
+			}
+			if !reflect.DeepEqual(paths, tt.wantPaths) {
+				t.Errorf("listMarkdownFiles() paths = %v, want %v", paths, tt.wantPaths)
+			}
+			if got.NextCursor != tt.wantNextCursor {
+				t.Errorf("listMarkdownFiles() next_cursor = %q, want %q", got.NextCursor, tt.wantNextCursor)
+			}
+		})
+	}
+}
+
+func TestWithExcludeFrontmatter_nested(t *testing.T) {
+	tests := []struct {
+		name    string
+		exclude []string
+		want    map[string]any
+	}{
+		{
+			name:    "three levels",
+			exclude: []string{"meta.internal.reviewer"},
+			want: map[string]any{
+				"title": "Doc",
+				"meta":  map[string]any{"author": "alice", "internal": map[string]any{"score": uint64(3)}},
+				"a.b":   "flat",
+			},
+		},
+		{
+			name:    "empty parents removed",
+			exclude: []string{"meta.internal.reviewer", "meta.internal.score"},
+			want: map[string]any{
+				"title": "Doc",
+				"meta":  map[string]any{"author": "alice"},
+				"a.b":   "flat",
+			},
+		},
+		{
+			name:    "missing path",
+			exclude: []string{"meta.missing.key", "title.sub"},
+			want: map[string]any{
+				"title": "Doc",
+				"meta":  map[string]any{"author": "alice", "internal": map[string]any{"reviewer": "bob", "score": uint64(3)}},
+				"a.b":   "flat",
+			},
+		},
+		{
+			name:    "flat dotted key",
+			exclude: []string{"a.b"},
+			want: map[string]any{
+				"title": "Doc",
+				"meta":  map[string]any{"author": "alice", "internal": map[string]any{"reviewer": "bob", "score": uint64(3)}},
+			},
+		},
+	}
+	testFS := fstest.MapFS{
+		"doc.md": {Data: []byte(`---
+title: Doc
+meta:
+  author: alice
+  internal:
+    reviewer: bob
+    score: 3
+a.b: flat
+---
+body`)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Server{fs: testFS}
+			WithExcludeFrontmatter(tt.exclude...)(s)
+			got, err := s.readMarkdownFile(context.Background(), &readMarkdownFileRequest{Path: "doc.md"})
+			if err != nil {
+				t.Fatalf("readMarkdownFile() error = %v", err)
+			}
+			if !reflect.DeepEqual(got.Frontmatter, tt.want) {
+				t.Errorf("frontmatter = %#v, want %#v", got.Frontmatter, tt.want)
+			}
+		})
+	}
+}
+
+func TestWithExcludeFrontmatter_emptyParent(t *testing.T) {
+	testFS := fstest.MapFS{
+		"doc.md": {Data: []byte("---\ntitle: Doc\nmeta: {}\n---\nbody")},
+	}
+	s := &Server{fs: testFS}
+	WithExcludeFrontmatter("meta.x")(s)
+	got, err := s.readMarkdownFile(context.Background(), &readMarkdownFileRequest{Path: "doc.md"})
+	if err != nil {
+		t.Fatalf("readMarkdownFile() error = %v", err)
+	}
+	want := map[string]any{"title": "Doc", "meta": map[string]any{}}
+	if !reflect.DeepEqual(got.Frontmatter, want) {
+		t.Errorf("frontmatter = %#v, want %#v", got.Frontmatter, want)
+	}
+}
+
+func Test_server_notFoundErrors(t *testing.T) {
+	testFS := &flakyFS{
+		MapFS: fstest.MapFS{
+			"draft.md":  {Data: []byte("---\ndraft: true\n---\n")},
+			"broken.md": {Data: []byte("unreadable")},
+		},
+		failures: map[string]int{"broken.md": 100},
+		opens:    map[string]int{},
+	}
+	s := &Server{fs: testFS}
+	WithHideDrafts("draft")(s)
+
+	reads := map[string]func(path string) error{
+		"readMarkdownFile": func(path string) error {
+			_, err := s.readMarkdownFile(context.Background(), &readMarkdownFileRequest{Path: path})
+			return err
+		},
+		"ReadResource": func(path string) error {
+			_, err := s.ReadResource(context.Background(), &mcp.Request[mcp.ReadResourceRequestParams]{Params: mcp.ReadResourceRequestParams{URI: "file://" + path}})
+			return err
+		},
+	}
+	for name, read := range reads {
+		for _, path := range []string{"missing/file.md", "draft.md"} {
+			err := read(path)
+			if !errors.Is(err, fs.ErrNotExist) {
+				t.Errorf("%s(%s) error = %v, want fs.ErrNotExist", name, path, err)
+			}
+			if want := `markdown file "` + path + `": file does not exist`; err == nil || err.Error() != want {
+				t.Errorf("%s(%s) error = %v, want %q", name, path, err, want)
+			}
+		}
+		err := read("broken.md")
+		if err == nil || errors.Is(err, fs.ErrNotExist) || !strings.Contains(err.Error(), "object not yet consistent") {
+			t.Errorf("%s(broken.md) error = %v, want the I/O error", name, err)
+		}
+	}
+}
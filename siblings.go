@@ -0,0 +1,110 @@
+package mcpmds
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"io/fs"
+	"path"
+	"slices"
+
+	"github.com/Warashi/go-modelcontextprotocol/jsonschema"
+	"github.com/Warashi/go-modelcontextprotocol/mcp"
+)
+
+func (s *Server) siblingsTool() mcp.Tool[*siblingsRequest, *siblingsResponse] {
+	return mcp.NewToolFunc(
+		fmt.Sprintf("siblings_%s", s.name),
+		fmt.Sprintf("Return the previous and next markdown files in the same directory as a file managed by %s, ordered by frontmatter weight or date", s.name),
+		jsonschema.Object{
+			Properties: map[string]jsonschema.Schema{
+				"path": jsonschema.String{
+					Description: "The path to the markdown file",
+				},
+				"order_by": jsonschema.String{
+					Description: "The frontmatter ordering, either \"weight\" (ascending) or \"date\" (oldest first); defaults to the navigation order if one is configured, or else \"weight\"",
+				},
+			},
+			Required: []string{"path"},
+		},
+		s.siblings,
+	)
+}
+
+type siblingsRequest struct {
+	Path    string `json:"path" jsonschema:"required"`
+	OrderBy string `json:"order_by"`
+}
+
+type siblingsResponse struct {
+	// Previous is the file before the requested one, or nil if it is the first.
+	Previous *markdownFileInfo `json:"previous"`
+	// Next is the file after the requested one, or nil if it is the last.
+	Next *markdownFileInfo `json:"next"`
+}
+
+// frontmatterOrder returns a comparison of files by the frontmatter key named
+// by orderBy, placing files without a usable value last and breaking ties by path.
+func frontmatterOrder(orderBy string) (func(a, b markdownFileInfo) int, error) {
+	switch orderBy {
+	case "", "weight":
+		return func(a, b markdownFileInfo) int {
+			x, okX := frontmatterNumber(a.Frontmatter["weight"])
+			y, okY := frontmatterNumber(b.Frontmatter["weight"])
+			return cmp.Or(compareMissing(okX, okY), cmp.Compare(x, y), cmp.Compare(a.Path, b.Path))
+		}, nil
+	case "date":
+		return func(a, b markdownFileInfo) int {
+			x, okX := frontmatterTime(a.Frontmatter["date"])
+			y, okY := frontmatterTime(b.Frontmatter["date"])
+			return cmp.Or(compareMissing(okX, okY), x.Compare(y), cmp.Compare(a.Path, b.Path))
+		}, nil
+	}
+	return nil, fmt.Errorf("unsupported order: %s", orderBy)
+}
+
+// compareMissing orders present values before missing ones.
+func compareMissing(okA, okB bool) int {
+	switch {
+	case okA == okB:
+		return 0
+	case okA:
+		return -1
+	}
+	return 1
+}
+
+func (s *Server) siblings(ctx context.Context, request *siblingsRequest) (*siblingsResponse, error) {
+	p, err := s.requestPath(request.Path)
+	if err != nil {
+		return nil, err
+	}
+	order, err := frontmatterOrder(request.OrderBy)
+	if request.OrderBy == "" && s.navigationOrderFile != "" {
+		order, err = s.navigationOrder()
+	}
+	if err != nil {
+		return nil, err
+	}
+	dir := path.Dir(p)
+	var files []markdownFileInfo
+	for f := range s.markdownFiles(ctx) {
+		if path.Dir(f.Path) == dir {
+			files = append(files, f)
+		}
+	}
+	slices.SortFunc(files, order)
+
+	i := slices.IndexFunc(files, func(f markdownFileInfo) bool { return f.Path == p })
+	if i == -1 {
+		return nil, &fs.PathError{Op: "read", Path: p, Err: fs.ErrNotExist}
+	}
+	resp := &siblingsResponse{}
+	if i > 0 {
+		resp.Previous = &files[i-1]
+	}
+	if i < len(files)-1 {
+		resp.Next = &files[i+1]
+	}
+	return resp, nil
+}
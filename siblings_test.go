@@ -0,0 +1,56 @@
+package mcpmds
+
+import (
+	"context"
+	"testing"
+	"testing/fstest"
+)
+
+func Test_server_siblings(t *testing.T) {
+	testFS := fstest.MapFS{
+		"book/intro.md":    {Data: []byte("---\nweight: 1\ndate: 2024-03-01\n---\n")},
+		"book/middle.md":   {Data: []byte("---\nweight: 2\ndate: 2024-01-01\n---\n")},
+		"book/end.md":      {Data: []byte("---\nweight: 10\ndate: 2024-02-01\n---\n")},
+		"book/appendix.md": {Data: []byte("# No weight\n")},
+		"other/x.md":       {Data: []byte("---\nweight: 3\n---\n")},
+	}
+
+	s := &Server{fs: testFS}
+
+	tests := []struct {
+		name     string
+		request  *siblingsRequest
+		wantPrev string
+		wantNext string
+		wantErr  bool
+	}{
+		{name: "Middle document", request: &siblingsRequest{Path: "book/middle.md"}, wantPrev: "book/intro.md", wantNext: "book/end.md"},
+		{name: "First document", request: &siblingsRequest{Path: "book/intro.md"}, wantNext: "book/middle.md"},
+		{name: "Last document", request: &siblingsRequest{Path: "book/appendix.md"}, wantPrev: "book/end.md"},
+		{name: "Ordered by date", request: &siblingsRequest{Path: "book/end.md", OrderBy: "date"}, wantPrev: "book/middle.md", wantNext: "book/intro.md"},
+		{name: "Unsupported order", request: &siblingsRequest{Path: "book/end.md", OrderBy: "title"}, wantErr: true},
+		{name: "Missing file", request: &siblingsRequest{Path: "book/missing.md"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := s.siblings(context.Background(), tt.request)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("siblings() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			var prev, next string
+			if got.Previous != nil {
+				prev = got.Previous.Path
+			}
+			if got.Next != nil {
+				next = got.Next.Path
+			}
+			if prev != tt.wantPrev || next != tt.wantNext {
+				t.Errorf("siblings() = (%q, %q), want (%q, %q)", prev, next, tt.wantPrev, tt.wantNext)
+			}
+		})
+	}
+}
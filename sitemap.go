@@ -0,0 +1,75 @@
+package mcpmds
+
+import (
+	"context"
+	"encoding/xml"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/Warashi/go-modelcontextprotocol/mcp"
+)
+
+// sitemapURI is the URI of the synthetic sitemap resource.
+const sitemapURI = "file://_sitemap.xml"
+
+// WithSitemap registers a synthetic resource, file://_sitemap.xml, holding an
+// XML sitemap of the served markdown files. Each entry's location is the
+// file's path resolved against baseURL, and its last modification date is
+// taken from the frontmatter "lastmod" key, falling back to the file's
+// modification time.
+func WithSitemap(baseURL string) ServerOption {
+	return func(s *Server) {
+		s.sitemap = true
+		s.sitemapBaseURL = baseURL
+	}
+}
+
+// sitemapResource is the resource entry of the sitemap.
+func sitemapResource() mcp.Resource {
+	return mcp.Resource{
+		URI:         sitemapURI,
+		Name:        "_sitemap.xml",
+		Description: "An XML sitemap of the markdown files",
+		MimeType:    "application/xml",
+	}
+}
+
+type sitemapURLSet struct {
+	XMLName xml.Name     `xml:"urlset"`
+	XMLNS   string       `xml:"xmlns,attr"`
+	URLs    []sitemapURL `xml:"url"`
+}
+
+type sitemapURL struct {
+	Loc     string `xml:"loc"`
+	LastMod string `xml:"lastmod,omitempty"`
+}
+
+// renderSitemap returns the sitemap of the served markdown files.
+func (s *Server) renderSitemap(ctx context.Context) ([]byte, error) {
+	base, err := url.Parse(s.sitemapBaseURL)
+	if err != nil {
+		return nil, err
+	}
+	if !strings.HasSuffix(base.Path, "/") {
+		base.Path += "/"
+	}
+	set := sitemapURLSet{XMLNS: "http://www.sitemaps.org/schemas/sitemap/0.9"}
+	for f := range s.markdownFiles(ctx) {
+		lastmod, ok := frontmatterTime(f.Frontmatter["lastmod"])
+		if !ok {
+			lastmod = f.ModTime
+		}
+		entry := sitemapURL{Loc: base.ResolveReference(&url.URL{Path: f.Path}).String()}
+		if !lastmod.IsZero() {
+			entry.LastMod = lastmod.Format(time.DateOnly)
+		}
+		set.URLs = append(set.URLs, entry)
+	}
+	b, err := xml.MarshalIndent(set, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), append(b, '\n')...), nil
+}
@@ -0,0 +1,53 @@
+package mcpmds
+
+import (
+	"context"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/Warashi/go-modelcontextprotocol/mcp"
+)
+
+func Test_server_sitemap(t *testing.T) {
+	modTime := time.Date(2024, 5, 6, 7, 8, 9, 0, time.UTC)
+	testFS := fstest.MapFS{
+		"index.md":          {Data: []byte("---\nlastmod: 2024-01-02\n---\n"), ModTime: modTime},
+		"docs/guide one.md": {Data: []byte("# Guide\n"), ModTime: modTime},
+	}
+
+	s := &Server{fs: testFS}
+	WithSitemap("https://example.com/docs")(s)
+
+	req := &mcp.Request[mcp.ReadResourceRequestParams]{Params: mcp.ReadResourceRequestParams{URI: "file://_sitemap.xml"}}
+	got, err := s.ReadResource(context.Background(), req)
+	if err != nil {
+		t.Fatalf("ReadResource() error = %v", err)
+	}
+	contents := got.Data.Contents[0].(mcp.TextResourceContents)
+	want := `<?xml version="1.0" encoding="UTF-8"?>
+<urlset xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">
+  <url>
+    <loc>https://example.com/docs/docs/guide%20one.md</loc>
+    <lastmod>2024-05-06</lastmod>
+  </url>
+  <url>
+    <loc>https://example.com/docs/index.md</loc>
+    <lastmod>2024-01-02</lastmod>
+  </url>
+</urlset>
+`
+	if contents.Text != want {
+		t.Errorf("sitemap = %s, want %s", contents.Text, want)
+	}
+	if contents.MimeType != "application/xml" {
+		t.Errorf("MimeType = %q, want application/xml", contents.MimeType)
+	}
+
+	t.Run("Disabled", func(t *testing.T) {
+		s := &Server{fs: testFS}
+		if _, err := s.ReadResource(context.Background(), req); err == nil {
+			t.Error("ReadResource() succeeded, want error")
+		}
+	})
+}
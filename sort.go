@@ -0,0 +1,78 @@
+package mcpmds
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// sortFiles stably sorts files, already in path order, by sortBy: "path",
+// "size", or any other value naming a frontmatter key. Files without the key
+// are placed last, in either direction.
+func sortFiles(files []markdownFileInfo, sortBy string, descending bool) {
+	direction := 1
+	if descending {
+		direction = -1
+	}
+	switch sortBy {
+	case "", "path":
+		if descending {
+			slices.Reverse(files)
+		}
+	case "size":
+		slices.SortStableFunc(files, func(a, b markdownFileInfo) int {
+			return direction * cmp.Compare(a.Size, b.Size)
+		})
+	default:
+		slices.SortStableFunc(files, func(a, b markdownFileInfo) int {
+			x, okX := a.Frontmatter[sortBy]
+			y, okY := b.Frontmatter[sortBy]
+			okX, okY = okX && x != nil, okY && y != nil
+			if c := compareMissing(okX, okY); c != 0 || !okX {
+				return c
+			}
+			return direction * compareFrontmatterValues(x, y)
+		})
+	}
+}
+
+// compareFrontmatterValues compares two frontmatter values, ordering numbers
+// before dates and dates before other values, which are compared as text.
+// Ranking by kind first keeps the order consistent for mixed-type values.
+func compareFrontmatterValues(x, y any) int {
+	kindX, kindY := frontmatterValueKind(x), frontmatterValueKind(y)
+	if c := cmp.Compare(kindX, kindY); c != 0 {
+		return c
+	}
+	switch kindX {
+	case numberValue:
+		a, _ := frontmatterNumber(x)
+		b, _ := frontmatterNumber(y)
+		return cmp.Compare(a, b)
+	case timeValue:
+		a, _ := frontmatterTime(x)
+		b, _ := frontmatterTime(y)
+		return a.Compare(b)
+	default:
+		return strings.Compare(fmt.Sprint(x), fmt.Sprint(y))
+	}
+}
+
+// Kinds of frontmatter values, in sort order.
+const (
+	numberValue = iota
+	timeValue
+	textValue
+)
+
+// frontmatterValueKind returns the kind v is compared as when sorting.
+func frontmatterValueKind(v any) int {
+	if _, ok := frontmatterNumber(v); ok {
+		return numberValue
+	}
+	if _, ok := frontmatterTime(v); ok {
+		return timeValue
+	}
+	return textValue
+}
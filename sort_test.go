@@ -0,0 +1,87 @@
+package mcpmds
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"testing/fstest"
+)
+
+func Test_server_listMarkdownFiles_sortBy(t *testing.T) {
+	testFS := fstest.MapFS{
+		"a.md": {Data: []byte("---\ndate: 2024-03-01\nweight: 10\n---\nlonger body text")},
+		"b.md": {Data: []byte("---\ndate: 2023-12-31\nweight: 2\n---\n")},
+		"c.md": {Data: []byte("no frontmatter")},
+		"d.md": {Data: []byte("---\ndate: 2024-03-01\n---\nbody")},
+	}
+	s := &Server{fs: testFS}
+
+	tests := []struct {
+		name       string
+		sortBy     string
+		descending bool
+		want       []string
+	}{
+		{name: "default", want: []string{"a.md", "b.md", "c.md", "d.md"}},
+		{name: "path descending", sortBy: "path", descending: true, want: []string{"d.md", "c.md", "b.md", "a.md"}},
+		{name: "size", sortBy: "size", want: []string{"c.md", "d.md", "b.md", "a.md"}},
+		{name: "size descending", sortBy: "size", descending: true, want: []string{"a.md", "b.md", "d.md", "c.md"}},
+		{name: "date", sortBy: "date", want: []string{"b.md", "a.md", "d.md", "c.md"}},
+		{name: "date descending", sortBy: "date", descending: true, want: []string{"a.md", "d.md", "b.md", "c.md"}},
+		{name: "numeric key", sortBy: "weight", want: []string{"b.md", "a.md", "c.md", "d.md"}},
+		{name: "numeric key descending", sortBy: "weight", descending: true, want: []string{"a.md", "b.md", "c.md", "d.md"}},
+		{name: "missing key", sortBy: "title", want: []string{"a.md", "b.md", "c.md", "d.md"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := s.listMarkdownFiles(context.Background(), &listMarkdownFilesRequest{SortBy: tt.sortBy, Descending: tt.descending})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			var paths []string
+			for _, f := range got.Files {
+				paths = append(paths, f.Path)
+			}
+			if !reflect.DeepEqual(paths, tt.want) {
+				t.Errorf("listMarkdownFiles() = %v, want %v", paths, tt.want)
+			}
+		})
+	}
+}
+
+func Test_sortFiles_mixedTypes(t *testing.T) {
+	weights := map[string]any{
+		"a.md": 10,
+		"b.md": "2",
+		"c.md": "10x",
+		"d.md": "2024-01-01",
+		"e.md": nil,
+	}
+	want := []string{"b.md", "a.md", "d.md", "c.md", "e.md"}
+
+	// Every input order yields the same result.
+	var permute func(paths []string, k int)
+	permute = func(paths []string, k int) {
+		if k == len(paths) {
+			var files []markdownFileInfo
+			for _, p := range paths {
+				files = append(files, markdownFileInfo{Path: p, Frontmatter: map[string]any{"weight": weights[p]}})
+			}
+			sortFiles(files, "weight", false)
+			var got []string
+			for _, f := range files {
+				got = append(got, f.Path)
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("sortFiles(%v) = %v, want %v", paths, got, want)
+			}
+			return
+		}
+		for i := k; i < len(paths); i++ {
+			paths[k], paths[i] = paths[i], paths[k]
+			permute(paths, k+1)
+			paths[k], paths[i] = paths[i], paths[k]
+		}
+	}
+	permute([]string{"a.md", "b.md", "c.md", "d.md", "e.md"}, 0)
+}
@@ -0,0 +1,49 @@
+package mcpmds
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/Warashi/go-modelcontextprotocol/jsonschema"
+	"github.com/Warashi/go-modelcontextprotocol/mcp"
+)
+
+func (s *Server) staleMarkdownFilesTool() mcp.Tool[*staleMarkdownFilesRequest, *staleMarkdownFilesResponse] {
+	return mcp.NewToolFunc(
+		fmt.Sprintf("stale_%s", s.name),
+		fmt.Sprintf("List markdown files managed by %s that have not been modified since the given time, oldest first", s.name),
+		jsonschema.Object{
+			Properties: map[string]jsonschema.Schema{
+				"before": jsonschema.String{
+					Description: "The cutoff time in RFC 3339 format; files modified before it are reported",
+				},
+			},
+			Required: []string{"before"},
+		},
+		s.staleMarkdownFiles,
+	)
+}
+
+type staleMarkdownFilesRequest struct {
+	Before time.Time `json:"before" jsonschema:"required"`
+}
+
+type staleMarkdownFilesResponse struct {
+	Files []markdownFileInfo `json:"files"`
+}
+
+func (s *Server) staleMarkdownFiles(ctx context.Context, request *staleMarkdownFilesRequest) (*staleMarkdownFilesResponse, error) {
+	files := []markdownFileInfo{}
+	for f := range s.markdownFiles(ctx) {
+		if f.ModTime.Before(request.Before) {
+			files = append(files, f)
+		}
+	}
+	slices.SortStableFunc(files, func(a, b markdownFileInfo) int {
+		return cmp.Or(a.ModTime.Compare(b.ModTime), cmp.Compare(a.Path, b.Path))
+	})
+	return &staleMarkdownFilesResponse{Files: files}, nil
+}
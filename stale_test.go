@@ -0,0 +1,61 @@
+package mcpmds
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"testing/fstest"
+	"time"
+)
+
+func Test_server_staleMarkdownFiles(t *testing.T) {
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	testFS := fstest.MapFS{
+		"oldest.md":     {Data: []byte("oldest"), ModTime: base},
+		"dir/older.md":  {Data: []byte("older"), ModTime: base.Add(24 * time.Hour)},
+		"recent.md":     {Data: []byte("recent"), ModTime: base.Add(30 * 24 * time.Hour)},
+		"newest.md":     {Data: []byte("newest"), ModTime: base.Add(60 * 24 * time.Hour)},
+		"ancient.txt":   {Data: []byte("not markdown"), ModTime: base.Add(-24 * time.Hour)},
+		"dir/cutoff.md": {Data: []byte("exactly at cutoff"), ModTime: base.Add(7 * 24 * time.Hour)},
+	}
+
+	s := &Server{fs: testFS}
+
+	tests := []struct {
+		name   string
+		before time.Time
+		want   []string
+	}{
+		{
+			name:   "Cutoff splits files",
+			before: base.Add(7 * 24 * time.Hour),
+			want:   []string{"oldest.md", "dir/older.md"},
+		},
+		{
+			name:   "Cutoff after all files",
+			before: base.Add(365 * 24 * time.Hour),
+			want:   []string{"oldest.md", "dir/older.md", "dir/cutoff.md", "recent.md", "newest.md"},
+		},
+		{
+			name:   "Cutoff before all files",
+			before: base.Add(-365 * 24 * time.Hour),
+			want:   []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := s.staleMarkdownFiles(context.Background(), &staleMarkdownFilesRequest{Before: tt.before})
+			if err != nil {
+				t.Fatalf("staleMarkdownFiles() error = %v", err)
+			}
+			got := []string{}
+			for _, f := range resp.Files {
+				got = append(got, f.Path)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("staleMarkdownFiles() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,59 @@
+package mcpmds
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Warashi/go-modelcontextprotocol/jsonschema"
+	"github.com/Warashi/go-modelcontextprotocol/mcp"
+)
+
+func (s *Server) statsTool() mcp.Tool[*statsRequest, *statsResponse] {
+	return mcp.NewToolFunc(
+		fmt.Sprintf("get_%s_markdown_stats", s.name),
+		fmt.Sprintf("Return aggregate statistics about the markdown files managed by %s: file count, total size, frontmatter presence, and how often each frontmatter key is used", s.name),
+		jsonschema.Object{},
+		s.stats,
+	)
+}
+
+type statsRequest struct{}
+
+type statsResponse struct {
+	// Files is the number of served markdown files.
+	Files int `json:"files"`
+	// TotalBytes is the total size of the files in bytes.
+	TotalBytes int64 `json:"total_bytes"`
+	// WithFrontmatter is the number of files with parsed frontmatter.
+	WithFrontmatter int `json:"with_frontmatter"`
+	// WithoutFrontmatter is the number of files without frontmatter,
+	// including those whose frontmatter failed to parse.
+	WithoutFrontmatter int `json:"without_frontmatter"`
+	// FrontmatterErrors is the number of files whose frontmatter failed to parse.
+	FrontmatterErrors int `json:"frontmatter_errors"`
+	// FrontmatterKeys maps each top-level frontmatter key to the number of
+	// files using it.
+	FrontmatterKeys map[string]int `json:"frontmatter_keys"`
+}
+
+// stats walks the files once, using the metadata gathered by the walk
+// rather than reading any file a second time.
+func (s *Server) stats(ctx context.Context, _ *statsRequest) (*statsResponse, error) {
+	resp := &statsResponse{FrontmatterKeys: map[string]int{}}
+	for f := range s.markdownFiles(ctx) {
+		resp.Files++
+		resp.TotalBytes += f.Size
+		if f.FrontmatterError != "" {
+			resp.FrontmatterErrors++
+		}
+		if f.Frontmatter == nil {
+			resp.WithoutFrontmatter++
+			continue
+		}
+		resp.WithFrontmatter++
+		for key := range f.Frontmatter {
+			resp.FrontmatterKeys[key]++
+		}
+	}
+	return resp, nil
+}
@@ -0,0 +1,41 @@
+package mcpmds
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"testing/fstest"
+)
+
+func TestStats(t *testing.T) {
+	testFS := fstest.MapFS{
+		"a.md":      {Data: []byte("---\ntitle: A\ntags: [x]\n---\nbody")},
+		"dir/b.md":  {Data: []byte("+++\ntitle = \"B\"\ndraft = true\n+++\n")},
+		"plain.md":  {Data: []byte("no frontmatter")},
+		"broken.md": {Data: []byte("---\ntitle: [unclosed\n---\n")},
+		"notes.txt": {Data: []byte("ignored")},
+	}
+	s := &Server{fs: testFS}
+
+	got, err := s.stats(context.Background(), &statsRequest{})
+	if err != nil {
+		t.Fatalf("stats() error = %v", err)
+	}
+	var totalBytes int64
+	for name, f := range testFS {
+		if name != "notes.txt" {
+			totalBytes += int64(len(f.Data))
+		}
+	}
+	want := &statsResponse{
+		Files:              4,
+		TotalBytes:         totalBytes,
+		WithFrontmatter:    2,
+		WithoutFrontmatter: 2,
+		FrontmatterErrors:  1,
+		FrontmatterKeys:    map[string]int{"title": 2, "tags": 1, "draft": 1},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("stats() = %+v, want %+v", got, want)
+	}
+}
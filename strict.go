@@ -0,0 +1,51 @@
+package mcpmds
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+)
+
+// WithStrictFrontmatter makes a file with invalid frontmatter an error: New
+// and the list tool fail, and other tools stop enumerating files at it.
+// By default, such a file is still listed with the parse error in its
+// frontmatter_error field, and the other files are unaffected.
+func WithStrictFrontmatter(enabled bool) ServerOption {
+	return func(s *Server) {
+		s.strictFrontmatter = enabled
+	}
+}
+
+// frontmatterParseError is reported when the frontmatter block of a file
+// cannot be decoded in its format.
+type frontmatterParseError struct {
+	Format string
+	Err    error
+}
+
+func (e *frontmatterParseError) Error() string {
+	return fmt.Sprintf("invalid %s frontmatter: %v", e.Format, e.Err)
+}
+
+func (e *frontmatterParseError) Unwrap() error { return e.Err }
+
+// isFrontmatterParseError reports whether err was caused by invalid frontmatter.
+func isFrontmatterParseError(err error) bool {
+	var parseErr *frontmatterParseError
+	return errors.As(err, &parseErr)
+}
+
+// brokenMarkdownInfo returns the metadata of a file whose frontmatter could
+// not be parsed, recording err in place of the frontmatter.
+func (s *Server) brokenMarkdownInfo(path string, d fs.DirEntry, err error) (markdownFileInfo, error) {
+	info, statErr := d.Info()
+	if statErr != nil {
+		return markdownFileInfo{}, statErr
+	}
+	return markdownFileInfo{
+		Path:             path,
+		Size:             info.Size(),
+		ModTime:          info.ModTime(),
+		FrontmatterError: err.Error(),
+	}, nil
+}
@@ -0,0 +1,69 @@
+package mcpmds
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func TestWithStrictFrontmatter(t *testing.T) {
+	testFS := fstest.MapFS{
+		"good.md":   {Data: []byte("---\ntitle: Good\n---\nbody")},
+		"broken.md": {Data: []byte("---\ntitle: [unclosed\n---\nbody")},
+		"plain.md":  {Data: []byte("body")},
+	}
+
+	tests := []struct {
+		name string
+		opts []ServerOption
+	}{
+		{name: "Default"},
+		{name: "With drafts hidden", opts: []ServerOption{WithHideDrafts("")}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Server{fs: testFS}
+			for _, opt := range tt.opts {
+				opt(s)
+			}
+			resp, err := s.listMarkdownFiles(context.Background(), nil)
+			if err != nil {
+				t.Fatalf("listMarkdownFiles() error = %v", err)
+			}
+			if len(resp.Files) != 3 {
+				t.Fatalf("listMarkdownFiles() returned %d files, want 3", len(resp.Files))
+			}
+			for _, f := range resp.Files {
+				switch f.Path {
+				case "broken.md":
+					if !strings.Contains(f.FrontmatterError, "invalid yaml frontmatter") {
+						t.Errorf("broken.md FrontmatterError = %q, want a yaml parse error", f.FrontmatterError)
+					}
+					if f.Frontmatter != nil || f.Size != int64(len(testFS["broken.md"].Data)) {
+						t.Errorf("broken.md = %+v, want nil frontmatter and its size", f)
+					}
+				case "good.md":
+					if f.FrontmatterError != "" || f.Frontmatter["title"] != "Good" {
+						t.Errorf("good.md = %+v, want parsed frontmatter", f)
+					}
+				default:
+					if f.FrontmatterError != "" {
+						t.Errorf("%s FrontmatterError = %q, want empty", f.Path, f.FrontmatterError)
+					}
+				}
+			}
+		})
+	}
+
+	t.Run("Strict", func(t *testing.T) {
+		s := &Server{fs: testFS}
+		WithStrictFrontmatter(true)(s)
+		if _, err := s.listMarkdownFiles(context.Background(), nil); err == nil {
+			t.Error("listMarkdownFiles() error = nil, want the parse error")
+		}
+		if _, err := New("test", "test", testFS, WithStrictFrontmatter(true)); err == nil {
+			t.Error("New() error = nil, want the parse error")
+		}
+	})
+}
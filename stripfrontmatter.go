@@ -0,0 +1,17 @@
+package mcpmds
+
+// WithStripFrontmatterContent makes read_<name>_markdown_file return the body
+// without its frontmatter block by default, as if every request set
+// strip_frontmatter. The parsed frontmatter is still returned separately.
+func WithStripFrontmatterContent(enabled bool) ServerOption {
+	return func(s *Server) {
+		s.stripFrontmatterContent = enabled
+	}
+}
+
+// stripFrontmatter returns content without its frontmatter block. Content
+// without frontmatter is returned unchanged.
+func (s *Server) stripFrontmatter(content []byte) []byte {
+	_, _, body := s.splitFrontmatter(content)
+	return body
+}
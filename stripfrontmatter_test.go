@@ -0,0 +1,54 @@
+package mcpmds
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"testing/fstest"
+)
+
+func TestReadMarkdownFile_stripFrontmatter(t *testing.T) {
+	testFS := fstest.MapFS{
+		"yaml.md":  {Data: []byte("---\ntitle: YAML\n---\n# Body\n")},
+		"toml.md":  {Data: []byte("+++\ntitle = \"TOML\"\n+++\n# Body\n")},
+		"plain.md": {Data: []byte("# Body\n")},
+		"only.md":  {Data: []byte("---\ntitle: Only\n---\n")},
+	}
+	tests := []struct {
+		path            string
+		wantContent     string
+		wantFrontmatter map[string]any
+	}{
+		{path: "yaml.md", wantContent: "# Body\n", wantFrontmatter: map[string]any{"title": "YAML"}},
+		{path: "toml.md", wantContent: "# Body\n", wantFrontmatter: map[string]any{"title": "TOML"}},
+		{path: "plain.md", wantContent: "# Body\n"},
+		{path: "only.md", wantContent: "", wantFrontmatter: map[string]any{"title": "Only"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			for name, s := range map[string]*Server{
+				"request": newServer("test", "test", testFS),
+				"option":  newServer("test", "test", testFS, WithStripFrontmatterContent(true)),
+			} {
+				got, err := s.readMarkdownFile(context.Background(), &readMarkdownFileRequest{Path: tt.path, StripFrontmatter: name == "request"})
+				if err != nil {
+					t.Fatalf("%s: readMarkdownFile() error = %v", name, err)
+				}
+				if got.Content != tt.wantContent {
+					t.Errorf("%s: content = %q, want %q", name, got.Content, tt.wantContent)
+				}
+				if !reflect.DeepEqual(got.Frontmatter, tt.wantFrontmatter) {
+					t.Errorf("%s: frontmatter = %#v, want %#v", name, got.Frontmatter, tt.wantFrontmatter)
+				}
+			}
+		})
+	}
+
+	got, err := newServer("test", "test", testFS).readMarkdownFile(context.Background(), &readMarkdownFileRequest{Path: "yaml.md"})
+	if err != nil {
+		t.Fatalf("readMarkdownFile() error = %v", err)
+	}
+	if want := "---\ntitle: YAML\n---\n# Body\n"; got.Content != want {
+		t.Errorf("unstripped content = %q, want %q", got.Content, want)
+	}
+}
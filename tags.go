@@ -0,0 +1,71 @@
+package mcpmds
+
+import (
+	"cmp"
+	"strings"
+)
+
+// defaultTagsKey is the frontmatter key holding the tags of a file unless
+// WithTagsKey sets another.
+const defaultTagsKey = "tags"
+
+// WithTagsKey sets the frontmatter key holding the tags of a file, which may
+// be a single string or a list. The default is "tags".
+func WithTagsKey(key string) ServerOption {
+	return func(s *Server) {
+		s.tagsKey = key
+	}
+}
+
+// WithLowercaseTags lowercases the values of the frontmatter tags key when
+// files are parsed, dropping duplicates that differ only in case, so that all
+// tag-based tools match tags case-insensitively. Tags given in requests are
+// lowercased the same way. Other values keep their casing.
+func WithLowercaseTags(enabled bool) ServerOption {
+	return func(s *Server) {
+		s.lowercaseTags = enabled
+	}
+}
+
+// tagsFrontmatterKey returns the frontmatter key holding the tags of a file.
+func (s *Server) tagsFrontmatterKey() string {
+	return cmp.Or(s.tagsKey, defaultTagsKey)
+}
+
+// frontmatterTags returns the tags in the tags key of frontmatter, which may
+// be a single string or a list.
+func (s *Server) frontmatterTags(frontmatter map[string]any) []string {
+	return stringList(frontmatter[s.tagsFrontmatterKey()])
+}
+
+// normalizeTag returns tag as it is stored in parsed frontmatter, lowercased
+// when WithLowercaseTags is enabled, so that requested tags match stored ones.
+func (s *Server) normalizeTag(tag string) string {
+	if s.lowercaseTags {
+		return strings.ToLower(tag)
+	}
+	return tag
+}
+
+// lowercaseTags lowercases the tags in the key of frontmatter in place.
+func lowercaseTags(frontmatter map[string]any, key string) {
+	switch v := frontmatter[key].(type) {
+	case string:
+		frontmatter[key] = strings.ToLower(v)
+	case []any:
+		tags := make([]any, 0, len(v))
+		seen := map[string]bool{}
+		for _, e := range v {
+			if tag, ok := e.(string); ok {
+				tag = strings.ToLower(tag)
+				if seen[tag] {
+					continue
+				}
+				seen[tag] = true
+				e = tag
+			}
+			tags = append(tags, e)
+		}
+		frontmatter[key] = tags
+	}
+}
@@ -0,0 +1,102 @@
+package mcpmds
+
+import (
+	"context"
+	"reflect"
+	"slices"
+	"testing"
+	"testing/fstest"
+)
+
+func Test_server_lowercaseTags(t *testing.T) {
+	testFS := fstest.MapFS{
+		"a.md": {Data: []byte("---\ntitle: Go Basics\ntags: [Go, go, Tooling]\n---\n")},
+		"b.md": {Data: []byte("---\ntags: go\n---\n")},
+		"c.md": {Data: []byte("---\ntags: [Rust]\n---\n")},
+	}
+
+	tests := []struct {
+		name      string
+		opts      []ServerOption
+		wantTags  map[string]any
+		wantTitle string
+		wantGo    []string
+	}{
+		{
+			name: "Enabled",
+			opts: []ServerOption{WithLowercaseTags(true)},
+			wantTags: map[string]any{
+				"a.md": []any{"go", "tooling"},
+				"b.md": "go",
+				"c.md": []any{"rust"},
+			},
+			wantTitle: "Go Basics",
+			wantGo:    []string{"a.md", "b.md"},
+		},
+		{
+			name: "Disabled",
+			wantTags: map[string]any{
+				"a.md": []any{"Go", "go", "Tooling"},
+				"b.md": "go",
+				"c.md": []any{"Rust"},
+			},
+			wantTitle: "Go Basics",
+			wantGo:    []string{"a.md", "b.md"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Server{fs: testFS}
+			for _, opt := range tt.opts {
+				opt(s)
+			}
+			for f := range s.markdownFiles(context.Background()) {
+				if !reflect.DeepEqual(f.Frontmatter["tags"], tt.wantTags[f.Path]) {
+					t.Errorf("tags of %s = %#v, want %#v", f.Path, f.Frontmatter["tags"], tt.wantTags[f.Path])
+				}
+				if f.Path == "a.md" && f.Frontmatter["title"] != tt.wantTitle {
+					t.Errorf("title = %v, want %v", f.Frontmatter["title"], tt.wantTitle)
+				}
+			}
+
+			got, err := s.bundleByTag(context.Background(), &bundleByTagRequest{Tag: "go"})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !slices.Equal(got.Files, tt.wantGo) {
+				t.Errorf("bundleByTag(go) files = %v, want %v", got.Files, tt.wantGo)
+			}
+		})
+	}
+}
+
+func Test_server_lowercaseTags_queries(t *testing.T) {
+	testFS := fstest.MapFS{
+		"a.md": {Data: []byte("---\nlabels: [Go]\ntags: [Rust]\n---\n")},
+		"b.md": {Data: []byte("---\nlabels: go\n---\n")},
+	}
+	s := newServer("test", "test", testFS, WithTagsKey("labels"), WithLowercaseTags(true), WithSavedFilters(map[string]Filter{
+		"go": {Tags: []string{"GO"}},
+	}))
+
+	for f := range s.markdownFiles(context.Background()) {
+		if f.Path == "a.md" && !reflect.DeepEqual(f.Frontmatter["tags"], []any{"Rust"}) {
+			t.Errorf("tags of a.md = %#v, want the tags key left as is", f.Frontmatter["tags"])
+		}
+	}
+	bundle, err := s.bundleByTag(context.Background(), &bundleByTagRequest{Tag: "Go"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := []string{"a.md", "b.md"}; !slices.Equal(bundle.Files, want) {
+		t.Errorf("bundleByTag(Go) files = %v, want %v", bundle.Files, want)
+	}
+	filtered, err := s.savedFilter(context.Background(), &savedFilterRequest{Filter: "go"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(filtered.Files) != 2 {
+		t.Errorf("savedFilter(go) matched %d files, want 2", len(filtered.Files))
+	}
+}
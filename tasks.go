@@ -0,0 +1,84 @@
+package mcpmds
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/Warashi/go-modelcontextprotocol/jsonschema"
+	"github.com/Warashi/go-modelcontextprotocol/mcp"
+)
+
+func (s *Server) tasksMarkdownFileTool() mcp.Tool[*tasksMarkdownFileRequest, *tasksMarkdownFileResponse] {
+	return mcp.NewToolFunc(
+		fmt.Sprintf("tasks_%s_markdown_file", s.name),
+		fmt.Sprintf("List the task list items of a markdown file managed by %s with its completion percentage", s.name),
+		jsonschema.Object{
+			Properties: map[string]jsonschema.Schema{
+				"path": jsonschema.String{
+					Description: "The path to the markdown file",
+				},
+			},
+			Required: []string{"path"},
+		},
+		s.tasksMarkdownFile,
+	)
+}
+
+type tasksMarkdownFileRequest struct {
+	Path string `json:"path" jsonschema:"required"`
+}
+
+type tasksMarkdownFileResponse struct {
+	// Path is the relative path to the markdown file.
+	Path string `json:"path"`
+	// Tasks are the task list items in document order.
+	Tasks []taskItem `json:"tasks"`
+	// Total is the number of task list items.
+	Total int `json:"total"`
+	// Completed is the number of checked task list items.
+	Completed int `json:"completed"`
+	// Percent is the percentage of checked items, or 0 if there are none.
+	Percent float64 `json:"percent"`
+}
+
+// taskItem is a single GitHub-flavored task list item.
+type taskItem struct {
+	// Text is the text of the item after the checkbox.
+	Text string `json:"text"`
+	// Checked reports whether the item is checked.
+	Checked bool `json:"checked"`
+	// Line is the 1-based line number of the item within the body.
+	Line int `json:"line"`
+}
+
+var taskItemPattern = regexp.MustCompile(`^\s*(?:[-*+]|\d+[.)])\s+\[([ xX])\]\s+(.*)$`)
+
+func (s *Server) tasksMarkdownFile(ctx context.Context, request *tasksMarkdownFileRequest) (*tasksMarkdownFileResponse, error) {
+	path, content, err := s.readRequestedMarkdown(request.Path)
+	if err != nil {
+		return nil, err
+	}
+	_, _, body := s.splitFrontmatter(content)
+
+	resp := &tasksMarkdownFileResponse{Path: path, Tasks: []taskItem{}}
+	for line := range markdownLines(body) {
+		if line.InCode {
+			continue
+		}
+		m := taskItemPattern.FindStringSubmatch(line.Text)
+		if m == nil {
+			continue
+		}
+		item := taskItem{Text: m[2], Checked: m[1] != " ", Line: line.Number}
+		resp.Tasks = append(resp.Tasks, item)
+		if item.Checked {
+			resp.Completed++
+		}
+	}
+	resp.Total = len(resp.Tasks)
+	if resp.Total > 0 {
+		resp.Percent = float64(resp.Completed) * 100 / float64(resp.Total)
+	}
+	return resp, nil
+}
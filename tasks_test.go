@@ -0,0 +1,88 @@
+package mcpmds
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"testing/fstest"
+)
+
+func Test_server_tasksMarkdownFile(t *testing.T) {
+	testFS := fstest.MapFS{
+		"todo.md": {Data: []byte(`---
+title: Release checklist
+---
+# Release
+
+- [x] Write changelog
+- [ ] Tag release
+* [X] Run tests
+  - [ ] Nested item
+1. [ ] Announce
+
+` + "```" + `markdown
+- [ ] Not a task, inside a code block
+` + "```" + `
+- [] Not a task either
+`)},
+		"notes.md": {Data: []byte("# Notes\n\n- plain item\n")},
+	}
+
+	s := &Server{fs: testFS}
+
+	tests := []struct {
+		name    string
+		path    string
+		want    *tasksMarkdownFileResponse
+		wantErr bool
+	}{
+		{
+			name: "Mixed checked and unchecked tasks",
+			path: "todo.md",
+			want: &tasksMarkdownFileResponse{
+				Path: "todo.md",
+				Tasks: []taskItem{
+					{Text: "Write changelog", Checked: true, Line: 3},
+					{Text: "Tag release", Checked: false, Line: 4},
+					{Text: "Run tests", Checked: true, Line: 5},
+					{Text: "Nested item", Checked: false, Line: 6},
+					{Text: "Announce", Checked: false, Line: 7},
+				},
+				Total:     5,
+				Completed: 2,
+				Percent:   40,
+			},
+		},
+		{
+			name: "No tasks",
+			path: "notes.md",
+			want: &tasksMarkdownFileResponse{
+				Path:  "notes.md",
+				Tasks: []taskItem{},
+			},
+		},
+		{
+			name:    "Non-existent file",
+			path:    "missing.md",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := s.tasksMarkdownFile(context.Background(), &tasksMarkdownFileRequest{Path: tt.path})
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected an error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("tasksMarkdownFile()\n got = %+v,\nwant = %+v", got, tt.want)
+			}
+		})
+	}
+}
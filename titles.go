@@ -0,0 +1,93 @@
+package mcpmds
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/Warashi/go-modelcontextprotocol/jsonschema"
+	"github.com/Warashi/go-modelcontextprotocol/mcp"
+)
+
+// documentTitle returns the title of a markdown file: the frontmatter title
+// when present, otherwise the text of the first level-one heading.
+func (s *Server) documentTitle(info markdownFileInfo) (string, error) {
+	if title, ok := info.Frontmatter["title"].(string); ok && strings.TrimSpace(title) != "" {
+		return strings.TrimSpace(title), nil
+	}
+	headings, err := s.fileHeadings(info.Path)
+	if err != nil {
+		return "", err
+	}
+	return firstHeading(headings), nil
+}
+
+// firstHeading returns the text of the first level-one heading in headings,
+// or an empty string if there is none.
+func firstHeading(headings []heading) string {
+	for _, h := range headings {
+		if h.Level == 1 {
+			return h.Text
+		}
+	}
+	return ""
+}
+
+func (s *Server) titleUniquenessTool() mcp.Tool[*titleUniquenessRequest, *titleUniquenessResponse] {
+	return mcp.NewToolFunc(
+		fmt.Sprintf("title_uniqueness_%s", s.name),
+		fmt.Sprintf("Check whether all markdown files managed by %s have distinct titles and list any collisions", s.name),
+		jsonschema.Object{},
+		s.titleUniqueness,
+	)
+}
+
+type titleUniquenessRequest struct{}
+
+type titleUniquenessResponse struct {
+	// Unique reports whether no two files share a title.
+	Unique bool `json:"unique"`
+	// Collisions lists the titles shared by more than one file.
+	Collisions []titleCollision `json:"collisions"`
+	// Untitled lists the files without a frontmatter title or level-one heading.
+	Untitled []string `json:"untitled"`
+}
+
+// titleCollision is a title shared by several files.
+type titleCollision struct {
+	Title string   `json:"title"`
+	Paths []string `json:"paths"`
+}
+
+func (s *Server) titleUniqueness(ctx context.Context, _ *titleUniquenessRequest) (*titleUniquenessResponse, error) {
+	byTitle := map[string]*titleCollision{}
+	resp := &titleUniquenessResponse{Collisions: []titleCollision{}, Untitled: []string{}}
+	for f := range s.markdownFiles(ctx) {
+		title, err := s.documentTitle(f)
+		if err != nil {
+			return nil, err
+		}
+		if title == "" {
+			resp.Untitled = append(resp.Untitled, f.Path)
+			continue
+		}
+		key := strings.ToLower(title)
+		if byTitle[key] == nil {
+			byTitle[key] = &titleCollision{Title: title}
+		}
+		byTitle[key].Paths = append(byTitle[key].Paths, f.Path)
+	}
+	for _, key := range slices.Sorted(maps.Keys(byTitle)) {
+		if c := byTitle[key]; len(c.Paths) > 1 {
+			resp.Collisions = append(resp.Collisions, *c)
+		}
+	}
+	slices.SortFunc(resp.Collisions, func(a, b titleCollision) int {
+		return cmp.Compare(a.Paths[0], b.Paths[0])
+	})
+	resp.Unique = len(resp.Collisions) == 0
+	return resp, nil
+}
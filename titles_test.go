@@ -0,0 +1,62 @@
+package mcpmds
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"testing/fstest"
+)
+
+func Test_server_titleUniqueness(t *testing.T) {
+	tests := []struct {
+		name string
+		fs   fstest.MapFS
+		want *titleUniquenessResponse
+	}{
+		{
+			name: "Unique titles",
+			fs: fstest.MapFS{
+				"a.md": {Data: []byte("---\ntitle: Alpha\n---\n# Heading ignored")},
+				"b.md": {Data: []byte("# Beta\n\ncontent")},
+				"c.md": {Data: []byte("+++\ntitle = \"Gamma\"\n+++\n")},
+				"d.md": {Data: []byte("no title here\n\n```\n# Not a heading\n```\n")},
+			},
+			want: &titleUniquenessResponse{
+				Unique:     true,
+				Collisions: []titleCollision{},
+				Untitled:   []string{"d.md"},
+			},
+		},
+		{
+			name: "Colliding titles",
+			fs: fstest.MapFS{
+				"a.md":       {Data: []byte("---\ntitle: Getting Started\n---\n")},
+				"docs/b.md":  {Data: []byte("# getting started\n")},
+				"docs/c.md":  {Data: []byte("# Reference\n")},
+				"other/d.md": {Data: []byte("---\ntitle: Reference\n---\n")},
+				"other/e.md": {Data: []byte("# Unique\n")},
+			},
+			want: &titleUniquenessResponse{
+				Unique: false,
+				Collisions: []titleCollision{
+					{Title: "Getting Started", Paths: []string{"a.md", "docs/b.md"}},
+					{Title: "Reference", Paths: []string{"docs/c.md", "other/d.md"}},
+				},
+				Untitled: []string{},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Server{fs: tt.fs}
+			got, err := s.titleUniqueness(context.Background(), nil)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("titleUniqueness()\n got = %+v,\nwant = %+v", got, tt.want)
+			}
+		})
+	}
+}
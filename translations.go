@@ -0,0 +1,97 @@
+package mcpmds
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/Warashi/go-modelcontextprotocol/jsonschema"
+	"github.com/Warashi/go-modelcontextprotocol/mcp"
+)
+
+func (s *Server) missingTranslationsTool() mcp.Tool[*missingTranslationsRequest, *missingTranslationsResponse] {
+	return mcp.NewToolFunc(
+		fmt.Sprintf("missing_translations_%s", s.name),
+		fmt.Sprintf("List the source markdown files managed by %s that have no translation into a language, using the lang and translationOf frontmatter keys", s.name),
+		jsonschema.Object{
+			Properties: map[string]jsonschema.Schema{
+				"lang": jsonschema.String{
+					Description: "The target language, compared case-insensitively with the lang frontmatter key",
+				},
+			},
+			Required: []string{"lang"},
+		},
+		s.missingTranslations,
+	)
+}
+
+type missingTranslationsRequest struct {
+	Lang string `json:"lang" jsonschema:"required"`
+}
+
+type missingTranslationsResponse struct {
+	// Lang is the target language.
+	Lang string `json:"lang"`
+	// Missing are the source files without a translation into Lang, in path order.
+	Missing []untranslatedFile `json:"missing"`
+}
+
+// untranslatedFile is a source file lacking a translation.
+type untranslatedFile struct {
+	Path string `json:"path"`
+	// Lang is the language of the source file, if it declares one.
+	Lang string `json:"lang,omitempty"`
+}
+
+// translationKey identifies the translation of a source file into a language.
+type translationKey struct {
+	source string
+	lang   string
+}
+
+// frontmatterLang returns the normalized lang frontmatter value.
+func frontmatterLang(frontmatter map[string]any) string {
+	lang, _ := frontmatter["lang"].(string)
+	return strings.ToLower(strings.TrimSpace(lang))
+}
+
+// translationSource returns the path of the source file named by the
+// translationOf frontmatter value, written relative to the root with or
+// without a leading slash.
+func translationSource(frontmatter map[string]any) string {
+	source, _ := frontmatter["translationOf"].(string)
+	if source = strings.TrimSpace(source); source == "" {
+		return ""
+	}
+	return strings.TrimPrefix(path.Clean("/"+source), "/")
+}
+
+func (s *Server) missingTranslations(ctx context.Context, request *missingTranslationsRequest) (*missingTranslationsResponse, error) {
+	lang := strings.ToLower(strings.TrimSpace(request.Lang))
+	if lang == "" {
+		return nil, errors.New("lang is required")
+	}
+	files, err := s.collectMarkdownFiles(ctx)
+	if err != nil {
+		return nil, err
+	}
+	translated := map[translationKey]bool{}
+	for _, f := range files {
+		if source := translationSource(f.Frontmatter); source != "" {
+			translated[translationKey{source: source, lang: frontmatterLang(f.Frontmatter)}] = true
+		}
+	}
+	resp := &missingTranslationsResponse{Lang: request.Lang, Missing: []untranslatedFile{}}
+	for _, f := range files {
+		if translationSource(f.Frontmatter) != "" || frontmatterLang(f.Frontmatter) == lang {
+			continue
+		}
+		if !translated[translationKey{source: f.Path, lang: lang}] {
+			fileLang, _ := f.Frontmatter["lang"].(string)
+			resp.Missing = append(resp.Missing, untranslatedFile{Path: f.Path, Lang: fileLang})
+		}
+	}
+	return resp, nil
+}
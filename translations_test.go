@@ -0,0 +1,66 @@
+package mcpmds
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"testing/fstest"
+)
+
+func TestMissingTranslations(t *testing.T) {
+	testFS := fstest.MapFS{
+		"en/intro.md":    {Data: []byte("---\nlang: en\n---\n")},
+		"en/guide.md":    {Data: []byte("---\nlang: en\n---\n")},
+		"en/faq.md":      {Data: []byte("---\nlang: en\n---\n")},
+		"notes.md":       {Data: []byte("no frontmatter")},
+		"ja/intro.md":    {Data: []byte("---\nlang: ja\ntranslationOf: /en/intro.md\n---\n")},
+		"fr/intro.md":    {Data: []byte("---\nlang: fr\ntranslationOf: en/intro.md\n---\n")},
+		"fr/guide.md":    {Data: []byte("---\nlang: FR\ntranslationOf: en/guide.md\n---\n")},
+		"ja/original.md": {Data: []byte("---\nlang: ja\n---\n")},
+	}
+	s := &Server{fs: testFS}
+
+	tests := []struct {
+		lang string
+		want []untranslatedFile
+	}{
+		{
+			lang: "ja",
+			want: []untranslatedFile{
+				{Path: "en/faq.md", Lang: "en"},
+				{Path: "en/guide.md", Lang: "en"},
+				{Path: "notes.md"},
+			},
+		},
+		{
+			lang: "fr",
+			want: []untranslatedFile{
+				{Path: "en/faq.md", Lang: "en"},
+				{Path: "ja/original.md", Lang: "ja"},
+				{Path: "notes.md"},
+			},
+		},
+		{
+			lang: "en",
+			want: []untranslatedFile{
+				{Path: "ja/original.md", Lang: "ja"},
+				{Path: "notes.md"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.lang, func(t *testing.T) {
+			got, err := s.missingTranslations(context.Background(), &missingTranslationsRequest{Lang: tt.lang})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got.Missing, tt.want) {
+				t.Errorf("missingTranslations() = %+v, want %+v", got.Missing, tt.want)
+			}
+		})
+	}
+
+	if _, err := s.missingTranslations(context.Background(), &missingTranslationsRequest{Lang: " "}); err == nil {
+		t.Error("missingTranslations() with blank lang: error = nil, want error")
+	}
+}
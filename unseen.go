@@ -0,0 +1,82 @@
+package mcpmds
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Warashi/go-modelcontextprotocol/jsonschema"
+	"github.com/Warashi/go-modelcontextprotocol/mcp"
+)
+
+func (s *Server) unseenTool() mcp.Tool[*unseenRequest, *unseenResponse] {
+	return mcp.NewToolFunc(
+		fmt.Sprintf("unseen_%s", s.name),
+		fmt.Sprintf("List markdown files managed by %s that the client has not consumed yet, to iterate through all documents without repeats", s.name),
+		jsonschema.Object{
+			Properties: map[string]jsonschema.Schema{
+				"seen": jsonschema.Array{
+					Description: "The paths or SHA-256 content hashes, as in the manifest, of the files already consumed",
+					Items:       jsonschema.String{},
+				},
+				"limit": jsonschema.Integer{
+					Description: "The maximum number of files to return; 0 returns all unseen files",
+				},
+			},
+		},
+		s.unseen,
+	)
+}
+
+type unseenRequest struct {
+	Seen  []string `json:"seen"`
+	Limit int      `json:"limit"`
+}
+
+type unseenResponse struct {
+	Files []unseenFile `json:"files"`
+	// Remaining is the number of unseen files, including those cut by limit.
+	Remaining int `json:"remaining"`
+}
+
+// unseenFile is an unseen markdown file with the hash to report as seen.
+type unseenFile struct {
+	markdownFileInfo
+	// SHA256 is the hex-encoded SHA-256 hash of the file content.
+	SHA256 string `json:"sha256"`
+}
+
+// unseen returns the files whose path and content hash are both absent from
+// the request's seen list, in path order. A file seen by hash reappears once
+// its content changes; a file seen by path does not.
+func (s *Server) unseen(ctx context.Context, request *unseenRequest) (*unseenResponse, error) {
+	if request.Limit < 0 {
+		return nil, errors.New("limit must not be negative")
+	}
+	seen := map[string]bool{}
+	for _, v := range request.Seen {
+		seen[v] = true
+		if p, err := s.requestPath(v); err == nil {
+			seen[p] = true
+		}
+	}
+	resp := &unseenResponse{Files: []unseenFile{}}
+	for f := range s.markdownFiles(ctx) {
+		if seen[f.Path] {
+			continue
+		}
+		content, err := s.readMarkdown(f.Path)
+		if err != nil {
+			return nil, err
+		}
+		hash := contentSHA256(content)
+		if seen[hash] {
+			continue
+		}
+		resp.Remaining++
+		if request.Limit == 0 || len(resp.Files) < request.Limit {
+			resp.Files = append(resp.Files, unseenFile{markdownFileInfo: f, SHA256: hash})
+		}
+	}
+	return resp, nil
+}
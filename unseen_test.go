@@ -0,0 +1,83 @@
+package mcpmds
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"testing"
+	"testing/fstest"
+)
+
+func Test_server_unseen(t *testing.T) {
+	testFS := fstest.MapFS{
+		"a.md":     {Data: []byte("# A\n")},
+		"b.md":     {Data: []byte("# B\n")},
+		"dir/c.md": {Data: []byte("# C\n")},
+		"dir/d.md": {Data: []byte("# D\n")},
+	}
+	s := &Server{fs: testFS}
+
+	tests := []struct {
+		name          string
+		request       *unseenRequest
+		want          []string
+		wantRemaining int
+	}{
+		{name: "Nothing seen", request: &unseenRequest{}, want: []string{"a.md", "b.md", "dir/c.md", "dir/d.md"}, wantRemaining: 4},
+		{name: "Seen by path", request: &unseenRequest{Seen: []string{"a.md", "dir/c.md"}}, want: []string{"b.md", "dir/d.md"}, wantRemaining: 2},
+		{name: "Seen by hash", request: &unseenRequest{Seen: []string{contentSHA256(testFS["b.md"].Data)}}, want: []string{"a.md", "dir/c.md", "dir/d.md"}, wantRemaining: 3},
+		{name: "Limit", request: &unseenRequest{Seen: []string{"a.md"}, Limit: 2}, want: []string{"b.md", "dir/c.md"}, wantRemaining: 3},
+		{name: "All seen", request: &unseenRequest{Seen: []string{"a.md", "b.md", "dir/c.md", "dir/d.md"}}, want: []string{}, wantRemaining: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := s.unseen(context.Background(), tt.request)
+			if err != nil {
+				t.Fatalf("unseen() error = %v", err)
+			}
+			got := []string{}
+			for _, f := range resp.Files {
+				got = append(got, f.Path)
+				if f.SHA256 != contentSHA256(testFS[f.Path].Data) {
+					t.Errorf("%s SHA256 = %s, want the content hash", f.Path, f.SHA256)
+				}
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("unseen() = %v, want %v", got, tt.want)
+			}
+			if resp.Remaining != tt.wantRemaining {
+				t.Errorf("Remaining = %d, want %d", resp.Remaining, tt.wantRemaining)
+			}
+		})
+	}
+
+	t.Run("Changed content reappears when seen by hash", func(t *testing.T) {
+		seen := []string{contentSHA256(testFS["a.md"].Data)}
+		testFS["a.md"] = &fstest.MapFile{Data: []byte("# A, revised\n")}
+		resp, err := s.unseen(context.Background(), &unseenRequest{Seen: seen})
+		if err != nil {
+			t.Fatalf("unseen() error = %v", err)
+		}
+		if len(resp.Files) != 4 || resp.Files[0].Path != "a.md" {
+			t.Errorf("unseen() = %+v, want a.md among all files", resp.Files)
+		}
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		resp, err := s.unseen(context.Background(), &unseenRequest{Limit: 1})
+		if err != nil {
+			t.Fatalf("unseen() error = %v", err)
+		}
+		b, err := json.Marshal(resp.Files[0])
+		if err != nil {
+			t.Fatal(err)
+		}
+		var got map[string]any
+		if err := json.Unmarshal(b, &got); err != nil {
+			t.Fatal(err)
+		}
+		if got["path"] != "a.md" || got["sha256"] == nil {
+			t.Errorf("unseen file JSON = %s, want path and sha256 at the top level", b)
+		}
+	})
+}
@@ -0,0 +1,54 @@
+package mcpmds
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Warashi/go-modelcontextprotocol/jsonschema"
+	"github.com/Warashi/go-modelcontextprotocol/mcp"
+)
+
+// WithValidator sets a function checking the frontmatter of each markdown file
+// for the validate_custom tool. It returns an error describing why a file
+// fails, or nil if the file passes. The frontmatter is passed as served,
+// after exclusions and defaults are applied, and may be nil.
+func WithValidator(validate func(path string, frontmatter map[string]any) error) ServerOption {
+	return func(s *Server) {
+		s.validator = validate
+	}
+}
+
+func (s *Server) validateCustomTool() mcp.Tool[*validateCustomRequest, *validateCustomResponse] {
+	return mcp.NewToolFunc(
+		fmt.Sprintf("validate_custom_%s", s.name),
+		fmt.Sprintf("List markdown files managed by %s whose frontmatter fails the server's custom validation rules", s.name),
+		jsonschema.Object{},
+		s.validateCustom,
+	)
+}
+
+type validateCustomRequest struct{}
+
+type validateCustomResponse struct {
+	Failures []validationFailure `json:"failures"`
+}
+
+// validationFailure is a file failing validation.
+type validationFailure struct {
+	Path    string `json:"path"`
+	Message string `json:"message"`
+}
+
+func (s *Server) validateCustom(ctx context.Context, _ *validateCustomRequest) (*validateCustomResponse, error) {
+	if s.validator == nil {
+		return nil, errors.New("no validator is configured")
+	}
+	resp := &validateCustomResponse{Failures: []validationFailure{}}
+	for f := range s.markdownFiles(ctx) {
+		if err := s.validator(f.Path, f.Frontmatter); err != nil {
+			resp.Failures = append(resp.Failures, validationFailure{Path: f.Path, Message: err.Error()})
+		}
+	}
+	return resp, nil
+}
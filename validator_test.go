@@ -0,0 +1,47 @@
+package mcpmds
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"testing/fstest"
+)
+
+func Test_server_validateCustom(t *testing.T) {
+	testFS := fstest.MapFS{
+		"owned.md":   {Data: []byte("---\nowner: alice\n---\n")},
+		"empty.md":   {Data: []byte("---\nowner: \"\"\n---\n")},
+		"missing.md": {Data: []byte("# No frontmatter\n")},
+	}
+
+	requireOwner := func(path string, frontmatter map[string]any) error {
+		if owner, _ := frontmatter["owner"].(string); owner == "" {
+			return errors.New("owner is required")
+		}
+		return nil
+	}
+
+	t.Run("Owner required", func(t *testing.T) {
+		s := &Server{fs: testFS}
+		WithValidator(requireOwner)(s)
+		got, err := s.validateCustom(context.Background(), &validateCustomRequest{})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		want := []validationFailure{
+			{Path: "empty.md", Message: "owner is required"},
+			{Path: "missing.md", Message: "owner is required"},
+		}
+		if !reflect.DeepEqual(got.Failures, want) {
+			t.Errorf("validateCustom() = %#v, want %#v", got.Failures, want)
+		}
+	})
+
+	t.Run("No validator", func(t *testing.T) {
+		s := &Server{fs: testFS}
+		if _, err := s.validateCustom(context.Background(), &validateCustomRequest{}); err == nil {
+			t.Error("validateCustom() succeeded, want error")
+		}
+	})
+}
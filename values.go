@@ -0,0 +1,50 @@
+package mcpmds
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// frontmatterNumber returns v as a number when it is numeric or a string
+// holding a number.
+func frontmatterNumber(v any) (float64, bool) {
+	switch v := v.(type) {
+	case int:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case uint64:
+		return float64(v), true
+	case float64:
+		return v, true
+	case string:
+		f, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+		return f, err == nil
+	}
+	return 0, false
+}
+
+// frontmatterTimeLayouts are the layouts accepted for dates written as strings.
+var frontmatterTimeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	time.DateOnly,
+}
+
+// frontmatterTime returns v as a time when it is a time or a string holding a
+// date in one of frontmatterTimeLayouts.
+func frontmatterTime(v any) (time.Time, bool) {
+	switch v := v.(type) {
+	case time.Time:
+		return v, true
+	case string:
+		for _, layout := range frontmatterTimeLayouts {
+			if t, err := time.Parse(layout, strings.TrimSpace(v)); err == nil {
+				return t, true
+			}
+		}
+	}
+	return time.Time{}, false
+}
@@ -0,0 +1,236 @@
+package mcpmds
+
+import (
+	"context"
+	"encoding/json"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"reflect"
+	"sync"
+
+	"github.com/Warashi/go-modelcontextprotocol/mcp"
+	"github.com/Warashi/go-modelcontextprotocol/transport"
+	"github.com/fsnotify/fsnotify"
+)
+
+// resourcesListChanged is the notification sent to clients when the set of
+// served resources changes.
+var resourcesListChanged = json.RawMessage(`{"jsonrpc":"2.0","method":"notifications/resources/list_changed"}`)
+
+// WithWatch watches the served directory for markdown files being created,
+// deleted, renamed, or written while clients are connected. On a change, the
+// resource list is rebuilt and connected clients receive a
+// notifications/resources/list_changed notification if it differs.
+// Changes to frontmatter fields are recorded and reported by the
+// frontmatter_changes tool.
+// Watching requires a filesystem created by os.DirFS and a server run by
+// ServeStdio or NewHandler; otherwise the resource list stays fixed.
+func WithWatch(enabled bool) ServerOption {
+	return func(s *Server) {
+		s.watch = enabled
+	}
+}
+
+// watchDir returns the directory behind fsys if it was created by os.DirFS.
+func watchDir(fsys fs.FS) (string, bool) {
+	t := reflect.TypeOf(fsys)
+	if t == nil || t.PkgPath() != "os" || t.Name() != "dirFS" || t.Kind() != reflect.String {
+		return "", false
+	}
+	return reflect.ValueOf(fsys).String(), true
+}
+
+// setResources replaces the resource list reported by resources/list in
+// watch mode and reports whether it changed.
+func (s *Server) setResources(resources []annotatedResource) bool {
+	s.resourcesMu.Lock()
+	defer s.resourcesMu.Unlock()
+	if reflect.DeepEqual(s.resources, resources) {
+		return false
+	}
+	s.resources = resources
+	return true
+}
+
+// watchedResourcesOption returns options serving resources/list from the
+// current resource list. The list_changed notifications are advertised by
+// initializeOption.
+func (s *Server) watchedResourcesOption() mcp.ServerOption {
+	return func(srv *mcp.Server) {
+		mcp.WithCustomHandlerFunc("resources/list", func(ctx context.Context, _ *mcp.Request[mcp.ListResourcesRequestParams]) (*mcp.Result[annotatedResourceList], error) {
+			s.resourcesMu.Lock()
+			defer s.resourcesMu.Unlock()
+			return &mcp.Result[annotatedResourceList]{Data: annotatedResourceList{Resources: s.resources}}, nil
+		})(srv)
+	}
+}
+
+// reindex records frontmatter changes and rebuilds the resource list.
+func (s *Server) reindex() {
+	s.resetAliasIndex()
+	s.snapshotFrontmatter()
+	s.reindexResources()
+}
+
+// reindexResources rebuilds the resource list and notifies connected clients
+// if it changed. A failed rebuild keeps the previous list.
+func (s *Server) reindexResources() {
+	resources, err := s.resourceList()
+	if err != nil || !s.setResources(resources) {
+		return
+	}
+	s.watchMu.Lock()
+	defer s.watchMu.Unlock()
+	for _, session := range s.sessions {
+		session.Send(resourcesListChanged)
+	}
+}
+
+// sessionHandler returns a handler serving MCP sessions with srv. In watch
+// mode, it tracks the sessions to notify and watches the filesystem while at
+// least one session is connected. With WithRateLimit, each session has the
+// rate limiter of its client.
+func (s *Server) sessionHandler(srv *mcp.Server) transport.SessionHandler {
+	if !s.watch && s.rateLimiter == nil {
+		return srv
+	}
+	return transport.SessionHandlerFunc(func(ctx context.Context, id uint64, t transport.Session) error {
+		ctx = s.withSessionRateLimiter(ctx)
+		if !s.watch {
+			return srv.Serve(ctx, id, t)
+		}
+		session := &lockedSession{Session: t}
+		s.addSession(id, session)
+		defer s.removeSession(id)
+		return srv.Serve(ctx, id, session)
+	})
+}
+
+func (s *Server) addSession(id uint64, session transport.Session) {
+	s.watchMu.Lock()
+	defer s.watchMu.Unlock()
+	if len(s.sessions) == 0 {
+		s.stopWatch = s.startWatching()
+		// Files may have changed while nobody was watching.
+		s.resetAliasIndex()
+		if resources, err := s.resourceList(); err == nil {
+			s.setResources(resources)
+		}
+		s.snapshotFrontmatter()
+	}
+	if s.sessions == nil {
+		s.sessions = map[uint64]transport.Session{}
+	}
+	s.sessions[id] = session
+}
+
+func (s *Server) removeSession(id uint64) {
+	s.watchMu.Lock()
+	delete(s.sessions, id)
+	stop := s.stopWatch
+	if len(s.sessions) > 0 {
+		stop = nil
+	} else {
+		s.stopWatch = nil
+	}
+	s.watchMu.Unlock()
+	if stop != nil {
+		stop()
+	}
+}
+
+// startWatching watches the served directory and its subdirectories,
+// re-indexing when markdown files, configuration files, or directories are
+// created, removed, or renamed, or markdown or configuration files are written. It returns a function that stops watching.
+// If the filesystem is not a directory on disk, it does nothing.
+func (s *Server) startWatching() func() {
+	dir, ok := watchDir(s.fs)
+	if !ok {
+		return func() {}
+	}
+	w, err := fsnotify.NewWatcher()
+	if err != nil {
+		return func() {}
+	}
+	filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err == nil && d.IsDir() {
+			w.Add(path)
+		}
+		return nil
+	})
+
+	r := newReindexer(s.reindex)
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for {
+			select {
+			case event, ok := <-w.Events:
+				if !ok {
+					return
+				}
+				indexed := s.isMarkdown(event.Name) || s.isConfigFile(dir, event.Name)
+				if event.Has(fsnotify.Write) && indexed {
+					r.trigger()
+					continue
+				}
+				if !event.Has(fsnotify.Create) && !event.Has(fsnotify.Remove) && !event.Has(fsnotify.Rename) {
+					continue
+				}
+				if info, err := os.Stat(event.Name); err == nil && info.IsDir() {
+					w.Add(event.Name)
+					r.trigger()
+					continue
+				}
+				// A removed or renamed path may have been a directory of markdown files.
+				if indexed || !event.Has(fsnotify.Create) {
+					r.trigger()
+				}
+			case _, ok := <-w.Errors:
+				if !ok {
+					return
+				}
+			}
+		}
+	}()
+	return func() {
+		w.Close()
+		wg.Wait()
+		r.wait()
+	}
+}
+
+// isConfigFile reports whether name, a path within the watched directory dir,
+// is a file read when indexing: the allowlist, the global defaults file, the
+// navigation order file, or a .gitignore file.
+func (s *Server) isConfigFile(dir, name string) bool {
+	if filepath.Base(name) == gitignoreFile {
+		return s.gitignore
+	}
+	rel, err := filepath.Rel(dir, name)
+	if err != nil {
+		return false
+	}
+	for _, file := range []string{s.allowlistFile, s.globalDefaultsFile, s.navigationOrderFile} {
+		if file != "" && path.Clean(file) == filepath.ToSlash(rel) {
+			return true
+		}
+	}
+	return false
+}
+
+// lockedSession serializes sends on a session, so that notifications do not
+// interleave with responses written by the connection.
+type lockedSession struct {
+	transport.Session
+	mu sync.Mutex
+}
+
+func (l *lockedSession) Send(v json.RawMessage) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.Session.Send(v)
+}
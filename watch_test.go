@@ -0,0 +1,215 @@
+package mcpmds
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/Warashi/go-modelcontextprotocol/transport"
+)
+
+// watchSession is an MCP session with a watching server, for tests.
+type watchSession struct {
+	t        *testing.T
+	ctx      context.Context
+	client   transport.Session
+	messages chan json.RawMessage
+	nextID   int
+}
+
+// startWatchSession connects a session to s, which is closed at the end of
+// the test.
+func startWatchSession(t *testing.T, s *Server) *watchSession {
+	t.Helper()
+	srv, err := s.server()
+	if err != nil {
+		t.Fatalf("server() error = %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	server, client := transport.NewPipe()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		s.sessionHandler(srv).HandleSession(ctx, 1, server)
+	}()
+	t.Cleanup(func() {
+		client.Close()
+		server.Close()
+		<-done
+		cancel()
+	})
+
+	messages := make(chan json.RawMessage)
+	go func() {
+		for msg := range client.Receive() {
+			messages <- msg
+		}
+		close(messages)
+	}()
+	return &watchSession{t: t, ctx: ctx, client: client, messages: messages}
+}
+
+// call sends a request with method and returns its result.
+func (w *watchSession) call(method string) json.RawMessage {
+	w.t.Helper()
+	w.nextID++
+	req, err := json.Marshal(map[string]any{"jsonrpc": "2.0", "id": w.nextID, "method": method, "params": map[string]any{}})
+	if err != nil {
+		w.t.Fatal(err)
+	}
+	go w.client.Send(req)
+	for {
+		select {
+		case msg := <-w.messages:
+			var resp struct {
+				ID     int             `json:"id"`
+				Result json.RawMessage `json:"result"`
+			}
+			if err := json.Unmarshal(msg, &resp); err != nil {
+				w.t.Fatalf("unmarshal message: %v", err)
+			}
+			if resp.ID == w.nextID {
+				return resp.Result
+			}
+		case <-w.ctx.Done():
+			w.t.Fatalf("%s: no response", method)
+		}
+	}
+}
+
+// listURIs returns the URIs of the listed resources.
+func (w *watchSession) listURIs() []string {
+	w.t.Helper()
+	var result struct {
+		Resources []struct {
+			URI string `json:"uri"`
+		} `json:"resources"`
+	}
+	if err := json.Unmarshal(w.call("resources/list"), &result); err != nil {
+		w.t.Fatal(err)
+	}
+	var uris []string
+	for _, r := range result.Resources {
+		uris = append(uris, r.URI)
+	}
+	return uris
+}
+
+// waitForList waits for a list_changed notification after which the listed
+// resources are want.
+func (w *watchSession) waitForList(want []string) {
+	w.t.Helper()
+	for {
+		select {
+		case msg := <-w.messages:
+			var n struct {
+				Method string `json:"method"`
+			}
+			if err := json.Unmarshal(msg, &n); err != nil {
+				w.t.Fatal(err)
+			}
+			if n.Method != "notifications/resources/list_changed" {
+				continue
+			}
+			if got := w.listURIs(); slices.Equal(got, want) {
+				return
+			}
+		case <-w.ctx.Done():
+			w.t.Fatalf("no list_changed notification for %v", want)
+		}
+	}
+}
+
+func TestWithWatch(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "a.md"), []byte("# A\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	s := newServer("test", "test", os.DirFS(dir), WithWatch(true))
+	w := startWatchSession(t, s)
+
+	var init struct {
+		Capabilities struct {
+			Resources *struct {
+				ListChanged bool `json:"listChanged"`
+			} `json:"resources"`
+		} `json:"capabilities"`
+	}
+	if err := json.Unmarshal(w.call("initialize"), &init); err != nil {
+		t.Fatal(err)
+	}
+	if init.Capabilities.Resources == nil || !init.Capabilities.Resources.ListChanged {
+		t.Errorf("initialize capabilities.resources = %+v, want listChanged", init.Capabilities.Resources)
+	}
+
+	if got, want := w.listURIs(), []string{"file://a.md"}; !slices.Equal(got, want) {
+		t.Fatalf("resources before change = %v, want %v", got, want)
+	}
+
+	if err := os.MkdirAll(filepath.Join(dir, "sub"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "sub", "b.md"), []byte("# B\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	w.waitForList([]string{"file://a.md", "file://sub/b.md"})
+}
+
+func TestWithWatch_allowlist(t *testing.T) {
+	dir := t.TempDir()
+	for name, content := range map[string]string{
+		"a.md":           "# A\n",
+		"b.md":           "# B\n",
+		"_published.txt": "a.md\n",
+	} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	s := newServer("test", "test", os.DirFS(dir), WithWatch(true), WithAllowlistFile("_published.txt"))
+	w := startWatchSession(t, s)
+	w.call("initialize")
+	if got, want := w.listURIs(), []string{"file://a.md"}; !slices.Equal(got, want) {
+		t.Fatalf("resources before change = %v, want %v", got, want)
+	}
+
+	// Editing the allowlist in place is a write to a non-markdown file.
+	if err := os.WriteFile(filepath.Join(dir, "_published.txt"), []byte("a.md\nb.md\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	w.waitForList([]string{"file://a.md", "file://b.md"})
+}
+
+func TestWithWatch_notWatchable(t *testing.T) {
+	testFS := fstest.MapFS{"a.md": {Data: []byte("# A\n")}}
+	s := newServer("test", "test", testFS, WithWatch(true))
+	srv, err := s.server()
+	if err != nil {
+		t.Fatalf("server() error = %v", err)
+	}
+	if _, ok := watchDir(testFS); ok {
+		t.Error("watchDir(MapFS) reported a directory")
+	}
+	s.addSession(1, transport.Discard{})
+	s.removeSession(1)
+
+	var result struct {
+		Resources []struct {
+			URI string `json:"uri"`
+		} `json:"resources"`
+	}
+	if err := json.Unmarshal(callMCP(t, srv, "resources/list", map[string]any{}), &result); err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Resources) != 1 || result.Resources[0].URI != "file://a.md" {
+		t.Errorf("resources = %+v, want file://a.md", result.Resources)
+	}
+}
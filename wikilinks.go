@@ -0,0 +1,231 @@
+package mcpmds
+
+import (
+	"cmp"
+	"context"
+	"path"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// wikiLinkPattern matches [[target]], [[target#heading]], and [[target|alias]].
+var wikiLinkPattern = regexp.MustCompile(`\[\[([^\[\]|#]*)(?:#([^\[\]|]*))?(?:\|([^\[\]]*))?\]\]`)
+
+// unresolvedWikiLinkMarker follows wiki-links whose target could not be found
+// when wiki-links are expanded.
+const unresolvedWikiLinkMarker = "<!-- unresolved wiki-link -->"
+
+// linkTextEscaper escapes the brackets that would end a markdown link's text early.
+var linkTextEscaper = strings.NewReplacer("[", `\[`, "]", `\]`)
+
+// wikiLink is a wiki-link found in a markdown body.
+type wikiLink struct {
+	// Target is the linked page name as written.
+	Target string
+	// Heading is the heading after "#", if any.
+	Heading string
+	// Alias is the display text after "|", if any.
+	Alias string
+}
+
+// wikiLinkTarget is a markdown file that wiki-links can resolve to.
+type wikiLinkTarget struct {
+	Path  string
+	Title string
+}
+
+// wikiLinkIndex maps normalized page names to the files they resolve to.
+// Both the path and the base name of each file, without extension, are page
+// names. When several files share a name, the first in walk order wins.
+func (s *Server) wikiLinkIndex(ctx context.Context) (map[string]wikiLinkTarget, error) {
+	index := map[string]wikiLinkTarget{}
+	for f := range s.markdownFiles(ctx) {
+		title, err := s.documentTitle(f)
+		if err != nil {
+			return nil, err
+		}
+		addWikiLinkTarget(index, wikiLinkTarget{Path: f.Path, Title: title})
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return index, nil
+}
+
+// addWikiLinkTarget adds the page names of target to index, keeping earlier
+// files for names already taken.
+func addWikiLinkTarget(index map[string]wikiLinkTarget, target wikiLinkTarget) {
+	withoutExt := strings.TrimSuffix(target.Path, path.Ext(target.Path))
+	for _, name := range []string{withoutExt, path.Base(withoutExt)} {
+		name = strings.ToLower(name)
+		if _, ok := index[name]; !ok {
+			index[name] = target
+		}
+	}
+}
+
+// resolveWikiLink returns the file a wiki-link target names.
+func (s *Server) resolveWikiLink(index map[string]wikiLinkTarget, target string) (wikiLinkTarget, bool) {
+	name := strings.TrimPrefix(strings.TrimSpace(target), "/")
+	if s.isMarkdown(name) {
+		name = strings.TrimSuffix(name, path.Ext(name))
+	}
+	t, ok := index[strings.ToLower(name)]
+	return t, ok
+}
+
+// replaceWikiLinks returns body with each wiki-link outside fenced code blocks
+// and inline code spans replaced by the result of replace.
+func replaceWikiLinks(body []byte, replace func(link wikiLink, raw string) string) []byte {
+	lines := strings.Split(string(body), "\n")
+	for line := range markdownLines(body) {
+		if line.InCode {
+			continue
+		}
+		text := lines[line.Number-1]
+		spans := codeSpanPattern.FindAllStringIndex(text, -1)
+		var b strings.Builder
+		last := 0
+		for _, m := range wikiLinkPattern.FindAllStringSubmatchIndex(text, -1) {
+			if slices.ContainsFunc(spans, func(span []int) bool { return m[0] < span[1] && span[0] < m[1] }) {
+				continue
+			}
+			link := wikiLink{Target: text[m[2]:m[3]]}
+			if m[4] >= 0 {
+				link.Heading = text[m[4]:m[5]]
+			}
+			if m[6] >= 0 {
+				link.Alias = text[m[6]:m[7]]
+			}
+			b.WriteString(text[last:m[0]])
+			b.WriteString(replace(link, text[m[0]:m[1]]))
+			last = m[1]
+		}
+		b.WriteString(text[last:])
+		lines[line.Number-1] = b.String()
+	}
+	return []byte(strings.Join(lines, "\n"))
+}
+
+// expandWikiLinks returns content with each resolvable wiki-link in the body
+// rewritten to a markdown link to the target's resource URI, titled with the
+// alias or the target's title. Unresolvable wiki-links are kept and marked.
+func (s *Server) expandWikiLinks(ctx context.Context, content []byte) ([]byte, error) {
+	index, err := s.wikiLinkIndex(ctx)
+	if err != nil {
+		return nil, err
+	}
+	_, _, body := s.splitFrontmatter(content)
+	head := content[:len(content)-len(body)]
+	body = replaceWikiLinks(body, func(link wikiLink, raw string) string {
+		target, ok := s.resolveWikiLink(index, link.Target)
+		if !ok {
+			return raw + unresolvedWikiLinkMarker
+		}
+		text := cmp.Or(strings.TrimSpace(link.Alias), target.Title, strings.TrimSpace(link.Target))
+		uri := resourceURI(target.Path)
+		if link.Heading != "" {
+			uri += "#" + headingAnchor(link.Heading)
+		}
+		return "[" + linkTextEscaper.Replace(text) + "](" + uri + ")"
+	})
+	return slices.Concat(head, body), nil
+}
+
+// WithWikilinks adds the wiki-links in each file's body, such as [[Note]],
+// [[Note#Section]], and [[Note|alias]], to its metadata as wikilinks. Each
+// target is resolved to a served file whose path or base name without
+// extension matches it case-insensitively; unresolved targets are flagged.
+// Targets are resolved from file paths alone, so a link to a file hidden by
+// its frontmatter, such as a draft, still resolves.
+func WithWikilinks(enabled bool) ServerOption {
+	return func(s *Server) {
+		s.wikilinks = enabled
+	}
+}
+
+// fileWikiLink is a wiki-link reported in file metadata by WithWikilinks.
+type fileWikiLink struct {
+	// Target is the linked page name as written.
+	Target string `json:"target"`
+	// Heading is the heading after "#", if any.
+	Heading string `json:"heading,omitempty"`
+	// Alias is the display text after "|", if any.
+	Alias string `json:"alias,omitempty"`
+	// Path is the served file the target resolves to.
+	Path string `json:"path,omitempty"`
+	// Unresolved reports whether no served file matches the target.
+	Unresolved bool `json:"unresolved,omitempty"`
+}
+
+// bodyWikiLinks returns the wiki-links in body outside code, in document
+// order and not yet resolved.
+func bodyWikiLinks(body []byte) []fileWikiLink {
+	links := []fileWikiLink{}
+	replaceWikiLinks(body, func(link wikiLink, raw string) string {
+		links = append(links, fileWikiLink{
+			Target:     strings.TrimSpace(link.Target),
+			Heading:    strings.TrimSpace(link.Heading),
+			Alias:      strings.TrimSpace(link.Alias),
+			Unresolved: true,
+		})
+		return raw
+	})
+	return links
+}
+
+// wikiLinkResolver resolves the wiki-links of file metadata, building its
+// index of the served files on first use.
+type wikiLinkResolver struct {
+	s     *Server
+	index map[string]wikiLinkTarget
+}
+
+// newWikiLinkResolver returns a resolver for one enumeration of the files,
+// or nil if WithWikilinks is not enabled.
+func (s *Server) newWikiLinkResolver() *wikiLinkResolver {
+	if !s.wikilinks {
+		return nil
+	}
+	return &wikiLinkResolver{s: s}
+}
+
+// resolve sets the paths of the wiki-links whose targets name a served file.
+// A nil resolver leaves links unchanged.
+func (r *wikiLinkResolver) resolve(links []fileWikiLink) error {
+	if r == nil || len(links) == 0 {
+		return nil
+	}
+	if r.index == nil {
+		index, err := r.s.wikiLinkPathIndex()
+		if err != nil {
+			return err
+		}
+		r.index = index
+	}
+	for i, link := range links {
+		if target, ok := r.s.resolveWikiLink(r.index, link.Target); ok {
+			links[i].Path = target.Path
+			links[i].Unresolved = false
+		}
+	}
+	return nil
+}
+
+// wikiLinkPathIndex maps normalized page names to the served files like
+// wikiLinkIndex, using directory entries only, without reading the files.
+func (s *Server) wikiLinkPathIndex() (map[string]wikiLinkTarget, error) {
+	resources, err := s.lazyResourceList()
+	if err != nil {
+		return nil, err
+	}
+	index := map[string]wikiLinkTarget{}
+	for _, r := range resources {
+		p, err := resourcePath(strings.TrimPrefix(r.URI, "file://"))
+		if err == nil && s.isMarkdown(p) {
+			addWikiLinkTarget(index, wikiLinkTarget{Path: p})
+		}
+	}
+	return index, nil
+}
@@ -0,0 +1,149 @@
+package mcpmds
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"testing/fstest"
+)
+
+func Test_server_expandWikiLinks(t *testing.T) {
+	testFS := fstest.MapFS{
+		"index.md":            {Data: []byte("---\ntitle: Home\n---\nSee [[Setup Guide]].\n")},
+		"docs/setup guide.md": {Data: []byte("---\ntitle: Installing the Tool\n---\n# Setup\n")},
+		"notes/faq.md":        {Data: []byte("# Frequently Asked\n")},
+		"notes/why? 100%.md":  {Data: []byte("# Why\n")},
+	}
+
+	tests := []struct {
+		name string
+		body string
+		want string
+	}{
+		{
+			name: "Resolvable wiki-link uses the frontmatter title",
+			body: "See [[Setup Guide]].\n",
+			want: "See [Installing the Tool](file://docs/setup%20guide.md).\n",
+		},
+		{
+			name: "Target path is escaped",
+			body: "[[why? 100%#Details]]\n",
+			want: "[Why](file://notes/why%3F%20100%25.md#details)\n",
+		},
+		{
+			name: "Title falls back to the first heading",
+			body: "Read [[faq]] first.\n",
+			want: "Read [Frequently Asked](file://notes/faq.md) first.\n",
+		},
+		{
+			name: "Path, heading, and alias",
+			body: "[[notes/faq#Getting Started|the FAQ]]\n",
+			want: "[the FAQ](file://notes/faq.md#getting-started)\n",
+		},
+		{
+			name: "Unresolvable wiki-link is marked",
+			body: "See [[Missing Page]].\n",
+			want: "See [[Missing Page]]" + unresolvedWikiLinkMarker + ".\n",
+		},
+		{
+			name: "Code is left unchanged",
+			body: "`[[faq]]`\n```\n[[faq]]\n```\n",
+			want: "`[[faq]]`\n```\n[[faq]]\n```\n",
+		},
+		{
+			name: "Frontmatter is left unchanged",
+			body: "---\ntitle: \"[[faq]]\"\n---\n[[faq]]\n",
+			want: "---\ntitle: \"[[faq]]\"\n---\n[Frequently Asked](file://notes/faq.md)\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Server{fs: testFS}
+			got, err := s.expandWikiLinks(context.Background(), []byte(tt.body))
+			if err != nil {
+				t.Fatalf("expandWikiLinks() error = %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("expandWikiLinks() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_server_readMarkdownFile_expandWikiLinks(t *testing.T) {
+	testFS := fstest.MapFS{
+		"index.md": {Data: []byte("---\ntitle: Home\n---\nSee [[faq]] and [[nowhere]].\n")},
+		"faq.md":   {Data: []byte("---\ntitle: FAQ\n---\nAnswers\n")},
+	}
+	s := &Server{fs: testFS}
+
+	got, err := s.readMarkdownFile(context.Background(), &readMarkdownFileRequest{Path: "index.md", ExpandWikiLinks: true})
+	if err != nil {
+		t.Fatalf("readMarkdownFile() error = %v", err)
+	}
+	want := "---\ntitle: Home\n---\nSee [FAQ](file://faq.md) and [[nowhere]]" + unresolvedWikiLinkMarker + ".\n"
+	if got.Content != want {
+		t.Errorf("readMarkdownFile() content = %q, want %q", got.Content, want)
+	}
+
+	got, err = s.readMarkdownFile(context.Background(), &readMarkdownFileRequest{Path: "index.md"})
+	if err != nil {
+		t.Fatalf("readMarkdownFile() error = %v", err)
+	}
+	if want := string(testFS["index.md"].Data); got.Content != want {
+		t.Errorf("readMarkdownFile() without expansion content = %q, want %q", got.Content, want)
+	}
+}
+
+func TestWithWikilinks(t *testing.T) {
+	testFS := fstest.MapFS{
+		"index.md":            {Data: []byte("---\ntitle: Index\n---\nSee [[Other Note|the other one]], [[notes/deep/Topic#Usage]], and [[missing]].\n\n`[[in code]]`\n")},
+		"notes/Other Note.md": {Data: []byte("Back to [[index]].\n")},
+		"notes/deep/topic.md": {Data: []byte("No links.\n")},
+	}
+
+	want := map[string][]fileWikiLink{
+		"index.md": {
+			{Target: "Other Note", Alias: "the other one", Path: "notes/Other Note.md"},
+			{Target: "notes/deep/Topic", Heading: "Usage", Path: "notes/deep/topic.md"},
+			{Target: "missing", Unresolved: true},
+		},
+		"notes/Other Note.md": {
+			{Target: "index", Path: "index.md"},
+		},
+		"notes/deep/topic.md": {},
+	}
+
+	s := newServer("test", "test", testFS, WithWikilinks(true))
+	resp, err := s.listMarkdownFiles(context.Background(), &listMarkdownFilesRequest{})
+	if err != nil {
+		t.Fatalf("listMarkdownFiles() error = %v", err)
+	}
+	if len(resp.Files) != len(want) {
+		t.Fatalf("listMarkdownFiles() returned %d files, want %d", len(resp.Files), len(want))
+	}
+	for _, f := range resp.Files {
+		if !reflect.DeepEqual(f.Wikilinks, want[f.Path]) {
+			t.Errorf("%s wikilinks = %+v, want %+v", f.Path, f.Wikilinks, want[f.Path])
+		}
+	}
+
+	folder, err := s.folderListing(context.Background(), &folderListingRequest{Path: "notes"})
+	if err != nil {
+		t.Fatalf("folderListing() error = %v", err)
+	}
+	if len(folder.Files) != 1 || !reflect.DeepEqual(folder.Files[0].Wikilinks, want["notes/Other Note.md"]) {
+		t.Errorf("folderListing() files = %+v, want notes/Other Note.md with its wikilinks", folder.Files)
+	}
+
+	resp, err = newServer("test", "test", testFS).listMarkdownFiles(context.Background(), &listMarkdownFilesRequest{})
+	if err != nil {
+		t.Fatalf("listMarkdownFiles() error = %v", err)
+	}
+	for _, f := range resp.Files {
+		if f.Wikilinks != nil {
+			t.Errorf("%s wikilinks = %+v without WithWikilinks, want nil", f.Path, f.Wikilinks)
+		}
+	}
+}
@@ -0,0 +1,20 @@
+package mcpmds
+
+// wordsPerMinute is the reading speed used to estimate reading time.
+const wordsPerMinute = 200
+
+// wordCount returns the number of words in the prose of body, skipping fenced
+// code blocks, inline code, link destinations, and markup.
+func wordCount(body []byte) int {
+	n := 0
+	for _, block := range proseBlocks(body) {
+		n += len(proseWordPattern.FindAllString(block, -1))
+	}
+	return n
+}
+
+// readingTimeMinutes returns the estimated minutes needed to read words,
+// rounded up.
+func readingTimeMinutes(words int) int {
+	return (words + wordsPerMinute - 1) / wordsPerMinute
+}
@@ -0,0 +1,74 @@
+package mcpmds
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func Test_wordCount(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want int
+	}{
+		{name: "empty", body: "", want: 0},
+		{name: "plain prose", body: "one two three", want: 3},
+		{name: "headings and list markers", body: "# Title Here\n\n- first item\n1. second item\n", want: 6},
+		{name: "inline formatting", body: "Some **bold** and _italic_ text with `code` inline", want: 7},
+		{name: "link destination", body: "See [the docs](https://example.com/very/long/path) now", want: 4},
+		{name: "fenced code block", body: "Before\n\n```go\nfunc main() { println(\"hi\") }\n```\n\nAfter", want: 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := wordCount([]byte(tt.body)); got != tt.want {
+				t.Errorf("wordCount() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_readingTimeMinutes(t *testing.T) {
+	tests := []struct {
+		words int
+		want  int
+	}{
+		{words: 0, want: 0},
+		{words: 1, want: 1},
+		{words: 200, want: 1},
+		{words: 201, want: 2},
+		{words: 1000, want: 5},
+	}
+	for _, tt := range tests {
+		if got := readingTimeMinutes(tt.words); got != tt.want {
+			t.Errorf("readingTimeMinutes(%d) = %d, want %d", tt.words, got, tt.want)
+		}
+	}
+}
+
+func TestWordCount_excludesFrontmatter(t *testing.T) {
+	testFS := fstest.MapFS{
+		"long.md": {Data: []byte("---\ntitle: Many words in the title\n---\n" + strings.Repeat("word ", 250))},
+	}
+	s := &Server{fs: testFS}
+
+	list, err := s.listMarkdownFiles(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("listMarkdownFiles() error = %v", err)
+	}
+	if len(list.Files) != 1 {
+		t.Fatalf("listMarkdownFiles() = %+v, want one file", list.Files)
+	}
+	if got := list.Files[0]; got.WordCount != 250 || got.ReadingTimeMinutes != 2 {
+		t.Errorf("list WordCount, ReadingTimeMinutes = %d, %d, want 250, 2", got.WordCount, got.ReadingTimeMinutes)
+	}
+
+	read, err := s.readMarkdownFile(context.Background(), &readMarkdownFileRequest{Path: "long.md"})
+	if err != nil {
+		t.Fatalf("readMarkdownFile() error = %v", err)
+	}
+	if read.WordCount != 250 || read.ReadingTimeMinutes != 2 {
+		t.Errorf("read WordCount, ReadingTimeMinutes = %d, %d, want 250, 2", read.WordCount, read.ReadingTimeMinutes)
+	}
+}